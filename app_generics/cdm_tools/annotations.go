@@ -0,0 +1,220 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Annotations
+ *
+ * This component adds constraints to CDM models, which the CDM models themselves cannot
+ * hold (yet). The annotations of a model are posted next to the model, as the state of a
+ * JSON artefact with the same ID as the model.
+ *
+ * By default, involvement types are neither mandatory nor unique, and have no multiplicity,
+ * while quality types have no reference mode. The annotations are serialised as JSON, only
+ * listing the involvement types for which a constraint holds, and the quality types that
+ * have a reference mode:
+ *
+ *   {
+ *     "mandatory":       {"<involvement type ID>": true, ...},
+ *     "unique":          {"<involvement type ID>": true, ...},
+ *     "multiplicities":  {"<involvement type ID>": {"min": "1", "max": "*"}, ...},
+ *     "reference_modes": {"<quality type ID>": "<reference mode>", ...}
+ *   }
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"encoding/json"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	AnnotationsJSONVersion = "cdm_annotations_v1_0" // JSON version of the posted annotations
+)
+
+/*
+ * Defining annotations
+ */
+
+// The multiplicity of an involvement type: the minimal and maximal number of times each
+// instance of its base type plays it, where "*" stands for any number
+type TCDMMultiplicity struct {
+	Min string `json:"min"` // The minimal number of times
+	Max string `json:"max"` // The maximal number of times, or "*"
+}
+
+// Rendering a multiplicity, such as "1..*", or "1" when the minimum and maximum are the same
+func (m TCDMMultiplicity) String() string {
+	if m.Min == m.Max {
+		return m.Min
+	}
+
+	return m.Min + ".." + m.Max
+}
+
+type TCDMModelAnnotations struct {
+	Mandatory map[string]bool `json:"mandatory,omitempty"` // Involvement types that must be played by each instance of their base type
+	Unique    map[string]bool `json:"unique,omitempty"`    // Involvement types that can be played at most once by each instance of their base type
+
+	Multiplicities map[string]TCDMMultiplicity `json:"multiplicities,omitempty"`  // Multiplicities of involvement types
+	ReferenceModes map[string]string           `json:"reference_modes,omitempty"` // Reference modes of quality types, such as "nr" for a Student Number
+}
+
+// Creating empty annotations
+func CreateCDMModelAnnotations() TCDMModelAnnotations {
+	return TCDMModelAnnotations{
+		Mandatory: map[string]bool{},
+		Unique:    map[string]bool{},
+
+		Multiplicities: map[string]TCDMMultiplicity{},
+		ReferenceModes: map[string]string{},
+	}
+}
+
+// Setting a constraint on an involvement type, only keeping the involvement types for which it holds
+func setConstraint(constraint map[string]bool, involvementType string, holds bool) {
+	if holds {
+		constraint[involvementType] = true
+	} else {
+		delete(constraint, involvementType)
+	}
+}
+
+// Marking an involvement type as mandatory, or not
+func (a *TCDMModelAnnotations) SetMandatory(involvementType string, mandatory bool) {
+	setConstraint(a.Mandatory, involvementType, mandatory)
+}
+
+// Marking an involvement type as unique, or not
+func (a *TCDMModelAnnotations) SetUnique(involvementType string, unique bool) {
+	setConstraint(a.Unique, involvementType, unique)
+}
+
+// Checking if an involvement type is mandatory
+func (a TCDMModelAnnotations) IsMandatory(involvementType string) bool {
+	return a.Mandatory[involvementType]
+}
+
+// Checking if an involvement type is unique
+func (a TCDMModelAnnotations) IsUnique(involvementType string) bool {
+	return a.Unique[involvementType]
+}
+
+// Setting the multiplicity of an involvement type, such as "1" to "*", or removing it when both are empty
+func (a *TCDMModelAnnotations) SetInvolvementMultiplicity(involvementType, min, max string) {
+	if min != "" || max != "" {
+		a.Multiplicities[involvementType] = TCDMMultiplicity{Min: min, Max: max}
+	} else {
+		delete(a.Multiplicities, involvementType)
+	}
+}
+
+// Getting the multiplicity of an involvement type, if any
+func (a TCDMModelAnnotations) InvolvementMultiplicity(involvementType string) (TCDMMultiplicity, bool) {
+	multiplicity, hasMultiplicity := a.Multiplicities[involvementType]
+
+	return multiplicity, hasMultiplicity
+}
+
+// Adding a reference mode to a quality type, or removing it when the mode is empty
+func (a *TCDMModelAnnotations) AddReferenceMode(qualityType, modeName string) {
+	if modeName != "" {
+		a.ReferenceModes[qualityType] = modeName
+	} else {
+		delete(a.ReferenceModes, qualityType)
+	}
+}
+
+// Getting the reference mode of a quality type, if any
+func (a TCDMModelAnnotations) ReferenceMode(qualityType string) string {
+	return a.ReferenceModes[qualityType]
+}
+
+/*
+ * Posting annotations
+ */
+
+type TCDMAnnotationsPoster struct {
+	artefactConnector connect.TModellingBusArtefactConnector // The connector for the annotations artefact
+}
+
+// Posting the annotations as the state of the annotations artefact
+func (p *TCDMAnnotationsPoster) PostAnnotations(annotations TCDMModelAnnotations) {
+	annotationsJSON, err := json.Marshal(annotations)
+	p.artefactConnector.PostJSONArtefactState(annotationsJSON, err == nil)
+}
+
+// Creating a poster for the annotations of the model with the given ID
+func CreateCDMAnnotationsPoster(modellingBusConnector connect.TModellingBusConnector, modelID string) TCDMAnnotationsPoster {
+	return TCDMAnnotationsPoster{
+		artefactConnector: connect.CreateModellingBusArtefactConnector(modellingBusConnector, AnnotationsJSONVersion, modelID),
+	}
+}
+
+/*
+ * Listening for annotations
+ */
+
+type TCDMAnnotationsListener struct {
+	Annotations TCDMModelAnnotations // The most recently received annotations
+	Guard       func(update func())  // Guarding the update of the annotations against concurrent readers; nil for none
+
+	artefactConnector connect.TModellingBusArtefactConnector // The connector for the annotations artefact
+	reporter          *generics.TReporter                    // The Reporter to be used to report progress, errors, and panics
+}
+
+// Updating the annotations from the most recently received posting; returns false if it
+// could not be read
+func (l *TCDMAnnotationsListener) updateAnnotations() bool {
+	annotations := CreateCDMModelAnnotations()
+	if l.reporter.MaybeReportError("Error reading CDM model annotations:", json.Unmarshal(l.artefactConnector.CurrentContent, &annotations)) {
+		return false
+	}
+
+	if l.Guard != nil {
+		l.Guard(func() { l.Annotations = annotations })
+	} else {
+		l.Annotations = annotations
+	}
+
+	return true
+}
+
+// Listening for postings of the annotations of a model, calling the handler after each received posting
+func (l *TCDMAnnotationsListener) ListenForAnnotationsPostings(agentID, modelID string, handler func()) {
+	l.artefactConnector.ListenForJSONArtefactStatePostings(agentID, modelID, func() {
+		if l.updateAnnotations() {
+			handler()
+		}
+	})
+}
+
+// Getting the retained annotations of a model, if posted
+func (l *TCDMAnnotationsListener) GetAnnotations(agentID, modelID string) {
+	l.artefactConnector.GetJSONArtefactState(agentID, modelID)
+
+	// Without a posting, there are no annotations
+	if len(l.artefactConnector.CurrentContent) > 0 {
+		l.updateAnnotations()
+	}
+}
+
+// Creating a listener for the annotations of the model with the given ID
+func CreateCDMAnnotationsListener(modellingBusConnector connect.TModellingBusConnector, modelID string, reporter *generics.TReporter) TCDMAnnotationsListener {
+	return TCDMAnnotationsListener{
+		Annotations:       CreateCDMModelAnnotations(),
+		artefactConnector: connect.CreateModellingBusArtefactConnector(modellingBusConnector, AnnotationsJSONVersion, modelID),
+		reporter:          reporter,
+	}
+}
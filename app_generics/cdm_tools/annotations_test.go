@@ -0,0 +1,124 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Annotations (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Involvement types are neither mandatory nor unique by default, and only the involvement
+// types for which a constraint holds are serialized, surviving a JSON round trip
+func TestAnnotationsConstraintsRoundTrip(t *testing.T) {
+	annotations := CreateCDMModelAnnotations()
+	annotations.SetMandatory("studies", true)
+	annotations.SetUnique("studies", true)
+	annotations.SetMandatory("is studied by", true)
+	annotations.SetUnique("is studied by", true)
+	annotations.SetUnique("is studied by", false)
+
+	annotationsJSON, err := json.Marshal(annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"mandatory":{"is studied by":true,"studies":true},"unique":{"studies":true}}`; string(annotationsJSON) != want {
+		t.Errorf("serialized %s, want %s", annotationsJSON, want)
+	}
+
+	received := CreateCDMModelAnnotations()
+	if err := json.Unmarshal(annotationsJSON, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		involvementType   string
+		mandatory, unique bool
+	}{
+		{"studies", true, true},
+		{"is studied by", true, false},
+		{"teaches", false, false},
+	}
+
+	for _, test := range tests {
+		if got := received.IsMandatory(test.involvementType); got != test.mandatory {
+			t.Errorf("IsMandatory(%q) = %t, want %t", test.involvementType, got, test.mandatory)
+		}
+		if got := received.IsUnique(test.involvementType); got != test.unique {
+			t.Errorf("IsUnique(%q) = %t, want %t", test.involvementType, got, test.unique)
+		}
+	}
+}
+
+// Reference modes can be added to quality types, retrieved, and removed again, surviving a JSON round trip
+func TestAnnotationsReferenceModes(t *testing.T) {
+	annotations := CreateCDMModelAnnotations()
+	annotations.AddReferenceMode("Student Number", "nr")
+	annotations.AddReferenceMode("Student Name", "name")
+	annotations.AddReferenceMode("Student Name", "")
+
+	annotationsJSON, err := json.Marshal(annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"reference_modes":{"Student Number":"nr"}}`; string(annotationsJSON) != want {
+		t.Errorf("serialized %s, want %s", annotationsJSON, want)
+	}
+
+	received := CreateCDMModelAnnotations()
+	if err := json.Unmarshal(annotationsJSON, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	for qualityType, want := range map[string]string{"Student Number": "nr", "Student Name": ""} {
+		if got := received.ReferenceMode(qualityType); got != want {
+			t.Errorf("ReferenceMode(%q) = %q, want %q", qualityType, got, want)
+		}
+	}
+}
+
+// Multiplicities of involvement types survive a JSON round trip, rendered as a range, or as a
+// single number when the minimum and maximum are the same, and can be removed again
+func TestAnnotationsMultiplicities(t *testing.T) {
+	annotations := CreateCDMModelAnnotations()
+	annotations.SetInvolvementMultiplicity("studying", "1", "*")
+	annotations.SetInvolvementMultiplicity("referred", "1", "1")
+	annotations.SetInvolvementMultiplicity("studied by", "0", "*")
+	annotations.SetInvolvementMultiplicity("studied by", "", "")
+
+	annotationsJSON, err := json.Marshal(annotations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	received := CreateCDMModelAnnotations()
+	if err := json.Unmarshal(annotationsJSON, &received); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		involvementType string
+		hasMultiplicity bool
+		want            string
+	}{
+		{"studying", true, "1..*"},
+		{"referred", true, "1"},
+		{"studied by", false, ""},
+	}
+
+	for _, test := range tests {
+		multiplicity, hasMultiplicity := received.InvolvementMultiplicity(test.involvementType)
+		if hasMultiplicity != test.hasMultiplicity || (hasMultiplicity && multiplicity.String() != test.want) {
+			t.Errorf("InvolvementMultiplicity(%q) = %q, %t; want %q, %t", test.involvementType, multiplicity, hasMultiplicity, test.want, test.hasMultiplicity)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Differences
+ *
+ * This component determines the differences between two versions of a CDM model, such
+ * as a model state and its update, in terms of the types and readings that were added,
+ * removed, or renamed.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"fmt"
+	"strings"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining differences
+ */
+
+// An element that is present in both versions, but under a different name
+type TCDMRenaming struct {
+	ID   string // The ID of the element
+	From string // The name in the first version
+	To   string // The name in the second version
+}
+
+// The changes to one kind of element, identified by their IDs
+type TCDMElementChanges struct {
+	Added   []string       // The elements only present in the second version
+	Removed []string       // The elements only present in the first version
+	Renamed []TCDMRenaming // The elements present in both versions, under a different name
+}
+
+// The differences between two versions of a model
+type TCDMModelDiff struct {
+	ConcreteIndividualTypes TCDMElementChanges // Changes to the concrete individual types
+	QualityTypes            TCDMElementChanges // Changes to the quality types
+	RelationTypes           TCDMElementChanges // Changes to the relation types
+	Readings                TCDMElementChanges // Changes to the readings, where a changed sentence counts as a renaming
+}
+
+// Checking if there are no changes
+func (c TCDMElementChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Renamed) == 0
+}
+
+// Checking if the versions are the same, in terms of their types and readings
+func (d TCDMModelDiff) IsEmpty() bool {
+	return d.ConcreteIndividualTypes.IsEmpty() && d.QualityTypes.IsEmpty() && d.RelationTypes.IsEmpty() && d.Readings.IsEmpty()
+}
+
+// Summarising the differences, such as "relation types: +2; readings: +4"
+func (d TCDMModelDiff) String() string {
+	summaries := []string{}
+	for _, kind := range []struct {
+		name    string             // Name of the kind of element
+		changes TCDMElementChanges // Changes to the elements of this kind
+	}{
+		{"concrete individual types", d.ConcreteIndividualTypes},
+		{"quality types", d.QualityTypes},
+		{"relation types", d.RelationTypes},
+		{"readings", d.Readings},
+	} {
+		if kind.changes.IsEmpty() {
+			continue
+		}
+
+		counts := []string{}
+		if len(kind.changes.Added) > 0 {
+			counts = append(counts, fmt.Sprintf("+%d", len(kind.changes.Added)))
+		}
+		if len(kind.changes.Removed) > 0 {
+			counts = append(counts, fmt.Sprintf("-%d", len(kind.changes.Removed)))
+		}
+		if len(kind.changes.Renamed) > 0 {
+			counts = append(counts, fmt.Sprintf("~%d", len(kind.changes.Renamed)))
+		}
+		summaries = append(summaries, kind.name+": "+strings.Join(counts, " "))
+	}
+
+	if len(summaries) == 0 {
+		return "no changes"
+	}
+
+	return strings.Join(summaries, "; ")
+}
+
+/*
+ * Determining differences
+ */
+
+// Determining the differences from model a to model b
+func DiffCDMModels(a, b cdm.TCDMModel) TCDMModelDiff {
+	aQualityTypes, aConcreteIndividualTypes, aRelationTypes := classifyTypes(a)
+	bQualityTypes, bConcreteIndividualTypes, bRelationTypes := classifyTypes(b)
+
+	// Naming the types
+	typeName := func(model cdm.TCDMModel) func(string) string {
+		return func(tpe string) string {
+			return model.TypeName[tpe]
+		}
+	}
+
+	// Naming the readings by their sentence
+	readingSentence := func(model cdm.TCDMModel) func(string) string {
+		return func(reading string) string {
+			return ReadingSentence(model, reading)
+		}
+	}
+
+	return TCDMModelDiff{
+		ConcreteIndividualTypes: diffElements(aConcreteIndividualTypes, bConcreteIndividualTypes, typeName(a), typeName(b)),
+		QualityTypes:            diffElements(aQualityTypes, bQualityTypes, typeName(a), typeName(b)),
+		RelationTypes:           diffElements(aRelationTypes, bRelationTypes, typeName(a), typeName(b)),
+		Readings:                diffElements(sortedKeys(a.ReadingDefinition), sortedKeys(b.ReadingDefinition), readingSentence(a), readingSentence(b)),
+	}
+}
+
+// Determining the changes between two lists of element IDs, named by the given functions
+func diffElements(aElements, bElements []string, aName, bName func(string) string) TCDMElementChanges {
+	changes := TCDMElementChanges{}
+
+	// Indexing the elements of a
+	inA := map[string]bool{}
+	for _, element := range aElements {
+		inA[element] = true
+	}
+
+	// Finding the added and renamed elements
+	inB := map[string]bool{}
+	for _, element := range bElements {
+		inB[element] = true
+
+		if !inA[element] {
+			changes.Added = append(changes.Added, element)
+		} else if aName(element) != bName(element) {
+			changes.Renamed = append(changes.Renamed, TCDMRenaming{ID: element, From: aName(element), To: bName(element)})
+		}
+	}
+
+	// Finding the removed elements
+	for _, element := range aElements {
+		if !inB[element] {
+			changes.Removed = append(changes.Removed, element)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,100 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Differences (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"slices"
+	"testing"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// Copying a model, so both copies share the same element IDs
+func copyModel(t *testing.T, model cdm.TCDMModel) cdm.TCDMModel {
+	modelJSON, ok := model.GetModelAsJSON()
+	copied := cdm.CreateCDMModel(testReporter(t))
+	if !ok || !copied.SetModelFromJSON(modelJSON) {
+		t.Fatal("could not copy the model")
+	}
+
+	return copied
+}
+
+// The transition of the university scenario from the basic university to the university only
+// adds its relation types and their readings
+func TestDiffCDMModels(t *testing.T) {
+	scenario := UniversityScenario()
+	model := cdm.CreateCDMModel(testReporter(t))
+	annotations := CreateCDMModelAnnotations()
+
+	RunScenario(scenario[:3], &model, &annotations, TNoPoster{})
+	basicUniversity := copyModel(t, model)
+	RunScenario(scenario[3:4], &model, &annotations, TNoPoster{})
+
+	diff := DiffCDMModels(basicUniversity, model)
+	if !diff.ConcreteIndividualTypes.IsEmpty() || !diff.QualityTypes.IsEmpty() {
+		t.Errorf("changed the concrete individual types or quality types: %+v", diff)
+	}
+	if len(diff.RelationTypes.Removed) > 0 || len(diff.RelationTypes.Renamed) > 0 || len(diff.Readings.Removed) > 0 || len(diff.Readings.Renamed) > 0 {
+		t.Errorf("removed or renamed relation types or readings: %+v", diff)
+	}
+
+	relationTypes := []string{}
+	for _, relationType := range diff.RelationTypes.Added {
+		relationTypes = append(relationTypes, model.TypeName[relationType])
+	}
+	slices.Sort(relationTypes)
+	if want := []string{"Programme Naming", "Student Naming", "Studies"}; !slices.Equal(relationTypes, want) {
+		t.Errorf("added relation types %q, want %q", relationTypes, want)
+	}
+
+	readings := []string{}
+	for _, reading := range diff.Readings.Added {
+		readings = append(readings, ReadingSentence(model, reading))
+	}
+	if !slices.Contains(readings, "Student studies Study Programme") || len(readings) != 6 {
+		t.Errorf("added readings %q, want the six readings of the relation types", readings)
+	}
+
+	if want := "relation types: +3; readings: +6"; diff.String() != want {
+		t.Errorf("diff summary = %q, want %q", diff.String(), want)
+	}
+}
+
+// Renaming and removing types are differences too, while a model does not differ from itself
+func TestDiffCDMModelsRenamedAndRemoved(t *testing.T) {
+	model := BuildUniversityModel(testReporter(t))
+	if diff := DiffCDMModels(model, model); !diff.IsEmpty() || diff.String() != "no changes" {
+		t.Errorf("model differs from itself: %s", diff)
+	}
+
+	renamed := copyModel(t, model)
+	lecturer := renamed.AddConcreteIndividualType("Lecturer")
+	for tpe, name := range renamed.TypeName {
+		if name == "Student Name" {
+			renamed.TypeName[tpe] = "Full Name"
+		}
+	}
+
+	diff := DiffCDMModels(model, renamed)
+	if !slices.Equal(diff.ConcreteIndividualTypes.Added, []string{lecturer}) {
+		t.Errorf("added concrete individual types %q, want %q", diff.ConcreteIndividualTypes.Added, lecturer)
+	}
+	if len(diff.QualityTypes.Renamed) != 1 || diff.QualityTypes.Renamed[0].From != "Student Name" || diff.QualityTypes.Renamed[0].To != "Full Name" {
+		t.Errorf("renamed quality types %+v, want Student Name to Full Name", diff.QualityTypes.Renamed)
+	}
+
+	if reverse := DiffCDMModels(renamed, model); !slices.Equal(reverse.ConcreteIndividualTypes.Removed, []string{lecturer}) {
+		t.Errorf("removed concrete individual types %q, want %q", reverse.ConcreteIndividualTypes.Removed, lecturer)
+	}
+}
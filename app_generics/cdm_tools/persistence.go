@@ -0,0 +1,91 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Persistence
+ *
+ * This component saves CDM models to, and loads them from, files, so models can be
+ * maintained on disk and posted from there.
+ *
+ * The models are stored as JSON objects, with the following fields:
+ *   - file_version: the version of the file format (see ModelFileVersion);
+ *   - model: the model itself, in the JSON structure of the CDM models posted on the modelling bus.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	ModelFileVersion = "cdm_model_file_v1_1" // Version of the file format for CDM models
+)
+
+/*
+ * Defining model files
+ */
+
+// A model, as stored in a model file
+type TCDMModelFile struct {
+	FileVersion string        `json:"file_version"` // Version of the file format
+	Model       cdm.TCDMModel `json:"model"`        // The model
+}
+
+/*
+ * Saving and loading models
+ */
+
+// Saving a model to the given file
+func SaveCDMModel(model cdm.TCDMModel, path string) error {
+	modelFile := TCDMModelFile{
+		FileVersion: ModelFileVersion,
+		Model:       model,
+	}
+
+	// Encoding the model
+	content, err := json.MarshalIndent(modelFile, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Writing the model
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// Loading a model from the given file
+func LoadCDMModel(path string, reporter *generics.TReporter) (cdm.TCDMModel, error) {
+	// Reading the model file
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cdm.CreateCDMModel(reporter), err
+	}
+
+	// Decoding the model file into an empty model, so parts missing from the file remain empty
+	modelFile := TCDMModelFile{Model: cdm.CreateCDMModel(reporter)}
+	if err := json.Unmarshal(content, &modelFile); err != nil {
+		return cdm.CreateCDMModel(reporter), fmt.Errorf("decoding model file %s: %w", path, err)
+	}
+
+	// Only accepting the known file format
+	if modelFile.FileVersion != ModelFileVersion {
+		return cdm.CreateCDMModel(reporter), fmt.Errorf("unsupported model file version in %s: %q", path, modelFile.FileVersion)
+	}
+
+	// Returning the loaded model
+	return modelFile.Model, nil
+}
@@ -0,0 +1,122 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Persistence (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// Creating a reporter for the tests, failing the test on reported errors
+func testReporter(t *testing.T) *generics.TReporter {
+	return generics.CreateReporter(generics.ProgressLevelBasic,
+		func(message string) { t.Errorf("reported error: %s", message) },
+		func(string) {})
+}
+
+// Creating a model with all kinds of types, and a relation type with two readings
+func testModel(reporter *generics.TReporter) cdm.TCDMModel {
+	model := cdm.CreateCDMModel(reporter)
+	model.SetModelName("Library")
+
+	book := model.AddConcreteIndividualType("Book")
+	title := model.AddQualityType("Title", "String")
+	hasTitle := model.AddInvolvementType("has", book)
+	isTitleOf := model.AddInvolvementType("is of", title)
+	bookTitle := model.AddRelationType("Book title", hasTitle, isTitleOf)
+	model.AddRelationTypeReading(bookTitle, "", hasTitle, " has title ", isTitleOf, "")
+	model.AddRelationTypeReading(bookTitle, "", isTitleOf, " is the title of ", hasTitle, "")
+
+	return model
+}
+
+// Saving and loading a model yields the same model
+func TestSaveLoadCDMModelRoundTrip(t *testing.T) {
+	reporter := testReporter(t)
+	model := testModel(reporter)
+	path := filepath.Join(t.TempDir(), "model.json")
+
+	if err := SaveCDMModel(model, path); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+
+	loaded, err := LoadCDMModel(path, reporter)
+	if err != nil {
+		t.Fatalf("loading: %v", err)
+	}
+
+	tests := []struct {
+		part        string
+		saved, read any
+	}{
+		{"model name", model.ModelName, loaded.ModelName},
+		{"type names", model.TypeName, loaded.TypeName},
+		{"concrete individual types", model.ConcreteIndividualTypes, loaded.ConcreteIndividualTypes},
+		{"quality types", model.QualityTypes, loaded.QualityTypes},
+		{"domains of quality types", model.DomainOfQualityType, loaded.DomainOfQualityType},
+		{"involvement types", model.InvolvementTypes, loaded.InvolvementTypes},
+		{"base types of involvement types", model.BaseTypeOfInvolvementType, loaded.BaseTypeOfInvolvementType},
+		{"relation types of involvement types", model.RelationTypeOfInvolvementType, loaded.RelationTypeOfInvolvementType},
+		{"relation types", model.RelationTypes, loaded.RelationTypes},
+		{"involvement types of relation types", model.InvolvementTypesOfRelationType, loaded.InvolvementTypesOfRelationType},
+		{"alternative readings of relation types", model.AlternativeReadingsOfRelationType, loaded.AlternativeReadingsOfRelationType},
+		{"primary readings of relation types", model.PrimaryReadingOfRelationType, loaded.PrimaryReadingOfRelationType},
+		{"reading definitions", model.ReadingDefinition, loaded.ReadingDefinition},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.saved, test.read) {
+			t.Errorf("%s: saved %v, loaded %v", test.part, test.saved, test.read)
+		}
+	}
+}
+
+// Loading fails for files that are not model files of the known version
+func TestLoadCDMModelErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"not JSON", "model", "decoding model file"},
+		{"unknown version", `{"file_version": "cdm_model_file_v0_9", "model": {}}`, "unsupported model file version"},
+		{"no version", `{"model": {}}`, "unsupported model file version"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "model.json")
+			if err := os.WriteFile(path, []byte(test.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadCDMModel(path, testReporter(t))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// Loading a missing file fails
+func TestLoadCDMModelMissingFile(t *testing.T) {
+	if _, err := LoadCDMModel(filepath.Join(t.TempDir(), "missing.json"), testReporter(t)); !os.IsNotExist(err) {
+		t.Fatalf("got error %v, want a missing file error", err)
+	}
+}
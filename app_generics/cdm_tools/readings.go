@@ -0,0 +1,59 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Readings
+ *
+ * This component renders the readings of CDM relation types as plain natural-language sentences.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"strings"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Rendering readings as sentences
+ */
+
+// Rendering a relation type reading as a natural-language sentence, such as "Student studies Study Programme"
+func ReadingSentence(model cdm.TCDMModel, reading string) string {
+	readingDefinition := model.ReadingDefinition[reading]
+
+	// Collecting the words of the sentence
+	words := []string{}
+	addWords := func(text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			words = append(words, text)
+		}
+	}
+
+	// Interleaving the reading elements with the names of the base types of the involvement types
+	for involvementPosition, involvementType := range readingDefinition.InvolvementTypes {
+		if involvementPosition == 0 && len(readingDefinition.ReadingElements) > 0 {
+			addWords(readingDefinition.ReadingElements[0])
+		}
+
+		addWords(model.TypeName[model.BaseTypeOfInvolvementType[involvementType]])
+
+		if involvementPosition+1 < len(readingDefinition.ReadingElements) {
+			addWords(readingDefinition.ReadingElements[involvementPosition+1])
+		}
+	}
+
+	// Returning the sentence
+	return strings.Join(words, " ")
+}
+
+// Rendering the primary reading of a relation type as a natural-language sentence
+func PrimaryReadingSentence(model cdm.TCDMModel, relationType string) string {
+	return ReadingSentence(model, model.PrimaryReadingOfRelationType[relationType])
+}
@@ -0,0 +1,47 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Readings (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"slices"
+	"testing"
+)
+
+// Rendering the readings of the "Studies" relation type of the university model as sentences,
+// without braces or the names of the involvement types
+func TestReadingSentence(t *testing.T) {
+	model := BuildUniversityModel(testReporter(t))
+
+	studies := ""
+	for relationType := range model.RelationTypes {
+		if model.TypeName[relationType] == "Studies" {
+			studies = relationType
+		}
+	}
+	if studies == "" {
+		t.Fatal("no Studies relation type in the university model")
+	}
+
+	if got, want := PrimaryReadingSentence(model, studies), "Student studies Study Programme"; got != want {
+		t.Errorf("PrimaryReadingSentence() = %q, want %q", got, want)
+	}
+
+	sentences := []string{}
+	for reading := range model.AlternativeReadingsOfRelationType[studies] {
+		sentences = append(sentences, ReadingSentence(model, reading))
+	}
+	slices.Sort(sentences)
+	if want := []string{"Student studies Study Programme", "Study Programme studied by Student"}; !slices.Equal(sentences, want) {
+		t.Errorf("ReadingSentence() of the alternative readings = %q, want %q", sentences, want)
+	}
+}
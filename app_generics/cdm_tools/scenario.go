@@ -0,0 +1,227 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Scenarios
+ *
+ * This component defines scripted scenarios of CDM model postings as data: a sequence
+ * of steps, each changing the model (and possibly its annotations) and then posting it
+ * as a state, an update, or a considered variant.
+ * The scenarios can be run against the modelling bus, or headless against any poster.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining scenarios
+ */
+
+// The kind of posting concluding a step
+type TPostKind int
+
+const (
+	PostState       TPostKind = iota // Posting the model as a state
+	PostUpdate                       // Posting the model as an update
+	PostConsidering                  // Posting the model as a considered variant
+)
+
+// Naming the kind of posting
+func (k TPostKind) String() string {
+	switch k {
+	case PostUpdate:
+		return "update"
+	case PostConsidering:
+		return "considering"
+	default:
+		return "state"
+	}
+}
+
+// A step in a scenario
+type TScenarioStep struct {
+	Description string                      // Description of the step
+	Mutate      func(*cdm.TCDMModel)        // Changing the model; nil for no changes
+	Annotate    func(*TCDMModelAnnotations) // Changing the annotations of the model; nil for no changes
+	Post        TPostKind                   // The kind of posting of the changed model
+}
+
+// The posting functionality needed to run a scenario, as offered by TScenarioBusPoster
+type TScenarioPoster interface {
+	PostState(cdm.TCDMModel)
+	PostUpdate(cdm.TCDMModel)
+	PostConsidering(cdm.TCDMModel)
+	PostAnnotations(TCDMModelAnnotations)
+}
+
+// Posting scenarios on the modelling bus, combining the CDM model poster with the annotations poster
+type TScenarioBusPoster struct {
+	cdm.TCDMModelPoster   // The CDM model poster
+	TCDMAnnotationsPoster // The annotations poster
+}
+
+// Creating a poster for running scenarios on the modelling bus, for the model with the given ID
+func CreateScenarioBusPoster(modellingBusConnector connect.TModellingBusConnector, modelID string) *TScenarioBusPoster {
+	return &TScenarioBusPoster{
+		TCDMModelPoster:       cdm.CreateCDMPoster(modellingBusConnector, modelID),
+		TCDMAnnotationsPoster: CreateCDMAnnotationsPoster(modellingBusConnector, modelID),
+	}
+}
+
+// A poster that does not post, for running scenarios without the modelling bus
+type TNoPoster struct{}
+
+func (TNoPoster) PostState(cdm.TCDMModel)              {}
+func (TNoPoster) PostUpdate(cdm.TCDMModel)             {}
+func (TNoPoster) PostConsidering(cdm.TCDMModel)        {}
+func (TNoPoster) PostAnnotations(TCDMModelAnnotations) {}
+
+/*
+ * Running scenarios
+ */
+
+// Running a single step of a scenario
+func RunScenarioStep(step TScenarioStep, model *cdm.TCDMModel, annotations *TCDMModelAnnotations, poster TScenarioPoster) {
+	// Changing the model
+	if step.Mutate != nil {
+		step.Mutate(model)
+	}
+
+	// Changing the annotations, and posting them ahead of the model they refer to
+	if step.Annotate != nil {
+		step.Annotate(annotations)
+		poster.PostAnnotations(*annotations)
+	}
+
+	// Posting the model
+	switch step.Post {
+	case PostUpdate:
+		poster.PostUpdate(*model)
+	case PostConsidering:
+		poster.PostConsidering(*model)
+	default:
+		poster.PostState(*model)
+	}
+}
+
+// Running all steps of a scenario, without pausing
+func RunScenario(steps []TScenarioStep, model *cdm.TCDMModel, annotations *TCDMModelAnnotations, poster TScenarioPoster) {
+	for _, step := range steps {
+		RunScenarioStep(step, model, annotations, poster)
+	}
+}
+
+// Running all steps of a scenario as posting stages, calling pause between the stages.
+// Each stage is announced on the output before it is run, and reported once it is posted.
+func PostModelStages(steps []TScenarioStep, model *cdm.TCDMModel, annotations *TCDMModelAnnotations, poster TScenarioPoster, pause func(), output io.Writer) {
+	for stepNumber, step := range steps {
+		if stepNumber > 0 {
+			pause()
+		}
+
+		fmt.Fprintln(output, step.Description)
+		RunScenarioStep(step, model, annotations, poster)
+		fmt.Fprintln(output, "Posted "+step.Post.String())
+	}
+}
+
+// Building the final model of the university scenario, without posting it
+func BuildUniversityModel(reporter *generics.TReporter) cdm.TCDMModel {
+	CDMModel := cdm.CreateCDMModel(reporter)
+	annotations := CreateCDMModelAnnotations()
+	RunScenario(UniversityScenario(), &CDMModel, &annotations, TNoPoster{})
+
+	return CDMModel
+}
+
+/*
+ * The university scenario
+ */
+
+// The scripted university scenario, gradually growing from an empty model.
+// The steps share the IDs of the types they create, so they must be run in order, on a fresh model.
+func UniversityScenario() []TScenarioStep {
+	var Student, StudyProgramme, StudentName, StudyProgrammeName string
+	var StudentStudying, StudentReferred, StudyProgrammeReferred string
+
+	return []TScenarioStep{
+		{
+			Description: "1) empty model",
+			Mutate: func(CDMModel *cdm.TCDMModel) {
+				CDMModel.SetModelName("Empty university")
+			},
+			Post: PostState,
+		},
+		{
+			Description: "2) basic model",
+			Mutate: func(CDMModel *cdm.TCDMModel) {
+				Student = CDMModel.AddConcreteIndividualType("Student")
+				StudyProgramme = CDMModel.AddConcreteIndividualType("Study Programme")
+				StudentName = CDMModel.AddQualityType("Student Name", "string")
+				StudyProgrammeName = CDMModel.AddQualityType("Study Programme Name", "string")
+				CDMModel.SetModelName("Basic university")
+			},
+			Post: PostUpdate,
+		},
+		{
+			Description: "3) basic model",
+			Post:        PostState,
+		},
+		{
+			Description: "4) larger model",
+			Mutate: func(CDMModel *cdm.TCDMModel) {
+				StudyProgrammeStudied := CDMModel.AddInvolvementType("studied by", StudyProgramme)
+				StudentStudying = CDMModel.AddInvolvementType("studying", Student)
+				Studies := CDMModel.AddRelationType("Studies", StudyProgrammeStudied, StudentStudying)
+				CDMModel.AddRelationTypeReading(Studies, "", StudentStudying, "studies", StudyProgrammeStudied, "")
+				CDMModel.AddRelationTypeReading(Studies, "", StudyProgrammeStudied, "studied by", StudentStudying, "")
+
+				StudentReferred = CDMModel.AddInvolvementType("referred", Student)
+				StudentNameReferring := CDMModel.AddInvolvementType("referring", StudentName)
+				StudentNaming := CDMModel.AddRelationType("Student Naming", StudentReferred, StudentNameReferring)
+				CDMModel.AddRelationTypeReading(StudentNaming, "", StudentReferred, "has", StudentNameReferring, "")
+				CDMModel.AddRelationTypeReading(StudentNaming, "", StudentNameReferring, "of", StudentReferred, "")
+
+				StudyProgrammeReferred = CDMModel.AddInvolvementType("referred", StudyProgramme)
+				StudyProgrammeNameReferring := CDMModel.AddInvolvementType("referring", StudyProgrammeName)
+				StudyProgrammeNaming := CDMModel.AddRelationType("Programme Naming", StudyProgrammeReferred, StudyProgrammeNameReferring)
+				CDMModel.AddRelationTypeReading(StudyProgrammeNaming, "", StudyProgrammeReferred, "goes by", StudyProgrammeNameReferring, "")
+				CDMModel.AddRelationTypeReading(StudyProgrammeNaming, "", StudyProgrammeNameReferring, "of", StudyProgrammeReferred, "")
+				CDMModel.SetModelName("University")
+			},
+			Annotate: func(annotations *TCDMModelAnnotations) {
+				// Each student studies some programme, and has exactly one name
+				annotations.SetMandatory(StudentStudying, true)
+				annotations.SetInvolvementMultiplicity(StudentStudying, "1", "*")
+				annotations.SetMandatory(StudentReferred, true)
+				annotations.SetUnique(StudentReferred, true)
+
+				// Each study programme has exactly one name
+				annotations.SetMandatory(StudyProgrammeReferred, true)
+				annotations.SetUnique(StudyProgrammeReferred, true)
+
+				// Students and study programmes are referred to by their names
+				annotations.AddReferenceMode(StudentName, "name")
+				annotations.AddReferenceMode(StudyProgrammeName, "name")
+			},
+			Post: PostUpdate,
+		},
+		{
+			Description: "5) final model",
+			Post:        PostState,
+		},
+	}
+}
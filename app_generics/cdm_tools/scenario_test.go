@@ -0,0 +1,77 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Scenarios (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// A poster recording what is posted, for checking the posting stages
+type TRecordingPoster struct {
+	posted []string // The kinds of the postings, in order
+}
+
+func (r *TRecordingPoster) PostState(cdm.TCDMModel)       { r.posted = append(r.posted, "state") }
+func (r *TRecordingPoster) PostUpdate(cdm.TCDMModel)      { r.posted = append(r.posted, "update") }
+func (r *TRecordingPoster) PostConsidering(cdm.TCDMModel) { r.posted = append(r.posted, "considering") }
+func (r *TRecordingPoster) PostAnnotations(TCDMModelAnnotations) {
+	r.posted = append(r.posted, "annotations")
+}
+
+// Building the university model yields the expected numbers of types
+func TestBuildUniversityModel(t *testing.T) {
+	model := BuildUniversityModel(testReporter(t))
+
+	tests := []struct {
+		types string
+		got   int
+		want  int
+	}{
+		{"concrete individual types", len(model.ConcreteIndividualTypes), 2},
+		{"quality types", len(model.QualityTypes), 2},
+		{"relation types", len(model.RelationTypes), 3},
+		{"involvement types", len(model.InvolvementTypes), 6},
+	}
+
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %d %s, want %d", test.got, test.types, test.want)
+		}
+	}
+
+	if model.ModelName != "University" {
+		t.Errorf("got model name %q, want %q", model.ModelName, "University")
+	}
+}
+
+// Posting the stages of the university scenario, with a pause between each two stages
+func TestPostModelStages(t *testing.T) {
+	reporter := testReporter(t)
+	model := cdm.CreateCDMModel(reporter)
+	annotations := CreateCDMModelAnnotations()
+	poster := &TRecordingPoster{}
+	pauses := 0
+
+	PostModelStages(UniversityScenario(), &model, &annotations, poster, func() { pauses++ }, io.Discard)
+
+	if want := []string{"state", "update", "state", "annotations", "update", "state"}; !slices.Equal(poster.posted, want) {
+		t.Errorf("posted %v, want %v", poster.posted, want)
+	}
+	if pauses != 4 {
+		t.Errorf("paused %d times, want 4", pauses)
+	}
+}
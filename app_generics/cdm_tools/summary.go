@@ -0,0 +1,80 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Summaries
+ *
+ * This component writes human-readable textual summaries of CDM models, for quick inspection.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Classifying types
+ */
+
+// Listing the quality types, concrete individual types, and relation types of a model, sorted by name
+func classifyTypes(model cdm.TCDMModel) (qualityTypes, concreteIndividualTypes, relationTypes []string) {
+	for tpe := range model.TypeName {
+		_, isQualityType := model.DomainOfQualityType[tpe]
+		_, isInvolvementType := model.BaseTypeOfInvolvementType[tpe]
+		_, isRelationType := model.PrimaryReadingOfRelationType[tpe]
+
+		switch {
+		case isQualityType:
+			qualityTypes = append(qualityTypes, tpe)
+		case isRelationType:
+			relationTypes = append(relationTypes, tpe)
+		case !isInvolvementType:
+			concreteIndividualTypes = append(concreteIndividualTypes, tpe)
+		}
+	}
+
+	// Sorting by name
+	for _, types := range [][]string{qualityTypes, concreteIndividualTypes, relationTypes} {
+		sort.Slice(types, func(i, j int) bool {
+			return model.TypeName[types[i]] < model.TypeName[types[j]]
+		})
+	}
+
+	return qualityTypes, concreteIndividualTypes, relationTypes
+}
+
+/*
+ * Writing summaries
+ */
+
+// Writing a textual summary of a model, with its types and, in sentence form, the primary readings of its relation types
+func WriteModelSummary(w io.Writer, model cdm.TCDMModel) {
+	qualityTypes, concreteIndividualTypes, relationTypes := classifyTypes(model)
+
+	fmt.Fprintf(w, "Model: %s\n", model.ModelName)
+
+	fmt.Fprintln(w, "Concrete individual types:")
+	for _, tpe := range concreteIndividualTypes {
+		fmt.Fprintf(w, "  - %s\n", model.TypeName[tpe])
+	}
+
+	fmt.Fprintln(w, "Quality types:")
+	for _, tpe := range qualityTypes {
+		fmt.Fprintf(w, "  - %s (%s)\n", model.TypeName[tpe], model.DomainOfQualityType[tpe])
+	}
+
+	fmt.Fprintln(w, "Relation types:")
+	for _, tpe := range relationTypes {
+		fmt.Fprintf(w, "  - %s: %s\n", model.TypeName[tpe], PrimaryReadingSentence(model, tpe))
+	}
+}
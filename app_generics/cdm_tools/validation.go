@@ -0,0 +1,183 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Validation
+ *
+ * This component checks the consistency of CDM models, as the CDM model builder accepts
+ * any combination of types and readings, and supports refusing to post inconsistent models.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	minimalInvolvementTypes = 2 // Minimal number of involvement types of a relation type
+)
+
+/*
+ * Validating models
+ */
+
+// Validating a CDM model, returning the inconsistencies found (if any), where:
+//   - every relation type has at least two involvement types;
+//   - the involvement types of every reading belong to a single relation type;
+//   - every quality type has a domain;
+//   - the names of the concrete individual types, quality types, and relation types are unique.
+//
+// The involvement types of a relation type are taken from its primary reading, which
+// involves all of them.
+func ValidateCDMModel(model cdm.TCDMModel) []error {
+	errors := []error{}
+
+	// Determining the relation type of each involvement type
+	relationTypeOfInvolvementType := map[string]string{}
+	for _, relationType := range sortedKeys(model.PrimaryReadingOfRelationType) {
+		readingDefinition, defined := model.ReadingDefinition[model.PrimaryReadingOfRelationType[relationType]]
+		if !defined {
+			errors = append(errors, fmt.Errorf("relation type %q has an undefined primary reading", model.TypeName[relationType]))
+
+			continue
+		}
+
+		if len(readingDefinition.InvolvementTypes) < minimalInvolvementTypes {
+			errors = append(errors, fmt.Errorf("relation type %q has %d involvement type(s), where at least %d are needed",
+				model.TypeName[relationType], len(readingDefinition.InvolvementTypes), minimalInvolvementTypes))
+		}
+
+		for _, involvementType := range readingDefinition.InvolvementTypes {
+			relationTypeOfInvolvementType[involvementType] = relationType
+		}
+	}
+
+	// Checking the involvement types of the readings
+	for _, reading := range sortedKeys(model.ReadingDefinition) {
+		readingRelationType := ""
+		for _, involvementType := range model.ReadingDefinition[reading].InvolvementTypes {
+			if _, known := model.BaseTypeOfInvolvementType[involvementType]; !known {
+				errors = append(errors, fmt.Errorf("reading %q refers to unknown involvement type %s", ReadingSentence(model, reading), involvementType))
+
+				continue
+			}
+
+			relationType := relationTypeOfInvolvementType[involvementType]
+			if readingRelationType == "" {
+				readingRelationType = relationType
+			}
+
+			if relationType == "" || relationType != readingRelationType {
+				errors = append(errors, fmt.Errorf("reading %q involves %q, which is not part of its relation type",
+					ReadingSentence(model, reading), model.TypeName[involvementType]))
+			}
+		}
+	}
+
+	// Checking the domains of the quality types
+	for _, qualityType := range sortedKeys(model.DomainOfQualityType) {
+		if model.DomainOfQualityType[qualityType] == "" {
+			errors = append(errors, fmt.Errorf("quality type %q has no domain", model.TypeName[qualityType]))
+		}
+	}
+
+	// Checking the uniqueness of the names
+	qualityTypes, concreteIndividualTypes, relationTypes := classifyTypes(model)
+	namedTypes := map[string]bool{}
+	for _, types := range [][]string{concreteIndividualTypes, qualityTypes, relationTypes} {
+		for _, tpe := range types {
+			name := model.TypeName[tpe]
+			if namedTypes[name] {
+				errors = append(errors, fmt.Errorf("type name %q is used more than once", name))
+			}
+			namedTypes[name] = true
+		}
+	}
+
+	return errors
+}
+
+/*
+ * Refusing to post inconsistent models
+ */
+
+// A poster that only posts models that pass validation, reporting the inconsistencies otherwise
+type TValidatingPoster struct {
+	TScenarioPoster // The poster to post valid models with
+
+	reporter *generics.TReporter // The Reporter to be used to report inconsistencies
+}
+
+// Reporting the inconsistencies of a model, if any. Returns true if the model is valid.
+func (p *TValidatingPoster) reportValidity(model cdm.TCDMModel, kind TPostKind) bool {
+	errors := ValidateCDMModel(model)
+	for _, err := range errors {
+		p.reporter.Error("Invalid model: %s", err)
+	}
+
+	if len(errors) > 0 {
+		p.reporter.Error("Not posting the %s of inconsistent model %s.", kind, model.ModelName)
+
+		return false
+	}
+
+	return true
+}
+
+// Posting the model as a state, if it is valid
+func (p *TValidatingPoster) PostState(model cdm.TCDMModel) {
+	if p.reportValidity(model, PostState) {
+		p.TScenarioPoster.PostState(model)
+	}
+}
+
+// Posting the model as an update, if it is valid
+func (p *TValidatingPoster) PostUpdate(model cdm.TCDMModel) {
+	if p.reportValidity(model, PostUpdate) {
+		p.TScenarioPoster.PostUpdate(model)
+	}
+}
+
+// Posting the model as a considered variant, if it is valid
+func (p *TValidatingPoster) PostConsidering(model cdm.TCDMModel) {
+	if p.reportValidity(model, PostConsidering) {
+		p.TScenarioPoster.PostConsidering(model)
+	}
+}
+
+// Creating a poster that validates the models before posting them with the given poster
+func CreateValidatingPoster(poster TScenarioPoster, reporter *generics.TReporter) *TValidatingPoster {
+	return &TValidatingPoster{
+		TScenarioPoster: poster,
+		reporter:        reporter,
+	}
+}
+
+/*
+ * Support functions
+ */
+
+// Listing the keys of a map, in sorted order
+func sortedKeys[V any](elements map[string]V) []string {
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
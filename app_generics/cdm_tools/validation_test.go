@@ -0,0 +1,121 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic CDM functionality shared by the Modelling Bus Apps
+ * Component:   Validation (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package cdm_tools
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// Validating the university model, after introducing an inconsistency into it, gives exactly
+// one error describing that inconsistency
+func TestValidateCDMModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(model *cdm.TCDMModel)
+		wantErr string // The error expected; none if empty
+	}{
+		{
+			name:   "consistent",
+			change: func(model *cdm.TCDMModel) {},
+		},
+		{
+			name: "unary relation type",
+			change: func(model *cdm.TCDMModel) {
+				lecturer := model.AddConcreteIndividualType("Lecturer")
+				teaching := model.AddInvolvementType("teaching", lecturer)
+				teaches := model.AddRelationType("Teaches", teaching)
+				model.AddRelationTypeReading(teaches, "", teaching, "teaches")
+			},
+			wantErr: `relation type "Teaches" has 1 involvement type(s), where at least 2 are needed`,
+		},
+		{
+			name: "reading across relation types",
+			change: func(model *cdm.TCDMModel) {
+				lecturer := model.AddConcreteIndividualType("Lecturer")
+				course := model.AddConcreteIndividualType("Course")
+				teaching := model.AddInvolvementType("teaching", lecturer)
+				taught := model.AddInvolvementType("taught", course)
+				teaches := model.AddRelationType("Teaches", teaching, taught)
+				model.AddRelationTypeReading(teaches, "", teaching, "teaches", taught, "")
+				examining := model.AddInvolvementType("examining", lecturer)
+				examined := model.AddInvolvementType("examined", course)
+				examines := model.AddRelationType("Examines", examining, examined)
+				model.AddRelationTypeReading(examines, "", examining, "examines", examined, "")
+				model.AddRelationTypeReading(teaches, "", teaching, "teaches and examines", examined, "")
+			},
+			wantErr: `involves "examined", which is not part of its relation type`,
+		},
+		{
+			name: "quality type without domain",
+			change: func(model *cdm.TCDMModel) {
+				model.AddQualityType("Student Number", "")
+			},
+			wantErr: `quality type "Student Number" has no domain`,
+		},
+		{
+			name: "duplicate name",
+			change: func(model *cdm.TCDMModel) {
+				model.AddConcreteIndividualType("Student")
+			},
+			wantErr: `type name "Student" is used more than once`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := BuildUniversityModel(testReporter(t))
+			test.change(&model)
+
+			errors := ValidateCDMModel(model)
+			if test.wantErr == "" {
+				if len(errors) > 0 {
+					t.Errorf("ValidateCDMModel() = %v, want no errors", errors)
+				}
+
+				return
+			}
+			if len(errors) != 1 || !strings.Contains(errors[0].Error(), test.wantErr) {
+				t.Errorf("ValidateCDMModel() = %v, want one error containing %q", errors, test.wantErr)
+			}
+		})
+	}
+}
+
+// The validating poster posts consistent models, and reports inconsistent ones rather than posting them
+func TestValidatingPoster(t *testing.T) {
+	reported := []string{}
+	reporter := generics.CreateReporter(generics.ProgressLevelBasic,
+		func(message string) { reported = append(reported, message) },
+		func(string) {})
+	recordingPoster := &TRecordingPoster{}
+	poster := CreateValidatingPoster(recordingPoster, reporter)
+
+	model := BuildUniversityModel(testReporter(t))
+	poster.PostState(model)
+
+	model.AddQualityType("Student Number", "")
+	poster.PostUpdate(model)
+	poster.PostConsidering(model)
+
+	if want := []string{"state"}; !slices.Equal(recordingPoster.posted, want) {
+		t.Errorf("posted %v, want %v", recordingPoster.posted, want)
+	}
+	if len(reported) != 4 || !strings.Contains(reported[1], "Not posting the update of inconsistent model University.") {
+		t.Errorf("reported %q, want the inconsistency and the refusals to post", reported)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Change commands
+ *
+ * This component supports running a shell command each time a file is changed as a result
+ * of a posting, such as a retrieved artefact being stored. Rapid changes to the same file
+ * are debounced, so the command only runs once the file has settled.
+ *
+ * In the command, {file} is replaced by the (shell quoted) path of the changed file, while
+ * the timestamp of the posting is passed in the MBUS_TIMESTAMP environment variable.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	ChangedFilePlaceholder = "{file}"         // Placeholder for the path of the changed file in change commands
+	TimestampVariable      = "MBUS_TIMESTAMP" // Environment variable passing the timestamp of the posting to change commands
+)
+
+/*
+ * Defining change commands
+ */
+
+// A change of a file, waiting for the file to settle
+type tPendingChange struct {
+	timer     *time.Timer // Timer running the command once the file has settled
+	timestamp string      // Timestamp of the posting that changed the file
+}
+
+// A shell command to run for changed files
+type TChangeCommand struct {
+	command  string              // The command, which may include the {file} placeholder
+	debounce time.Duration       // Time a file must be left unchanged before running the command for it
+	reporter *generics.TReporter // The reporter to report the outcome of the command to
+
+	pending map[string]tPendingChange // The changed files waiting to settle
+	lock    sync.Mutex                // Guarding the pending changes
+}
+
+// Creating a change command, debouncing changes to the same file by the given duration
+func CreateChangeCommand(command string, debounce time.Duration, reporter *generics.TReporter) *TChangeCommand {
+	return &TChangeCommand{
+		command:  command,
+		debounce: debounce,
+		reporter: reporter,
+		pending:  map[string]tPendingChange{},
+	}
+}
+
+/*
+ * Running change commands
+ */
+
+// Signalling that a file was changed by a posting with the given timestamp, running the
+// command once the file has been left unchanged for the debounce duration
+func (c *TChangeCommand) Changed(filePath, timestamp string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Restarting the wait for a file that is still changing
+	if change, isPending := c.pending[filePath]; isPending {
+		change.timer.Stop()
+	}
+
+	c.pending[filePath] = tPendingChange{
+		timer: time.AfterFunc(c.debounce, func() {
+			c.lock.Lock()
+			delete(c.pending, filePath)
+			c.lock.Unlock()
+
+			c.run(filePath, timestamp)
+		}),
+		timestamp: timestamp,
+	}
+}
+
+// Running the command right away for the changed files that have not settled yet, as when
+// the app is about to end
+func (c *TChangeCommand) Flush() {
+	c.lock.Lock()
+	pending := c.pending
+	c.pending = map[string]tPendingChange{}
+	c.lock.Unlock()
+
+	for filePath, change := range pending {
+		// Only running the command if its timer had not fired yet
+		if change.timer.Stop() {
+			c.run(filePath, change.timestamp)
+		}
+	}
+}
+
+// Running the command for a changed file
+func (c *TChangeCommand) run(filePath, timestamp string) {
+	command := strings.ReplaceAll(c.command, ChangedFilePlaceholder, shellQuote(filePath))
+
+	// Running the command through the shell, passing the timestamp
+	shellCommand := exec.Command("sh", "-c", command)
+	shellCommand.Env = append(os.Environ(), TimestampVariable+"="+timestamp)
+	shellCommand.Stdout = os.Stdout
+	shellCommand.Stderr = os.Stderr
+
+	c.reporter.Progress(generics.ProgressLevelBasic, "Running: %s", command)
+	c.reporter.MaybeReportError("Error running change command:", shellCommand.Run())
+}
+
+/*
+ * Support functions
+ */
+
+// Quoting a string for the shell, so it is passed as a single argument
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,149 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Compression
+ *
+ * This component supports storing retrieved postings compressed with gzip, and posting
+ * files that were compressed with gzip.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	GzipExtension = ".gz" // Extension of files compressed with gzip
+)
+
+/*
+ * Compressing
+ */
+
+// Writing content to a file, compressed with gzip
+func WriteGzipFile(filePath string, content io.Reader) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Compressing the content
+	compressor := gzip.NewWriter(file)
+	compressor.Name = strings.TrimSuffix(filepath.Base(filePath), GzipExtension)
+	if _, err := io.Copy(compressor, content); err != nil {
+		return err
+	}
+
+	// Flushing the compressed content
+	if err := compressor.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// Compressing a file with gzip, replacing it by the compressed file with the .gz extension,
+// and returning the path of the compressed file
+func GzipFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	// Writing the compressed file
+	compressedFilePath := filePath + GzipExtension
+	if err := WriteGzipFile(compressedFilePath, file); err != nil {
+		os.Remove(compressedFilePath)
+
+		return "", err
+	}
+
+	// Only keeping the compressed file
+	file.Close()
+
+	return compressedFilePath, os.Remove(filePath)
+}
+
+/*
+ * Decompressing
+ */
+
+// Checking if a file is compressed with gzip, by its extension
+func IsGzipFile(filePath string) bool {
+	return strings.EqualFold(filepath.Ext(filePath), GzipExtension)
+}
+
+// Reading the decompressed content of a file compressed with gzip
+func ReadGzipFile(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decompressor, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressor.Close()
+
+	return io.ReadAll(decompressor)
+}
+
+// Decompressing a file compressed with gzip into a temporary file, keeping the original
+// file name without the .gz extension, and returning the path of the temporary file.
+// The caller is to remove the temporary directory holding it, once done.
+func GunzipToTempFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	decompressor, err := gzip.NewReader(file)
+	if err != nil {
+		return "", err
+	}
+	defer decompressor.Close()
+
+	// Creating the temporary file, in a directory of its own, so it keeps its name
+	tempDirectory, err := os.MkdirTemp("", "gunzip")
+	if err != nil {
+		return "", err
+	}
+	tempFilePath := filepath.Join(tempDirectory, strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath)))
+
+	tempFile, err := os.Create(tempFilePath)
+	if err != nil {
+		os.RemoveAll(tempDirectory)
+
+		return "", err
+	}
+	defer tempFile.Close()
+
+	// Decompressing the content
+	if _, err := io.Copy(tempFile, decompressor); err != nil {
+		os.RemoveAll(tempDirectory)
+
+		return "", err
+	}
+
+	return tempFilePath, tempFile.Close()
+}
@@ -0,0 +1,248 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Configuration
+ *
+ * This component supports loading the configuration of the apps, while allowing
+ * individual configuration values to be overridden (e.g. from the command line).
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining configuration overrides
+ */
+
+const (
+	EnvOverridePrefix = "MBUS_" // Prefix of the environment variables overriding configuration values
+)
+
+// A value overriding the value from the configuration file
+type TConfigOverride struct {
+	Section string // The section of the value; empty for the default section
+	Key     string // The key of the value
+	Value   string // The value to be used instead
+}
+
+// A repeatable command line flag collecting configuration overrides, given as
+// key=value, or section.key=value for keys outside the default section
+type TConfigOverrides []TConfigOverride
+
+// Rendering the overrides, as needed for flag.Value
+func (o *TConfigOverrides) String() string {
+	settings := []string{}
+	for _, override := range *o {
+		key := override.Key
+		if override.Section != "" {
+			key = override.Section + "." + key
+		}
+		settings = append(settings, key+"="+override.Value)
+	}
+
+	return strings.Join(settings, ", ")
+}
+
+// Removing all overrides, as needed for ParseFlags
+func (o *TConfigOverrides) Reset() {
+	*o = TConfigOverrides{}
+}
+
+// Adding an override, as needed for flag.Value
+func (o *TConfigOverrides) Set(setting string) error {
+	key, value, found := strings.Cut(setting, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return fmt.Errorf("expected key=value, got: %s", setting)
+	}
+
+	// Splitting off the section, if any
+	section := ""
+	if position := strings.LastIndex(key, "."); position >= 0 {
+		section, key = key[:position], key[position+1:]
+	}
+	if key == "" {
+		return fmt.Errorf("no key given in: %s", setting)
+	}
+
+	*o = append(*o, TConfigOverride{Section: section, Key: key, Value: value})
+
+	return nil
+}
+
+// Defining a repeatable flag for configuration overrides
+func ConfigOverridesFlag(flags *flag.FlagSet, name, usage string) *TConfigOverrides {
+	overrides := &TConfigOverrides{}
+	flags.Var(overrides, name, usage)
+
+	return overrides
+}
+
+// Collecting the overrides of the default section from the environment, where the
+// variables with the given prefix map to the lowercased remainder of their name,
+// e.g. MBUS_WORK_FOLDER to work_folder. The overrides are sorted by key, so they are
+// applied in a deterministic order.
+func EnvConfigOverrides(prefix string) []TConfigOverride {
+	overrides := []TConfigOverride{}
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if key, found := strings.CutPrefix(name, prefix); found && key != "" {
+			overrides = append(overrides, TConfigOverride{Key: strings.ToLower(key), Value: value})
+		}
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Key < overrides[j].Key
+	})
+
+	return overrides
+}
+
+/*
+ * Loading the configuration
+ */
+
+// Loading the configuration, where the overrides take precedence over the environment (see
+// EnvConfigOverrides), which in turn takes precedence over the configuration file.
+// Configuration files in YAML or TOML are converted to INI first (see readConfigAsINI).
+// As generics.LoadConfig silently proceeds with an empty configuration when the configuration
+// file cannot be read, this is checked first, returning an ExitConfigError exit error if it cannot,
+// as is the case when the overrides cannot be applied.
+func LoadConfig(configFile string, overrides []TConfigOverride, reporter *generics.TReporter) (*generics.TConfigData, error) {
+	// Checking that the configuration file can be read
+	if reporter.MaybeReportError("Error loading configuration file:", checkConfigFile(configFile)) {
+		return nil, ExitWith(ExitConfigError)
+	}
+
+	// Applying the overrides after those from the environment, so they take precedence
+	overrides = append(EnvConfigOverrides(EnvOverridePrefix), overrides...)
+
+	// Without overrides, we can simply load an INI configuration file
+	if len(overrides) == 0 && isINIConfig(configFile) {
+		return generics.LoadConfig(configFile, reporter), nil
+	}
+
+	// Reading the configuration file, converting it to INI if needed
+	configContent, err := readConfigAsINI(configFile)
+	if reporter.MaybeReportError("Error reading configuration file:", err) {
+		return nil, ExitWith(ExitConfigError)
+	}
+
+	// Writing the configuration, including the overrides, to a temporary file
+	overriddenConfigFile, err := os.CreateTemp("", "mbus_config_*.ini")
+	if reporter.MaybeReportError("Error creating temporary configuration file:", err) {
+		return nil, ExitWith(ExitConfigError)
+	}
+	defer os.Remove(overriddenConfigFile.Name())
+
+	_, err = overriddenConfigFile.WriteString(applyConfigOverrides(configContent, overrides))
+	overriddenConfigFile.Close()
+	if reporter.MaybeReportError("Error writing temporary configuration file:", err) {
+		return nil, ExitWith(ExitConfigError)
+	}
+
+	// Loading the configuration including the overrides
+	return generics.LoadConfig(overriddenConfigFile.Name(), reporter), nil
+}
+
+// Checking that a configuration file exists, and can be read
+func checkConfigFile(configFile string) error {
+	file, err := os.Open(configFile)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// Checking that the required keys of the default section are present in the configuration,
+// reporting each missing key. Returns true if all required keys are present.
+func RequireConfigKeys(configData *generics.TConfigData, reporter *generics.TReporter, keys ...string) bool {
+	present := true
+	for _, key := range keys {
+		if configData.GetValue("", key).String() == "" {
+			reporter.Error("Missing required config key: %s", key)
+			present = false
+		}
+	}
+
+	return present
+}
+
+var (
+	sectionRegex  = regexp.MustCompile(`^\s*\[\s*([^\]]*?)\s*\]\s*$`) // Section header
+	keyValueRegex = regexp.MustCompile(`^\s*([^=\s]+)\s*=`)           // Key/value line
+)
+
+// Applying the overrides to the content of an INI configuration file
+func applyConfigOverrides(configContent string, overrides []TConfigOverride) string {
+	lines := strings.Split(configContent, "\n")
+
+	// Formatting a line for an override
+	overrideLine := func(override TConfigOverride) string {
+		return iniKeyValue(override.Key, override.Value)
+	}
+
+	for _, override := range overrides {
+		section := ""
+		sectionEnd := -1
+		replaced := false
+
+		// Replacing the existing value, if any
+		for position, line := range lines {
+			if matches := sectionRegex.FindStringSubmatch(line); matches != nil {
+				if section == override.Section && sectionEnd < 0 {
+					sectionEnd = position
+				}
+				section = matches[1]
+
+				continue
+			}
+
+			if matches := keyValueRegex.FindStringSubmatch(line); matches != nil && section == override.Section && matches[1] == override.Key {
+				lines[position] = overrideLine(override)
+				replaced = true
+			}
+		}
+
+		if replaced {
+			continue
+		}
+
+		// Otherwise, adding the value to its section
+		switch {
+		case override.Section == "":
+			// The default section is at the start of the file
+			lines = append([]string{overrideLine(override)}, lines...)
+
+		case section == override.Section || sectionEnd >= 0:
+			// The section exists, so we add the value at the end of it
+			if sectionEnd < 0 {
+				sectionEnd = len(lines)
+			}
+			lines = append(lines[:sectionEnd], append([]string{overrideLine(override)}, lines[sectionEnd:]...)...)
+
+		default:
+			// The section does not exist yet
+			lines = append(lines, "", "["+override.Section+"]", overrideLine(override))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
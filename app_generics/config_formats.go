@@ -0,0 +1,301 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Configuration formats
+ *
+ * This component supports configuration files in YAML and TOML, next to INI, by converting
+ * them to INI, which is what generics.LoadConfig reads. The format is determined by the
+ * extension of the configuration file, where INI is the default.
+ *
+ * Only the subsets of YAML and TOML needed for configurations are supported: (nested) maps
+ * and tables of scalar values. Top-level keys go to the default section, while nested maps
+ * and tables become sections, where any deeper nesting is flattened into dotted keys.
+ * For example, the YAML configuration:
+ *
+ *   work_folder: work
+ *   mqtt:
+ *     broker: localhost
+ *     tls:
+ *       enabled: true
+ *
+ * is equivalent to the TOML configuration:
+ *
+ *   work_folder = "work"
+ *   [mqtt]
+ *   broker = "localhost"
+ *   [mqtt.tls]
+ *   enabled = true
+ *
+ * and to the INI configuration:
+ *
+ *   work_folder = "work"
+ *   [mqtt]
+ *   broker = "localhost"
+ *   tls.enabled = "true"
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	iniExtension  = ".ini"  // Extension of INI configuration files
+	yamlExtension = ".yaml" // Extension of YAML configuration files
+	ymlExtension  = ".yml"  // Alternative extension of YAML configuration files
+	tomlExtension = ".toml" // Extension of TOML configuration files
+
+	keyPathSeparator = "." // Separator of the parts of flattened keys
+)
+
+/*
+ * Determining configuration formats
+ */
+
+// Checking if a configuration file is in INI format, which is the case for all extensions
+// other than those of YAML and TOML
+func isINIConfig(configFile string) bool {
+	switch strings.ToLower(filepath.Ext(configFile)) {
+	case yamlExtension, ymlExtension, tomlExtension:
+		return false
+	default:
+		return true
+	}
+}
+
+// Reading a configuration file, converting it to INI if needed
+func readConfigAsINI(configFile string) (string, error) {
+	configContent, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", err
+	}
+
+	// Converting the configuration, based on its format
+	var entries []TConfigOverride
+	switch strings.ToLower(filepath.Ext(configFile)) {
+	case yamlExtension, ymlExtension:
+		entries, err = parseYAMLConfig(string(configContent))
+	case tomlExtension:
+		entries, err = parseTOMLConfig(string(configContent))
+	default:
+		return string(configContent), nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", configFile, err)
+	}
+
+	return renderINIConfig(entries), nil
+}
+
+/*
+ * Rendering configurations as INI
+ */
+
+// Formatting a key/value line of an INI file, where the value is quoted so it is read as is.
+// As the INI reader does not unescape quoted values, the value is put between backticks, or
+// between triple quotes when it contains a backtick, rather than being escaped.
+func iniKeyValue(key, value string) string {
+	if strings.Contains(value, "`") {
+		return key + ` = """` + value + `"""`
+	}
+
+	return key + " = `" + value + "`"
+}
+
+// Rendering configuration entries as INI, starting with the default section, followed by
+// the other sections in order of their first appearance
+func renderINIConfig(entries []TConfigOverride) string {
+	sections := []string{""}
+	sectionLines := map[string][]string{}
+	for _, entry := range entries {
+		if _, known := sectionLines[entry.Section]; !known && entry.Section != "" {
+			sections = append(sections, entry.Section)
+		}
+		sectionLines[entry.Section] = append(sectionLines[entry.Section], iniKeyValue(entry.Key, entry.Value))
+	}
+
+	lines := []string{}
+	for _, section := range sections {
+		if section != "" {
+			lines = append(lines, "", "["+section+"]")
+		}
+		lines = append(lines, sectionLines[section]...)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Creating a configuration entry from the path of keys leading to a value, where the
+// first key names the section, unless the value is at the top level
+func configEntry(keyPath []string, value string) TConfigOverride {
+	if len(keyPath) == 1 {
+		return TConfigOverride{Key: keyPath[0], Value: value}
+	}
+
+	return TConfigOverride{Section: keyPath[0], Key: strings.Join(keyPath[1:], keyPathSeparator), Value: value}
+}
+
+/*
+ * Parsing YAML configurations
+ */
+
+// A map in a YAML configuration, which is open while its keys are being read
+type tYAMLMap struct {
+	indentation int    // Indentation of the key of the map
+	key         string // Key of the map
+}
+
+// Parsing a YAML configuration into configuration entries
+func parseYAMLConfig(configContent string) ([]TConfigOverride, error) {
+	entries := []TConfigOverride{}
+	openMaps := []tYAMLMap{}
+
+	for lineNumber, line := range strings.Split(configContent, "\n") {
+		content := strings.TrimRight(stripComment(line), " \t\r")
+		trimmed := strings.TrimSpace(content)
+
+		// Skipping empty lines and document markers
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		// Lists are not supported
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			return nil, fmt.Errorf("line %d: lists are not supported", lineNumber+1)
+		}
+
+		// Splitting the key and value
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key: value", lineNumber+1)
+		}
+		key = unquoteConfigValue(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		// Closing the maps that are not indented less than this key
+		indentation := len(content) - len(strings.TrimLeft(content, " \t"))
+		for len(openMaps) > 0 && openMaps[len(openMaps)-1].indentation >= indentation {
+			openMaps = openMaps[:len(openMaps)-1]
+		}
+
+		// Determining the path of keys leading to this key
+		keyPath := []string{}
+		for _, openMap := range openMaps {
+			keyPath = append(keyPath, openMap.key)
+		}
+		keyPath = append(keyPath, key)
+
+		// Opening a map, or adding a value
+		if value == "" {
+			openMaps = append(openMaps, tYAMLMap{indentation: indentation, key: key})
+		} else {
+			entries = append(entries, configEntry(keyPath, unquoteConfigValue(value)))
+		}
+	}
+
+	return entries, nil
+}
+
+/*
+ * Parsing TOML configurations
+ */
+
+// Parsing a TOML configuration into configuration entries
+func parseTOMLConfig(configContent string) ([]TConfigOverride, error) {
+	entries := []TConfigOverride{}
+	tablePath := []string{}
+
+	for lineNumber, line := range strings.Split(configContent, "\n") {
+		trimmed := strings.TrimSpace(stripComment(line))
+
+		// Skipping empty lines
+		if trimmed == "" {
+			continue
+		}
+
+		// Opening a table
+		if strings.HasPrefix(trimmed, "[") {
+			if strings.HasPrefix(trimmed, "[[") || !strings.HasSuffix(trimmed, "]") {
+				return nil, fmt.Errorf("line %d: only plain tables are supported", lineNumber+1)
+			}
+
+			tablePath = []string{}
+			for _, key := range strings.Split(strings.Trim(trimmed, "[]"), keyPathSeparator) {
+				tablePath = append(tablePath, unquoteConfigValue(strings.TrimSpace(key)))
+			}
+
+			continue
+		}
+
+		// Splitting the key and value
+		key, value, found := strings.Cut(trimmed, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber+1)
+		}
+		value = strings.TrimSpace(value)
+
+		// Arrays and inline tables are not supported
+		if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "{") {
+			return nil, fmt.Errorf("line %d: arrays and inline tables are not supported", lineNumber+1)
+		}
+
+		// Adding the value
+		keyPath := append(append([]string{}, tablePath...), unquoteConfigValue(strings.TrimSpace(key)))
+		entries = append(entries, configEntry(keyPath, unquoteConfigValue(value)))
+	}
+
+	return entries, nil
+}
+
+/*
+ * Support functions
+ */
+
+// Removing a comment, starting with a '#' outside of quotes, from a line
+func stripComment(line string) string {
+	quote := rune(0)
+	for position, character := range line {
+		switch {
+		case quote != 0 && character == quote:
+			quote = 0
+		case quote == 0 && (character == '"' || character == '\''):
+			quote = character
+		case quote == 0 && character == '#':
+			return line[:position]
+		}
+	}
+
+	return line
+}
+
+// Removing the quotes around a value, if any
+func unquoteConfigValue(value string) string {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				return unquoted
+			}
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,189 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Configuration (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Creating a reporter that reports errors to the test
+func testConfigReporter(t *testing.T) *generics.TReporter {
+	return generics.CreateReporter(ProgressLevelSilent,
+		func(message string) { t.Errorf("reported error: %s", message) },
+		func(string) {})
+}
+
+// Writing a configuration file with the given name and content to a temporary folder
+func writeConfigFile(t *testing.T, name, content string) string {
+	configFile := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(configFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return configFile
+}
+
+// Overriding configuration values keeps them as is, including backslashes and quotes
+func TestConfigOverrideQuoting(t *testing.T) {
+	values := map[string]string{
+		"windows_path": `C:\work\models`,
+		"quoted":       `say "hello"`,
+		"single":       `it's`,
+		"backtick":     "run `make`",
+		"comment":      "topic # not a comment; really",
+		"escapes":      `\n\t\"`,
+	}
+
+	configFile := writeConfigFile(t, "config.ini", "agent = poster\n")
+
+	overrides := []TConfigOverride{}
+	for key, value := range values {
+		overrides = append(overrides, TConfigOverride{Key: key, Value: value})
+	}
+
+	configData, err := LoadConfig(configFile, overrides, testConfigReporter(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, value := range values {
+		if got := configData.GetValue("", key).String(); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+// Equivalent configurations in INI, YAML, and TOML resolve to the same values, where nested
+// maps and tables become sections, and backslashes are kept
+func TestConfigFormats(t *testing.T) {
+	configFiles := map[string]string{
+		"config.ini": `agent = poster
+work_folder = C:\work\models
+
+[mqtt]
+broker = "localhost"
+port = 1883
+tls.enabled = true
+`,
+		"config.yaml": `agent: poster
+work_folder: 'C:\work\models'
+mqtt:
+  broker: "localhost"
+  port: 1883 # the default port
+  tls:
+    enabled: true
+`,
+		"config.toml": `agent = "poster"
+work_folder = 'C:\work\models'
+
+[mqtt]
+broker = "localhost"
+port = 1883
+
+[mqtt.tls]
+enabled = true
+`,
+	}
+
+	want := []TConfigOverride{
+		{Key: "agent", Value: "poster"},
+		{Key: "work_folder", Value: `C:\work\models`},
+		{Section: "mqtt", Key: "broker", Value: "localhost"},
+		{Section: "mqtt", Key: "port", Value: "1883"},
+		{Section: "mqtt", Key: "tls.enabled", Value: "true"},
+	}
+
+	for name, content := range configFiles {
+		t.Run(name, func(t *testing.T) {
+			configData, err := LoadConfig(writeConfigFile(t, name, content), nil, testConfigReporter(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, value := range want {
+				if got := configData.GetValue(value.Section, value.Key).String(); got != value.Value {
+					t.Errorf("[%s] %s = %q, want %q", value.Section, value.Key, got, value.Value)
+				}
+			}
+		})
+	}
+}
+
+// The environment variables with the prefix give overrides, sorted by key, for their lowercased
+// remainder, ignoring the other variables and the prefix on its own
+func TestEnvConfigOverrides(t *testing.T) {
+	t.Setenv("MBUS_TEST_WORK_FOLDER", "/tmp/environment")
+	t.Setenv("MBUS_TEST_AGENT", "poster")
+	t.Setenv("MBUS_TEST_", "ignored")
+	t.Setenv("OTHER_MBUS_TEST_LATEX", "ignored")
+
+	want := []TConfigOverride{
+		{Key: "agent", Value: "poster"},
+		{Key: "work_folder", Value: "/tmp/environment"},
+	}
+	if got := EnvConfigOverrides("MBUS_TEST_"); !slices.Equal(got, want) {
+		t.Errorf("EnvConfigOverrides() = %+v, want %+v", got, want)
+	}
+}
+
+// Overriding configuration values, where -set flags take precedence over the environment,
+// which takes precedence over the configuration file
+func TestConfigOverridePrecedence(t *testing.T) {
+	configFile := writeConfigFile(t, "config.ini", "agent = poster\nwork_folder = work\nlatex_command = pdflatex\n\n[mqtt]\nbroker = localhost\n")
+
+	t.Setenv(EnvOverridePrefix+"WORK_FOLDER", "/tmp/environment")
+	t.Setenv(EnvOverridePrefix+"LATEX_COMMAND", "lualatex")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	configSetFlag := ConfigOverridesFlag(flags, "set", "")
+	if err := flags.Parse([]string{"-set", "work_folder=/tmp/out", "-set", "mqtt.broker=bus.example.org"}); err != nil {
+		t.Fatal(err)
+	}
+
+	configData, err := LoadConfig(configFile, *configSetFlag, testConfigReporter(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []TConfigOverride{
+		{Key: "agent", Value: "poster"},
+		{Key: "work_folder", Value: "/tmp/out"},
+		{Key: "latex_command", Value: "lualatex"},
+		{Section: "mqtt", Key: "broker", Value: "bus.example.org"},
+	} {
+		if got := configData.GetValue(value.Section, value.Key).String(); got != value.Value {
+			t.Errorf("[%s] %s = %q, want %q", value.Section, value.Key, got, value.Value)
+		}
+	}
+}
+
+// Failing to apply the overrides is a configuration error, rather than silently loading
+// the configuration file without them
+func TestConfigOverrideFailure(t *testing.T) {
+	configFile := writeConfigFile(t, "config.ini", "work_folder = work\n")
+	t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "missing"))
+
+	errors := 0
+	reporter := generics.CreateReporter(ProgressLevelSilent, func(string) { errors++ }, func(string) {})
+
+	configData, err := LoadConfig(configFile, []TConfigOverride{{Key: "work_folder", Value: "/tmp/out"}}, reporter)
+	if ExitCode(err) != ExitConfigError || configData != nil || errors == 0 {
+		t.Errorf("LoadConfig() = %v, %v with %d error(s); want no configuration, exit code %d, and a reported error", configData, err, errors, ExitConfigError)
+	}
+}
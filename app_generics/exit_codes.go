@@ -0,0 +1,82 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Exit codes
+ *
+ * This component defines the exit codes of the apps, so wrappers can tell why an app failed:
+ *   - 0: success;
+ *   - 1: usage errors, such as missing, unknown, or invalid flag values;
+ *   - 2: configuration errors, such as an unreadable configuration file, missing
+ *        configuration keys, or an unusable work folder;
+ *   - 3: errors while operating on the modelling bus, or on files.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"fmt"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	ExitUsageError  = 1 // Exit code for usage errors
+	ExitConfigError = 2 // Exit code for configuration errors
+	ExitBusError    = 3 // Exit code for errors while operating on the modelling bus, or on files
+)
+
+/*
+ * Defining exit errors
+ */
+
+// An error ending an app with the given exit code, where the cause has already been reported
+type TExitError struct {
+	Code int // The exit code
+}
+
+// Describing the exit error
+func (e TExitError) Error() string {
+	return fmt.Sprintf("exit code %d", e.Code)
+}
+
+// Creating an error ending an app with the given exit code
+func ExitWith(code int) error {
+	return TExitError{Code: code}
+}
+
+/*
+ * Exiting
+ */
+
+// Determining the exit code for the outcome of running an app, which is 0 without an error,
+// the code of an exit error, and ExitBusError for other errors
+func ExitCode(err error) int {
+	exitError := TExitError{}
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitError):
+		return exitError.Code
+	default:
+		return ExitBusError
+	}
+}
+
+// Returning an exit error with ExitBusError if any errors were reported since the given
+// error count, such as by the handlers of the app, and nil otherwise
+func ExitErrorSince(errorCount int64) error {
+	if ReportedErrorCount() > errorCount {
+		return ExitWith(ExitBusError)
+	}
+
+	return nil
+}
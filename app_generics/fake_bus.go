@@ -0,0 +1,257 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Fake modelling bus
+ *
+ * This component defines the operations the apps perform on observations, coordinations,
+ * and environments, as offered by the Modelling Bus Connector, together with an in-memory
+ * fake implementing them, so the handlers of the apps can be exercised without a live bus.
+ *
+ * Artefacts are not covered, as the artefact connectors can only be created from an
+ * actual Modelling Bus Connector.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+)
+
+/*
+ * Defining the modelling bus operations
+ */
+
+// The operations on observations, coordinations, and environments, as offered by
+// *connect.TModellingBusConnector and by TFakeModellingBus
+type TModellingBusOperations interface {
+	PostRawObservation(observationID, file string)
+	PostJSONObservation(observationID string, json []byte)
+	PostStreamedObservation(observationID string, json []byte)
+	PostCoordination(coordinationID string, json []byte)
+
+	GetRawObservation(agentID, observationID, fileName string) (string, string)
+	GetJSONObservation(agentID, observationID string) ([]byte, string)
+	GetStreamedObservation(agentID, observationID string) ([]byte, string)
+	GetCoordination(agentID, coordinationID string) ([]byte, string)
+
+	DeleteRawObservation(observationID string)
+	DeleteJSONObservation(observationID string)
+	DeleteStreamedObservation(observationID string)
+	DeleteCoordination(coordinationID string)
+	DeleteEnvironment(environment ...string)
+}
+
+/*
+ * Defining the fake modelling bus
+ */
+
+// The kinds of postings held by the fake modelling bus
+const (
+	FakeRawObservation      = "raw_observation"      // Raw observation postings
+	FakeJSONObservation     = "json_observation"     // JSON observation postings
+	FakeStreamedObservation = "streamed_observation" // Streamed observation postings
+	FakeCoordination        = "coordination"         // Coordination postings
+)
+
+// A posting held by the fake modelling bus
+type TFakePosting struct {
+	Kind      string // Kind of the posting
+	AgentID   string // Agent the posting belongs to
+	ID        string // Observation ID, or coordination topic
+	Content   []byte // Content of the posting
+	Timestamp string // Time of the posting
+}
+
+// An in-memory modelling bus, holding the postings of each kind by agent and ID
+type TFakeModellingBus struct {
+	AgentID    string // Agent on whose behalf postings are made, as configured for a connector
+	WorkFolder string // Folder to store retrieved raw observations in
+
+	postings  map[string]TFakePosting // The postings, by kind, agent, and ID
+	listeners []func(TFakePosting)    // Called for each posting
+
+	lock sync.Mutex // Guarding the postings and listeners
+}
+
+// Creating an empty fake modelling bus, for the given agent
+func CreateFakeModellingBus(agentID, workFolder string) *TFakeModellingBus {
+	return &TFakeModellingBus{
+		AgentID:    agentID,
+		WorkFolder: workFolder,
+		postings:   map[string]TFakePosting{},
+	}
+}
+
+// Registering a listener, called for each subsequent posting
+func (f *TFakeModellingBus) ListenForPostings(listener func(TFakePosting)) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.listeners = append(f.listeners, listener)
+}
+
+// Getting a posting, if present
+func (f *TFakeModellingBus) Posting(kind, agentID, id string) (TFakePosting, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	posting, present := f.postings[fakePostingKey(kind, agentID, id)]
+
+	return posting, present
+}
+
+// Identifying a posting by its kind, agent, and ID
+func fakePostingKey(kind, agentID, id string) string {
+	return kind + "/" + agentID + "/" + id
+}
+
+// Storing a posting, and calling the listeners
+func (f *TFakeModellingBus) post(kind, agentID, id string, content []byte) {
+	posting := TFakePosting{
+		Kind:      kind,
+		AgentID:   agentID,
+		ID:        id,
+		Content:   append([]byte{}, content...),
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+	}
+
+	f.lock.Lock()
+	f.postings[fakePostingKey(kind, agentID, id)] = posting
+	listeners := append([]func(TFakePosting){}, f.listeners...)
+	f.lock.Unlock()
+
+	for _, listener := range listeners {
+		listener(posting)
+	}
+}
+
+// Getting the content and timestamp of a posting, which are empty when absent
+func (f *TFakeModellingBus) get(kind, agentID, id string) ([]byte, string) {
+	posting, _ := f.Posting(kind, agentID, id)
+
+	return posting.Content, posting.Timestamp
+}
+
+// Deleting a posting
+func (f *TFakeModellingBus) delete(kind, agentID, id string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.postings, fakePostingKey(kind, agentID, id))
+}
+
+/*
+ * Posting
+ */
+
+func (f *TFakeModellingBus) PostRawObservation(observationID, file string) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+
+	f.post(FakeRawObservation, f.AgentID, observationID, content)
+}
+
+func (f *TFakeModellingBus) PostJSONObservation(observationID string, json []byte) {
+	f.post(FakeJSONObservation, f.AgentID, observationID, json)
+}
+
+func (f *TFakeModellingBus) PostStreamedObservation(observationID string, json []byte) {
+	f.post(FakeStreamedObservation, f.AgentID, observationID, json)
+}
+
+func (f *TFakeModellingBus) PostCoordination(coordinationID string, json []byte) {
+	f.post(FakeCoordination, f.AgentID, coordinationID, json)
+}
+
+/*
+ * Getting
+ */
+
+// Getting a raw observation, stored in the work folder under the given file name
+func (f *TFakeModellingBus) GetRawObservation(agentID, observationID, fileName string) (string, string) {
+	content, timestamp := f.get(FakeRawObservation, agentID, observationID)
+
+	filePath := filepath.Join(f.WorkFolder, fileName)
+	if os.WriteFile(filePath, content, 0644) != nil {
+		return "", ""
+	}
+
+	return filePath, timestamp
+}
+
+func (f *TFakeModellingBus) GetJSONObservation(agentID, observationID string) ([]byte, string) {
+	return f.get(FakeJSONObservation, agentID, observationID)
+}
+
+func (f *TFakeModellingBus) GetStreamedObservation(agentID, observationID string) ([]byte, string) {
+	return f.get(FakeStreamedObservation, agentID, observationID)
+}
+
+func (f *TFakeModellingBus) GetCoordination(agentID, coordinationID string) ([]byte, string) {
+	return f.get(FakeCoordination, agentID, coordinationID)
+}
+
+// Getting the coordinations posted by the agent of the fake modelling bus, whose topic
+// starts with the given prefix
+func (f *TFakeModellingBus) GetCoordinationsUnder(prefix string) (map[string][]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	coordinations := map[string][]byte{}
+	for _, posting := range f.postings {
+		if posting.Kind == FakeCoordination && posting.AgentID == f.AgentID && strings.HasPrefix(posting.ID, prefix) {
+			coordinations[posting.ID] = posting.Content
+		}
+	}
+
+	return coordinations, nil
+}
+
+/*
+ * Deleting
+ */
+
+func (f *TFakeModellingBus) DeleteRawObservation(observationID string) {
+	f.delete(FakeRawObservation, f.AgentID, observationID)
+}
+
+func (f *TFakeModellingBus) DeleteJSONObservation(observationID string) {
+	f.delete(FakeJSONObservation, f.AgentID, observationID)
+}
+
+func (f *TFakeModellingBus) DeleteStreamedObservation(observationID string) {
+	f.delete(FakeStreamedObservation, f.AgentID, observationID)
+}
+
+func (f *TFakeModellingBus) DeleteCoordination(coordinationID string) {
+	f.delete(FakeCoordination, f.AgentID, coordinationID)
+}
+
+// Deleting all postings, as the fake modelling bus has a single environment
+func (f *TFakeModellingBus) DeleteEnvironment(environment ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.postings = map[string]TFakePosting{}
+}
+
+// Ensuring both the connector and the fake offer the modelling bus operations
+var (
+	_ TModellingBusOperations = (*connect.TModellingBusConnector)(nil)
+	_ TModellingBusOperations = (*TFakeModellingBus)(nil)
+	_ TCoordinationLister     = (*TFakeModellingBus)(nil)
+)
@@ -0,0 +1,206 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Fake modelling bus (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const (
+	testAgentID = "test-agent" // Agent of the fake modelling bus in the tests
+)
+
+// Posting, getting, and deleting each kind of posting via the modelling bus operations
+func TestFakeModellingBusRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		post   func(bus TModellingBusOperations)
+		get    func(bus TModellingBusOperations) []byte
+		delete func(bus TModellingBusOperations)
+	}{
+		{
+			name: "JSON observation",
+			post: func(bus TModellingBusOperations) { bus.PostJSONObservation("obs", []byte(`{"a":1}`)) },
+			get: func(bus TModellingBusOperations) []byte {
+				content, _ := bus.GetJSONObservation(testAgentID, "obs")
+				return content
+			},
+			delete: func(bus TModellingBusOperations) { bus.DeleteJSONObservation("obs") },
+		},
+		{
+			name: "streamed observation",
+			post: func(bus TModellingBusOperations) { bus.PostStreamedObservation("obs", []byte(`{"a":1}`)) },
+			get: func(bus TModellingBusOperations) []byte {
+				content, _ := bus.GetStreamedObservation(testAgentID, "obs")
+				return content
+			},
+			delete: func(bus TModellingBusOperations) { bus.DeleteStreamedObservation("obs") },
+		},
+		{
+			name: "coordination",
+			post: func(bus TModellingBusOperations) { bus.PostCoordination("topic", []byte(`{"a":1}`)) },
+			get: func(bus TModellingBusOperations) []byte {
+				content, _ := bus.GetCoordination(testAgentID, "topic")
+				return content
+			},
+			delete: func(bus TModellingBusOperations) { bus.DeleteCoordination("topic") },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+
+			test.post(bus)
+			if got := string(test.get(bus)); got != `{"a":1}` {
+				t.Fatalf("got %q after posting, want %q", got, `{"a":1}`)
+			}
+
+			test.delete(bus)
+			if got := test.get(bus); len(got) != 0 {
+				t.Fatalf("got %q after deleting, want nothing", got)
+			}
+		})
+	}
+}
+
+// Posting a raw observation, which is stored in the work folder when retrieved
+func TestFakeModellingBusRawObservation(t *testing.T) {
+	workFolder := t.TempDir()
+	bus := CreateFakeModellingBus(testAgentID, workFolder)
+
+	file := filepath.Join(t.TempDir(), "posted.txt")
+	if err := os.WriteFile(file, []byte("raw content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bus.PostRawObservation("obs", file)
+
+	filePath, timestamp := bus.GetRawObservation(testAgentID, "obs", "retrieved.txt")
+	if filePath != filepath.Join(workFolder, "retrieved.txt") || timestamp == "" {
+		t.Fatalf("got %q at %q, want the file in the work folder with a timestamp", filePath, timestamp)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil || string(content) != "raw content" {
+		t.Fatalf("got %q (%v), want %q", content, err, "raw content")
+	}
+}
+
+// Postings are kept per agent, so postings of other agents are not found
+func TestFakeModellingBusOtherAgent(t *testing.T) {
+	bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+	bus.PostCoordination("topic", []byte(`{}`))
+
+	if content, _ := bus.GetCoordination("other-agent", "topic"); len(content) != 0 {
+		t.Fatalf("got %q for another agent, want nothing", content)
+	}
+}
+
+// Listeners are called for each posting
+func TestFakeModellingBusListeners(t *testing.T) {
+	bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+
+	heard := []string{}
+	bus.ListenForPostings(func(posting TFakePosting) {
+		heard = append(heard, posting.Kind+":"+posting.ID)
+	})
+
+	bus.PostJSONObservation("obs", []byte(`{}`))
+	bus.PostCoordination("topic", []byte(`{}`))
+
+	if want := []string{FakeJSONObservation + ":obs", FakeCoordination + ":topic"}; !slices.Equal(heard, want) {
+		t.Fatalf("heard %v, want %v", heard, want)
+	}
+}
+
+// Deleting the environment removes all postings
+func TestFakeModellingBusDeleteEnvironment(t *testing.T) {
+	bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+	bus.PostJSONObservation("obs", []byte(`{}`))
+	bus.PostCoordination("topic", []byte(`{}`))
+
+	bus.DeleteEnvironment()
+
+	if _, present := bus.Posting(FakeJSONObservation, testAgentID, "obs"); present {
+		t.Fatal("JSON observation still present after deleting the environment")
+	}
+	if _, present := bus.Posting(FakeCoordination, testAgentID, "topic"); present {
+		t.Fatal("coordination still present after deleting the environment")
+	}
+}
+
+// Getting the coordinations under a prefix, where only buses that can list coordinations
+// support this
+func TestGetCoordinationsUnder(t *testing.T) {
+	bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+	for _, topic := range []string{"project/alpha/a", "project/alpha/b", "project/alpha/c", "project/beta/d"} {
+		bus.PostCoordination(topic, []byte(`"`+topic+`"`))
+	}
+
+	coordinations, err := GetCoordinationsUnder(bus, "project/alpha/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topics := slices.Sorted(maps.Keys(coordinations)); !slices.Equal(topics, []string{"project/alpha/a", "project/alpha/b", "project/alpha/c"}) {
+		t.Fatalf("got topics %v, want the three under project/alpha/", topics)
+	}
+	for topic, content := range coordinations {
+		if string(content) != `"`+topic+`"` {
+			t.Errorf("%s: got %s, want its own posting", topic, content)
+		}
+	}
+
+	// A bus that cannot list coordinations, such as the connector
+	notListing := struct{ TModellingBusOperations }{bus}
+	if _, err := GetCoordinationsUnder(notListing, "project/alpha/"); !errors.Is(err, ErrListingNotSupported) {
+		t.Fatalf("got error %v, want %v", err, ErrListingNotSupported)
+	}
+}
+
+// Listing the coordination topics under a prefix, in sorted order
+func TestListCoordinationTopics(t *testing.T) {
+	bus := CreateFakeModellingBus(testAgentID, t.TempDir())
+	for _, topic := range []string{"jobs/b", "jobs/a", "other/c"} {
+		bus.PostCoordination(topic, []byte(`{}`))
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"", []string{"jobs/a", "jobs/b", "other/c"}},
+		{"jobs/", []string{"jobs/a", "jobs/b"}},
+		{"none/", []string{}},
+	}
+
+	for _, test := range tests {
+		topics, err := ListCoordinationTopics(bus, test.prefix)
+		if err != nil {
+			t.Fatalf("prefix %q: unexpected error: %v", test.prefix, err)
+		}
+		if !slices.Equal(topics, test.want) {
+			t.Errorf("prefix %q: got %v, want %v", test.prefix, topics, test.want)
+		}
+	}
+
+	// A bus that cannot list coordinations, such as the connector
+	notListing := struct{ TModellingBusOperations }{bus}
+	if _, err := ListCoordinationTopics(notListing, "jobs/"); !errors.Is(err, ErrListingNotSupported) {
+		t.Fatalf("got error %v, want %v", err, ErrListingNotSupported)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Flags
+ *
+ * This component supports parsing the flags of an app, and checking that the flags required
+ * by an operation are given, reporting all missing flags at once, rather than only the first one.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Parsing flags
+ */
+
+// A flag value that can be reset to its default, rather than by setting its default value
+type tResettableFlag interface {
+	Reset()
+}
+
+// Parsing the arguments of an app into its flags, after resetting all flags to their
+// defaults, so an app can be run several times, such as in tests. Asking for help is not
+// an error, while other problems with the flags are returned as ExitUsageError.
+func ParseFlags(flags *flag.FlagSet, arguments []string) (bool, error) {
+	// Resetting the flags
+	flags.VisitAll(func(definedFlag *flag.Flag) {
+		if resettable, canReset := definedFlag.Value.(tResettableFlag); canReset {
+			resettable.Reset()
+		} else {
+			definedFlag.Value.Set(definedFlag.DefValue)
+		}
+	})
+
+	// Parsing the arguments, where the flag set has already reported any problems
+	err := flags.Parse(arguments)
+	if errors.Is(err, flag.ErrHelp) {
+		return false, nil
+	} else if err != nil {
+		return false, ExitWith(ExitUsageError)
+	}
+
+	return true, nil
+}
+
+/*
+ * Defining required flags
+ */
+
+// A flag required by an operation
+type TRequiredFlag struct {
+	Value *string // The value of the flag
+	Name  string  // The name of the flag, as given on the command line
+}
+
+/*
+ * Checking required flags
+ */
+
+// Checking that the required flags of an operation are given (i.e. not empty), reporting
+// the missing flags in a single error. Returns true if all required flags are given.
+func RequireFlags(reporter *generics.TReporter, operation string, flags ...TRequiredFlag) bool {
+	missingFlags := []string{}
+	for _, requiredFlag := range flags {
+		if *requiredFlag.Value == "" {
+			missingFlags = append(missingFlags, "-"+requiredFlag.Name)
+		}
+	}
+
+	if len(missingFlags) == 0 {
+		return true
+	}
+
+	reporter.Error("Missing required flag(s) for %s: %s.", operation, strings.Join(missingFlags, ", "))
+
+	return false
+}
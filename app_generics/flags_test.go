@@ -0,0 +1,59 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Flags (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Checking required flags, reporting all missing flags in a single error
+func TestRequireFlags(t *testing.T) {
+	file, artefactID, empty := "model.json", "university", ""
+
+	tests := []struct {
+		name   string
+		flags  []TRequiredFlag
+		want   bool
+		errors []string
+	}{
+		{"none required", nil, true, nil},
+		{"all given", []TRequiredFlag{{&file, "file"}, {&artefactID, "artefact_id"}}, true, nil},
+		{"one missing", []TRequiredFlag{{&file, "file"}, {&empty, "artefact_id"}}, false,
+			[]string{"Missing required flag(s) for posting: -artefact_id."}},
+		{"two missing", []TRequiredFlag{{&empty, "file"}, {&empty, "artefact_id"}}, false,
+			[]string{"Missing required flag(s) for posting: -file, -artefact_id."}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := []string{}
+			reporter := generics.CreateReporter(ProgressLevelSilent,
+				func(message string) { errors = append(errors, message) },
+				func(string) {})
+
+			if got := RequireFlags(reporter, "posting", test.flags...); got != test.want {
+				t.Errorf("RequireFlags() = %t, want %t", got, test.want)
+			}
+			if len(errors) != len(test.errors) {
+				t.Fatalf("reported %q, want %q", errors, test.errors)
+			}
+			for i := range errors {
+				if errors[i] != test.errors[i] {
+					t.Errorf("reported %q, want %q", errors[i], test.errors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+module app_generics
+
+go 1.24.0
+
+require (
+	github.com/erikproper/big-modelling-bus.go.v1 v1.0.32
+	plantuml v0.0.0
+)
+
+require (
+	github.com/eclipse/paho.mqtt.golang v1.5.1 // indirect
+	github.com/evanphx/json-patch v0.5.2 // indirect
+	github.com/gorilla/websocket v1.5.3 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/secsy/goftp v0.0.0-20200609142545-aa2de14babf4 // indirect
+	github.com/tidwall/gjson v1.18.0 // indirect
+	github.com/tidwall/match v1.2.0 // indirect
+	github.com/tidwall/pretty v1.2.1 // indirect
+	github.com/tidwall/sjson v1.2.5 // indirect
+	github.com/wI2L/jsondiff v0.7.0 // indirect
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+	gopkg.in/ini.v1 v1.67.0 // indirect
+)
+
+replace plantuml => ../plantuml
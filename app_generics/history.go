@@ -0,0 +1,67 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   History
+ *
+ * This component supports retrieving artefacts as they were at a given time.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+)
+
+/*
+ * Retrieving historical versions
+ */
+
+var (
+	// The error returned when the version at the requested time was replaced, while the modelling bus
+	// offers no way to retrieve earlier versions of postings
+	ErrHistoryNotSupported = errors.New("retrieving historical versions is not supported by the modelling bus")
+
+	// The error returned when no version of an artefact exists at or before the requested time
+	ErrNoHistoricalVersion = errors.New("no version exists at or before the requested time")
+)
+
+// Retrieving the state of a JSON artefact, as it was at the given time, together with its timestamp.
+// The modelling bus only retains the latest state of an artefact, so this can only retrieve the
+// state at the given time if it is still the latest one.
+func GetJSONArtefactStateAt(artefactConnector connect.TModellingBusArtefactConnector, agentID, artefactID string, at time.Time) ([]byte, string, error) {
+	artefactConnector.GetJSONArtefactState(agentID, artefactID)
+
+	return versionAt(artefactConnector.CurrentContent, artefactConnector.CurrentTimestamp, at)
+}
+
+// Selecting the retained version of an artefact, with the given content and timestamp, if it
+// was the version at the given time. Returns ErrNoHistoricalVersion if there is no version,
+// and ErrHistoryNotSupported if the version is newer, as the version it replaced is not retained.
+func versionAt(content []byte, timestamp string, at time.Time) ([]byte, string, error) {
+	// Without content, no version was posted at all
+	if len(content) == 0 {
+		return nil, "", ErrNoHistoricalVersion
+	}
+
+	// Determining when the version was posted
+	postingTime, err := ParseTimestamp(timestamp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// A newer version replaced the version at the given time, which is not retained
+	if postingTime.After(at) {
+		return nil, "", ErrHistoryNotSupported
+	}
+
+	return content, timestamp, nil
+}
@@ -0,0 +1,55 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   History (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Selecting the retained version of an artefact as the version at a given time, only when it
+// was posted at or before that time
+func TestVersionAt(t *testing.T) {
+	at := time.Date(2025, 12, 16, 10, 0, 0, 0, time.UTC)
+	content := []byte(`{"model name":"University"}`)
+
+	tests := []struct {
+		name      string
+		content   []byte
+		timestamp string
+		wantErr   error
+	}{
+		{"posted before", content, "2025-12-16T09:59:59Z", nil},
+		{"posted at", content, "2025-12-16T10:00:00Z", nil},
+		{"posted after", content, "2025-12-16T10:00:01Z", ErrHistoryNotSupported},
+		{"never posted", nil, "", ErrNoHistoricalVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotContent, gotTimestamp, err := versionAt(test.content, test.timestamp, at)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("versionAt() error = %v, want %v", err, test.wantErr)
+			}
+
+			if test.wantErr == nil && (string(gotContent) != string(test.content) || gotTimestamp != test.timestamp) {
+				t.Errorf("versionAt() = %s, %q; want %s, %q", gotContent, gotTimestamp, test.content, test.timestamp)
+			}
+		})
+	}
+
+	if _, _, err := versionAt(content, "yesterday", at); err == nil {
+		t.Error("versionAt() accepted an unparsable timestamp")
+	}
+}
@@ -0,0 +1,48 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Kinds
+ *
+ * This component supports listing and explaining the kinds of operations
+ * (retrievals, postings, deletions) supported by the apps.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+ * Listing and explaining kinds
+ */
+
+// Listing the kinds handled by a handler map, in sorted order
+func SortedKinds[THandler any](handlers map[string]THandler) []string {
+	kinds := make([]string, 0, len(handlers))
+	for kind := range handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// Explaining a list of options, as in "a, b, or c"
+func ExplainOptions(options []string) string {
+	switch len(options) {
+	case 0:
+		return ""
+	case 1:
+		return options[0]
+	default:
+		return strings.Join(options[:len(options)-1], ", ") + ", or " + options[len(options)-1]
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Kinds (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"slices"
+	"testing"
+)
+
+// Listing the kinds of a handler map in sorted order, and explaining them as options
+func TestKinds(t *testing.T) {
+	handlers := map[string]func(){"raw_artefact": nil, "coordination": nil, "json_observation": nil}
+
+	kinds := SortedKinds(handlers)
+	if want := []string{"coordination", "json_observation", "raw_artefact"}; !slices.Equal(kinds, want) {
+		t.Errorf("SortedKinds() = %q, want %q", kinds, want)
+	}
+
+	tests := []struct {
+		options []string
+		want    string
+	}{
+		{[]string{}, ""},
+		{[]string{"coordination"}, "coordination"},
+		{[]string{"coordination", "raw_artefact"}, "coordination, or raw_artefact"},
+		{kinds, "coordination, json_observation, or raw_artefact"},
+	}
+
+	for _, test := range tests {
+		if got := ExplainOptions(test.options); got != test.want {
+			t.Errorf("ExplainOptions(%q) = %q, want %q", test.options, got, test.want)
+		}
+	}
+}
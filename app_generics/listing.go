@@ -0,0 +1,76 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Listing
+ *
+ * This component supports discovering which artefacts, and coordination topics, are available
+ * on the modelling bus.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+)
+
+/*
+ * Listing artefacts
+ */
+
+// The error returned when the modelling bus offers no way to enumerate its postings
+var ErrListingNotSupported = errors.New("listing is not supported by the modelling bus")
+
+// Listing the IDs of the artefacts posted by the given agent.
+// The modelling bus connector currently offers no primitive to enumerate postings: artefacts
+// can only be retrieved, or listened for, by their ID. Until it does, this always returns
+// ErrListingNotSupported, so the apps can already offer (and report on) listing.
+func ListArtefacts(modellingBusConnector connect.TModellingBusConnector, agentID string) ([]string, error) {
+	return nil, ErrListingNotSupported
+}
+
+/*
+ * Listing coordinations
+ */
+
+// The modelling buses that can enumerate the coordinations posted by their agent, such
+// as the fake modelling bus
+type TCoordinationLister interface {
+	GetCoordinationsUnder(prefix string) (map[string][]byte, error)
+}
+
+// Getting the coordinations posted by the agent of the modelling bus, whose topic starts
+// with the given prefix, by topic. The modelling bus connector currently offers no primitive
+// to enumerate coordinations, so for the connector this returns ErrListingNotSupported.
+// Adding GetCoordinationsUnder as a method of the connector itself is out of scope for these
+// apps, as the connector belongs to the modelling bus library; buses that offer it are used
+// via TCoordinationLister.
+func GetCoordinationsUnder(modellingBus TModellingBusOperations, prefix string) (map[string][]byte, error) {
+	if lister, canList := modellingBus.(TCoordinationLister); canList {
+		return lister.GetCoordinationsUnder(prefix)
+	}
+
+	return nil, ErrListingNotSupported
+}
+
+// Listing the topics of the coordinations posted by the agent of the modelling bus, which
+// start with the given prefix, in sorted order. As for GetCoordinationsUnder, this returns
+// ErrListingNotSupported for the modelling bus connector, and adding ListCoordinationTopics
+// as a method of the connector itself is out of scope for these apps.
+func ListCoordinationTopics(modellingBus TModellingBusOperations, prefix string) ([]string, error) {
+	coordinations, err := GetCoordinationsUnder(modellingBus, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Sorted(maps.Keys(coordinations)), nil
+}
@@ -0,0 +1,239 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Logging
+ *
+ * This component supports directing the output of the reporters to a log file,
+ * which is rotated once it exceeds a given size, or to any other writer, as well as formatting the reports
+ * as JSON lines, for log aggregation.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	rotatedLogFileExtension = ".1" // Extension of the rotated log file
+
+	TextLogFormat = "text" // Reporting human readable lines
+	JSONLogFormat = "json" // Reporting one JSON object per line
+
+	errorLogLevel    = "error"    // Level of reported errors in JSON reports
+	progressLogLevel = "progress" // Level of reported progress in JSON reports
+)
+
+/*
+ * Defining the rotating log file
+ */
+
+type TRotatingLogFile struct {
+	path    string // Path of the log file
+	maxSize int64  // Size beyond which the log file is rotated; 0 for no rotation
+
+	file *os.File // The log file
+	size int64    // Current size of the log file
+
+	lock sync.Mutex // Guarding the log file, as reports may come from several goroutines
+}
+
+// The log file, or other writer, the reporters write to; nil when writing to the progress and error outputs
+var logOutput io.Writer
+
+// The outputs the reporters write progress and errors to, when not writing to a log file
+var (
+	progressOutput io.Writer = os.Stdout
+	errorOutput    io.Writer = os.Stderr
+)
+
+// Guarding the writer of the reporters, as it need not be safe for concurrent use
+var logOutputLock sync.Mutex
+
+// Opening the log file for appending
+func (l *TRotatingLogFile) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	// Determining the current size
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	l.file = file
+	l.size = info.Size()
+
+	return nil
+}
+
+// Rotating the log file, keeping the previous content as <path>.1
+func (l *TRotatingLogFile) rotate() error {
+	l.file.Close()
+
+	if err := os.Rename(l.path, l.path+rotatedLogFileExtension); err != nil {
+		return err
+	}
+
+	return l.open()
+}
+
+// Writing to the log file, rotating it first when it would exceed its maximum size
+func (l *TRotatingLogFile) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// Rotating, if needed
+	if l.maxSize > 0 && l.size > 0 && l.size+int64(len(p)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+
+	return n, err
+}
+
+// Creating a rotating log file, which is created if needed and appended to otherwise
+func CreateRotatingLogFile(path string, maxSize int64) (*TRotatingLogFile, error) {
+	logFile := &TRotatingLogFile{path: path, maxSize: maxSize}
+
+	if err := logFile.open(); err != nil {
+		return nil, err
+	}
+
+	return logFile, nil
+}
+
+/*
+ * Directing the reporters to a log file
+ */
+
+// Directing all output of the reporters to the given log file, rotating it once
+// it exceeds maxSize bytes (0 for no rotation)
+func SetLogFile(path string, maxSize int64) error {
+	logFile, err := CreateRotatingLogFile(path, maxSize)
+	if err != nil {
+		return err
+	}
+
+	SetReportWriter(logFile)
+
+	return nil
+}
+
+// Directing all output of the reporters to the given writer, such as a bytes.Buffer
+// or an already opened file, or back to the progress and error outputs when nil. As with
+// log files, each report is written as a line prefixed by its level.
+func SetReportWriter(writer io.Writer) {
+	logOutput = writer
+}
+
+// Directing the progress and errors of the reporters to the given standard output and
+// standard error of an app, as passed to its run function
+func SetReportOutput(stdout, stderr io.Writer) {
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+
+	progressOutput, errorOutput = stdout, stderr
+}
+
+// Determining the writer for reports of the given level, being the log file if set, and
+// the error or progress output otherwise
+func reportWriter(level string) io.Writer {
+	switch {
+	case logOutput != nil:
+		return logOutput
+	case level == errorLogLevel:
+		return errorOutput
+	default:
+		return progressOutput
+	}
+}
+
+// Writing a report as a line prefixed by its level
+func writeTextReport(level, message string) {
+	prefix := progressPrefix
+	if level == errorLogLevel {
+		prefix = errorPrefix
+	}
+
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+
+	fmt.Fprintln(reportWriter(level), prefix+message)
+}
+
+/*
+ * Formatting the reports as JSON
+ */
+
+// A report, as written in the JSON log format. The reporters only receive formatted messages,
+// so the only arguments known are the error values reported by generics.TReporter.ReportError,
+// which reports these on a line of their own following the message.
+type TJSONReport struct {
+	Level     string   `json:"level"`          // The level of the report
+	Timestamp string   `json:"ts"`             // The time of the report
+	Message   string   `json:"msg"`            // The formatted message
+	Args      []string `json:"args,omitempty"` // The arguments of the message, if known
+}
+
+// The format of the reports
+var logFormat = TextLogFormat
+
+// Setting the format of the reports to either TextLogFormat or JSONLogFormat
+func SetLogFormat(format string) error {
+	if format != TextLogFormat && format != JSONLogFormat {
+		return fmt.Errorf("unknown log format: %s", format)
+	}
+
+	logFormat = format
+
+	return nil
+}
+
+// Checking if the reports are to be written as JSON
+func reportingJSON() bool {
+	return logFormat == JSONLogFormat
+}
+
+// Writing a report as a JSON line, to the log file if set, and to the error or progress output otherwise
+func writeJSONReport(level, message string, args ...string) {
+	report := TJSONReport{
+		Level:     level,
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Message:   message,
+		Args:      args,
+	}
+
+	line, err := json.Marshal(report)
+	if err != nil {
+		return
+	}
+
+	logOutputLock.Lock()
+	defer logOutputLock.Unlock()
+
+	reportWriter(level).Write(append(line, '\n'))
+}
@@ -0,0 +1,107 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Logging (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Reports are written to the configured writer, as lines prefixed by their level, until the
+// writer is reset, after which they go to the outputs again
+func TestSetReportWriter(t *testing.T) {
+	var stdout, stderr, reports bytes.Buffer
+	SetReportOutput(&stdout, &stderr)
+	t.Cleanup(func() {
+		SetReportWriter(nil)
+		SetReportOutput(os.Stdout, os.Stderr)
+	})
+
+	reporter := CreateReporter(generics.ProgressLevelBasic, generics.ProgressLevelBasic)
+
+	SetReportWriter(&reports)
+	reporter.Progress(generics.ProgressLevelBasic, "Rendering model.")
+	reporter.Error("Compilation failed.")
+
+	SetReportWriter(nil)
+	reporter.Progress(generics.ProgressLevelBasic, "Shutting down.")
+
+	if want := "PROGRESS: Rendering model.\nERROR: Compilation failed.\n"; reports.String() != want {
+		t.Errorf("reports = %q, want %q", reports.String(), want)
+	}
+	if stdout.String() != "PROGRESS: Shutting down.\n" || stderr.String() != "" {
+		t.Errorf("outputs = %q and %q, want only the report after resetting the writer", stdout.String(), stderr.String())
+	}
+}
+
+// Reports are appended to the configured log file, rather than written to the outputs
+func TestSetLogFile(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	SetReportOutput(&stdout, &stderr)
+	t.Cleanup(func() {
+		SetReportWriter(nil)
+		SetReportOutput(os.Stdout, os.Stderr)
+	})
+
+	logFile := filepath.Join(t.TempDir(), "renderer.log")
+	if err := os.WriteFile(logFile, []byte("PROGRESS: earlier run\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetLogFile(logFile, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter := CreateReporter(generics.ProgressLevelBasic, generics.ProgressLevelBasic)
+	reporter.Progress(generics.ProgressLevelBasic, "Received state.")
+	reporter.Error("Error running LaTeX.")
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "PROGRESS: earlier run\nPROGRESS: Received state.\nERROR: Error running LaTeX.\n"; string(content) != want {
+		t.Errorf("log file = %q, want %q", content, want)
+	}
+	if stdout.Len() > 0 || stderr.Len() > 0 {
+		t.Errorf("reported on the outputs as well: %q, %q", stdout.String(), stderr.String())
+	}
+}
+
+// A log file is rotated once a write would make it exceed its maximum size, keeping the
+// previous content as <path>.1
+func TestRotatingLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "renderer.log")
+	logFile, err := CreateRotatingLogFile(path, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{"first line\n", "second line\n", "third\n"} {
+		if _, err := logFile.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for file, want := range map[string]string{
+		path:                           "third\n",
+		path + rotatedLogFileExtension: "first line\nsecond line\n",
+	} {
+		if content, err := os.ReadFile(file); err != nil || string(content) != want {
+			t.Errorf("%s = %q (%v), want %q", filepath.Base(file), content, err, want)
+		}
+	}
+}
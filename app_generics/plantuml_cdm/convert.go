@@ -0,0 +1,175 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Adapter from PlantUML models to CDM models
+ * Component:   Conversion
+ *
+ * This component converts structural PlantUML models to CDM models, so models
+ * maintained in PlantUML can be posted on the modelling bus as CDM models.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package plantuml_cdm
+
+import (
+	"sort"
+	"strings"
+
+	"app_generics/cdm_tools"
+	"plantuml"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	defaultDomain = "string" // Domain of quality types for untyped attributes
+
+	referredInvolvement  = "referred"  // Involvement of an entity in a naming relation type
+	referringInvolvement = "referring" // Involvement of a quality type in a naming relation type
+
+	multiplicityRange = ".." // Separator of the minimum and maximum of a multiplicity
+	anyNumber         = "*"  // Multiplicity standing for any number
+)
+
+/*
+ * Converting models
+ */
+
+// Converting a PlantUML model to a CDM model, where:
+//   - entities become concrete individual types;
+//   - attributes become quality types, linked to their entity by a naming relation type;
+//   - binary associations become relation types, with involvement types derived from
+//     the association ends and label.
+//
+// CDM has no notion of multiplicities or inheritance, so these are not carried over;
+// inheritance relationships are reported and skipped. See ToCDMWithAnnotations for
+// carrying over the multiplicities.
+func ToCDM(model *plantuml.Model, reporter *generics.TReporter) cdm.TCDMModel {
+	CDMModel, _ := ToCDMWithAnnotations(model, reporter)
+
+	return CDMModel
+}
+
+// Converting a PlantUML model to a CDM model as ToCDM does, while carrying over the
+// multiplicities of the associations as annotations of the involvement types
+func ToCDMWithAnnotations(model *plantuml.Model, reporter *generics.TReporter) (cdm.TCDMModel, cdm_tools.TCDMModelAnnotations) {
+	CDMModel := cdm.CreateCDMModel(reporter)
+	annotations := cdm_tools.CreateCDMModelAnnotations()
+
+	// Converting the entities, in a deterministic order
+	entityKeys := make([]string, 0, len(model.Entities))
+	for key := range model.Entities {
+		entityKeys = append(entityKeys, key)
+	}
+	sort.Strings(entityKeys)
+
+	entityTypes := map[string]string{}
+	for _, key := range entityKeys {
+		entity := model.Entities[key]
+		entityTypes[key] = CDMModel.AddConcreteIndividualType(entity.Name)
+
+		// Converting the attributes of the entity
+		for _, attribute := range entity.Attributes {
+			addAttribute(&CDMModel, entity.Name, entityTypes[key], attribute)
+		}
+	}
+
+	// Converting the relationships
+	for _, relationship := range model.Relationships {
+		// CDM has no inheritance
+		if relationship.Kind == plantuml.Inheritance {
+			reporter.Progress(generics.ProgressLevelBasic, "Skipping inheritance of %s from %s.", relationship.From, relationship.To)
+
+			continue
+		}
+
+		// Both ends must be known entities
+		fromType, fromKnown := entityTypes[relationship.From]
+		toType, toKnown := entityTypes[relationship.To]
+		if !fromKnown || !toKnown {
+			reporter.Error("Skipping relationship between %s and %s, which involves an undeclared entity.", relationship.From, relationship.To)
+
+			continue
+		}
+
+		addRelationship(&CDMModel, &annotations, model, relationship, fromType, toType)
+	}
+
+	// Returning the converted model
+	return CDMModel, annotations
+}
+
+// Adding an attribute as a quality type, together with its naming relation type
+func addAttribute(CDMModel *cdm.TCDMModel, entityName, entityType string, attribute plantuml.Attribute) {
+	// Determining the domain
+	domain := attribute.Type
+	if domain == "" {
+		domain = defaultDomain
+	}
+
+	// Adding the quality type
+	qualityName := entityName + " " + attribute.Name
+	qualityType := CDMModel.AddQualityType(qualityName, domain)
+
+	// Adding the naming relation type
+	entityReferred := CDMModel.AddInvolvementType(referredInvolvement, entityType)
+	qualityReferring := CDMModel.AddInvolvementType(referringInvolvement, qualityType)
+	naming := CDMModel.AddRelationType(qualityName+" Naming", entityReferred, qualityReferring)
+	CDMModel.AddRelationTypeReading(naming, "", entityReferred, "has", qualityReferring, "")
+	CDMModel.AddRelationTypeReading(naming, "", qualityReferring, "of", entityReferred, "")
+}
+
+// Adding a binary association as a relation type
+func addRelationship(CDMModel *cdm.TCDMModel, annotations *cdm_tools.TCDMModelAnnotations, model *plantuml.Model,
+	relationship *plantuml.Relationship, fromType, toType string) {
+	fromName := model.Entities[relationship.From].Name
+	toName := model.Entities[relationship.To].Name
+
+	// Deriving the names from the label, if any
+	relationName := fromName + " " + toName
+	verb := "is related to"
+	if relationship.Label != "" {
+		relationName = relationship.Label
+		verb = relationship.Label
+	}
+
+	// Adding the relation type
+	fromInvolvement := CDMModel.AddInvolvementType(verb, fromType)
+	toInvolvement := CDMModel.AddInvolvementType(toName, toType)
+	relationType := CDMModel.AddRelationType(relationName, fromInvolvement, toInvolvement)
+	CDMModel.AddRelationTypeReading(relationType, "", fromInvolvement, verb, toInvolvement, "")
+
+	// The multiplicity at one end of an association limits how often each instance at
+	// the other end is related, so it applies to the involvement type of the other end
+	setMultiplicity(annotations, fromInvolvement, relationship.ToMultiplicity)
+	setMultiplicity(annotations, toInvolvement, relationship.FromMultiplicity)
+}
+
+// Setting the multiplicity of an involvement type from a PlantUML multiplicity, such as "1", "*", or "0..*"
+func setMultiplicity(annotations *cdm_tools.TCDMModelAnnotations, involvementType, multiplicity string) {
+	multiplicity = strings.TrimSpace(multiplicity)
+
+	switch {
+	case multiplicity == "":
+		return
+
+	case multiplicity == anyNumber:
+		annotations.SetInvolvementMultiplicity(involvementType, "0", anyNumber)
+
+	case strings.Contains(multiplicity, multiplicityRange):
+		min, max, _ := strings.Cut(multiplicity, multiplicityRange)
+		annotations.SetInvolvementMultiplicity(involvementType, strings.TrimSpace(min), strings.TrimSpace(max))
+
+	default:
+		annotations.SetInvolvementMultiplicity(involvementType, multiplicity, multiplicity)
+	}
+}
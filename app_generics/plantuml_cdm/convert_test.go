@@ -0,0 +1,102 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Adapter from PlantUML models to CDM models
+ * Component:   Conversion (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package plantuml_cdm
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"app_generics/cdm_tools"
+	"plantuml"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// The university model of the CDM test poster, maintained in PlantUML
+const universityDiagram = `@startuml
+class Student {
+  Name : string
+}
+class "Study Programme" as StudyProgramme {
+  Name : string
+}
+class Person
+Student --|> Person
+Student "*" -- "1..*" StudyProgramme : studies
+@enduml
+`
+
+// The sorted names of the given types of a model
+func typeNames(model cdm.TCDMModel, types map[string]bool) []string {
+	names := []string{}
+	for tpe := range types {
+		names = append(names, model.TypeName[tpe])
+	}
+
+	return slices.Sorted(slices.Values(names))
+}
+
+// Converting the university model from PlantUML gives the types of the university model of
+// the CDM test poster, with the association as a relation type carrying its multiplicities
+func TestToCDMWithAnnotations(t *testing.T) {
+	reporter := generics.CreateReporter(generics.ProgressLevelBasic,
+		func(message string) { t.Errorf("reported error: %s", message) },
+		func(string) {})
+
+	diagram, err := plantuml.NewParser(strings.NewReader(universityDiagram)).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, annotations := ToCDMWithAnnotations(diagram, reporter)
+	university := cdm_tools.BuildUniversityModel(reporter)
+
+	// The concrete individual types and quality types, with their domains
+	if got, want := typeNames(converted, converted.ConcreteIndividualTypes), []string{"Person", "Student", "Study Programme"}; !slices.Equal(got, want) {
+		t.Errorf("concrete individual types = %q, want %q", got, want)
+	}
+	if got, want := typeNames(converted, converted.QualityTypes), typeNames(university, university.QualityTypes); !slices.Equal(got, want) {
+		t.Errorf("quality types = %q, want %q", got, want)
+	}
+	if got := slices.Sorted(maps.Values(converted.DomainOfQualityType)); !slices.Equal(got, []string{"string", "string"}) {
+		t.Errorf("domains of quality types = %q, want string", got)
+	}
+
+	// The association, without the inheritance, next to the naming relation types
+	studies := ""
+	for relationType := range converted.RelationTypes {
+		if converted.TypeName[relationType] == "studies" {
+			studies = relationType
+		}
+	}
+	if len(converted.RelationTypes) != 3 || studies == "" {
+		t.Fatalf("relation types = %q, want the studies association and two naming relation types", typeNames(converted, converted.RelationTypes))
+	}
+	if got, want := cdm_tools.PrimaryReadingSentence(converted, studies), "Student studies Study Programme"; got != want {
+		t.Errorf("primary reading = %q, want %q", got, want)
+	}
+
+	// The multiplicity at one end of the association applies to the involvement type at the other end
+	multiplicities := map[string]string{}
+	for involvementType := range converted.InvolvementTypesOfRelationType[studies] {
+		if multiplicity, ok := annotations.InvolvementMultiplicity(involvementType); ok {
+			multiplicities[converted.TypeName[converted.BaseTypeOfInvolvementType[involvementType]]] = multiplicity.String()
+		}
+	}
+	if want := map[string]string{"Student": "1..*", "Study Programme": "0..*"}; !maps.Equal(multiplicities, want) {
+		t.Errorf("multiplicities = %v, want %v", multiplicities, want)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Worker pool
+ *
+ * This component supports running the tasks of batch operations with a bounded
+ * concurrency, while summarising the outcomes in the order of the tasks, so all
+ * batch operations of the apps behave in the same way.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	Sequential = 1 // Concurrency for tasks that share state, such as the flags of the app, and must run one at a time
+)
+
+/*
+ * Defining task results
+ */
+
+type TTaskResult struct {
+	Task   string // The task
+	Err    error  // The error of the task; nil if it succeeded
+	NotRun bool   // The task was not run, as an earlier task failed while failing fast
+}
+
+/*
+ * Running tasks
+ */
+
+// Running the tasks, with at most concurrency tasks at the same time, and returning
+// their results in the order of the tasks. When failing fast, no further tasks are
+// started once a task failed.
+func RunTasks(concurrency int, failFast bool, tasks []string, work func(task string) error) []TTaskResult {
+	results := make([]TTaskResult, len(tasks))
+	var failed atomic.Bool
+
+	// Feeding the positions of the tasks to the workers
+	positions := make(chan int)
+	go func() {
+		for position := range tasks {
+			positions <- position
+		}
+		close(positions)
+	}()
+
+	// Starting the workers
+	var workers sync.WaitGroup
+	for range max(1, min(concurrency, len(tasks))) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			for position := range positions {
+				// Not starting further tasks once a task failed, when failing fast
+				if failFast && failed.Load() {
+					results[position] = TTaskResult{Task: tasks[position], NotRun: true}
+
+					continue
+				}
+
+				results[position] = TTaskResult{Task: tasks[position], Err: work(tasks[position])}
+				if results[position].Err != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+
+	// Waiting for all tasks to finish
+	workers.Wait()
+
+	return results
+}
+
+// The failure of an action that did not fail itself, while errors were reported as it ran
+type TReportedErrors struct {
+	Count int64 // The number of reported errors
+}
+
+// Describing the reported errors
+func (e TReportedErrors) Error() string {
+	return fmt.Sprintf("%d error(s) reported", e.Count)
+}
+
+// Running an action, and returning its error. As the Modelling Bus Connector only reports
+// its errors via the reporter, rather than returning them, an action that did not fail itself
+// still fails when errors were reported while it ran. As the count of reported errors is
+// shared by the app, this is only precise when no other actions run at the same time.
+func ErrorsReportedBy(action func() error) error {
+	reportedBefore := ReportedErrorCount()
+	if err := action(); err != nil {
+		return err
+	}
+
+	if reported := ReportedErrorCount() - reportedBefore; reported > 0 {
+		return TReportedErrors{Count: reported}
+	}
+
+	return nil
+}
+
+/*
+ * Summarising task results
+ */
+
+// Listing the tasks that failed, in the order of the tasks
+func FailedTasks(results []TTaskResult) []string {
+	failed := []string{}
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Task)
+		}
+	}
+
+	return failed
+}
+
+// Counting the tasks that were not run
+func notRunCount(results []TTaskResult) int {
+	notRun := 0
+	for _, result := range results {
+		if result.NotRun {
+			notRun++
+		}
+	}
+
+	return notRun
+}
+
+// Reporting the summary of a batch operation, e.g. "Posted 3 of 4 artefacts.", followed by
+// the tasks that were not run, and the failed tasks. Returns true if all tasks succeeded.
+func ReportTaskSummary(reporter *generics.TReporter, done, things string, results []TTaskResult) bool {
+	failed := FailedTasks(results)
+	notRun := notRunCount(results)
+
+	reporter.Progress(generics.ProgressLevelBasic, "%s %d of %d %s.", done, len(results)-len(failed)-notRun, len(results), things)
+	if notRun > 0 {
+		reporter.Progress(generics.ProgressLevelBasic, "Stopped after the first failure, leaving %d of %d %s unprocessed.", notRun, len(results), things)
+	}
+	if len(failed) > 0 {
+		reporter.Error("Failed %s: %s.", things, strings.Join(failed, ", "))
+
+		return false
+	}
+
+	return true
+}
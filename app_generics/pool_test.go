@@ -0,0 +1,75 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Worker pool (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// Running tasks yields their results in the order of the tasks, bounded by the concurrency,
+// and, when failing fast, leaves the tasks after a failure unprocessed
+func TestRunTasks(t *testing.T) {
+	tasks := []string{"a", "b", "fail", "c", "d"}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		failFast    bool
+		wantFailed  []string
+		wantNotRun  int
+	}{
+		{"sequential", Sequential, false, []string{"fail"}, 0},
+		{"sequential, failing fast", Sequential, true, []string{"fail"}, 2},
+		{"concurrent", 3, false, []string{"fail"}, 0},
+		{"more workers than tasks", 10, false, []string{"fail"}, 0},
+		{"no concurrency given", 0, false, []string{"fail"}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var running, mostRunning atomic.Int32
+			results := RunTasks(test.concurrency, test.failFast, tasks, func(task string) error {
+				now := running.Add(1)
+				defer running.Add(-1)
+				for seen := mostRunning.Load(); now > seen && !mostRunning.CompareAndSwap(seen, now); seen = mostRunning.Load() {
+				}
+
+				if task == "fail" {
+					return fmt.Errorf("task %s failed", task)
+				}
+
+				return nil
+			})
+
+			got := []string{}
+			for _, result := range results {
+				got = append(got, result.Task)
+			}
+			if !slices.Equal(got, tasks) {
+				t.Errorf("got results for %v, want %v", got, tasks)
+			}
+			if failed := FailedTasks(results); !slices.Equal(failed, test.wantFailed) {
+				t.Errorf("got failed tasks %v, want %v", failed, test.wantFailed)
+			}
+			if notRun := notRunCount(results); notRun != test.wantNotRun {
+				t.Errorf("got %d tasks not run, want %d", notRun, test.wantNotRun)
+			}
+			if most := int(mostRunning.Load()); most > max(1, test.concurrency) {
+				t.Errorf("ran %d tasks at the same time, want at most %d", most, max(1, test.concurrency))
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Reporting
+ *
+ * This component supports creating the reporters used by the apps.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	errorPrefix    = "ERROR: "    // Prefix of reported errors
+	progressPrefix = "PROGRESS: " // Prefix of reported progress
+	errorValueLead = "=> "        // Lead of the line with the error value, as reported by generics.TReporter.ReportError
+
+	ProgressLevelSilent = generics.ProgressLevelBasic - 1 // Progress level suppressing all progress reports, while still reporting errors
+)
+
+// The number of errors reported so far, including those below the error level
+var reportedErrors atomic.Int64
+
+/*
+ * Creating reporters
+ */
+
+// The number of errors reported so far by the reporters, allowing apps to detect
+// whether an operation reported any errors
+func ReportedErrorCount() int64 {
+	return reportedErrors.Load()
+}
+
+// Creating a reporter, with independent thresholds for the progress and the error output.
+// The messages passed to the reporters have already been formatted by the generics.TReporter.
+// Progress messages are reported up to the progress level, while errors are only reported
+// when the error level is at least generics.ProgressLevelBasic.
+// Progress is written to the progress output and errors to the error output (see SetReportOutput),
+// unless a log file is set (see SetLogFile), to which all reports are written instead.
+// SetLogFormat allows for reporting JSON lines rather than human readable ones, where the line
+// with an error value, as reported by generics.TReporter.ReportError, repeats the message it
+// belongs to, with the error value as its argument.
+func CreateReporter(progressLevel, errorLevel int) *generics.TReporter {
+	// The last reported error message, to which a following error value belongs
+	lastErrorMessage := ""
+
+	// Reporting errors, depending on the error level
+	reportError := func(message string) {
+		reportedErrors.Add(1)
+
+		if errorLevel < generics.ProgressLevelBasic {
+			return
+		}
+
+		if reportingJSON() {
+			if errorValue, isErrorValue := strings.CutPrefix(message, errorValueLead); isErrorValue {
+				writeJSONReport(errorLogLevel, lastErrorMessage, errorValue)
+			} else {
+				lastErrorMessage = message
+				writeJSONReport(errorLogLevel, message)
+			}
+		} else {
+			writeTextReport(errorLogLevel, message)
+		}
+	}
+
+	// Reporting progress
+	reportProgress := func(message string) {
+		if reportingJSON() {
+			writeJSONReport(progressLogLevel, message)
+		} else {
+			writeTextReport(progressLogLevel, message)
+		}
+	}
+
+	// Returning the created reporter
+	return generics.CreateReporter(progressLevel, reportError, reportProgress)
+}
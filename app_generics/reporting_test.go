@@ -0,0 +1,119 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Reporting (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Progress is reported on the progress output up to the progress level, while errors are
+// reported on the error output, independent of the progress level
+func TestReportingThresholds(t *testing.T) {
+	tests := []struct {
+		name                    string
+		progressLevel           int
+		errorLevel              int
+		wantProgress, wantError string
+	}{
+		{"basic progress", generics.ProgressLevelBasic, generics.ProgressLevelBasic,
+			"PROGRESS: basic\n", "ERROR: failed\n"},
+		{"detailed progress", generics.ProgressLevelDetailed, generics.ProgressLevelBasic,
+			"PROGRESS: basic\nPROGRESS: detailed\n", "ERROR: failed\n"},
+		{"silent progress", ProgressLevelSilent, generics.ProgressLevelBasic,
+			"", "ERROR: failed\n"},
+		{"silent errors", generics.ProgressLevelBasic, ProgressLevelSilent,
+			"PROGRESS: basic\n", ""},
+	}
+
+	t.Cleanup(func() { SetReportOutput(os.Stdout, os.Stderr) })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			SetReportOutput(&stdout, &stderr)
+
+			reporter := CreateReporter(test.progressLevel, test.errorLevel)
+			reporter.Progress(generics.ProgressLevelBasic, "basic")
+			reporter.Progress(generics.ProgressLevelDetailed, "detailed")
+			reporter.Error("failed")
+
+			if stdout.String() != test.wantProgress {
+				t.Errorf("progress output = %q, want %q", stdout.String(), test.wantProgress)
+			}
+			if stderr.String() != test.wantError {
+				t.Errorf("error output = %q, want %q", stderr.String(), test.wantError)
+			}
+		})
+	}
+}
+
+// Reporting in the JSON log format writes one valid JSON object per line, with the level,
+// time, and message of each report, and the error value of a reported error as its argument
+func TestJSONReports(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	SetReportOutput(&stdout, &stderr)
+	if err := SetLogFormat(JSONLogFormat); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		SetReportOutput(os.Stdout, os.Stderr)
+		SetLogFormat(TextLogFormat)
+	})
+
+	reporter := CreateReporter(generics.ProgressLevelBasic, generics.ProgressLevelBasic)
+	reporter.Progress(generics.ProgressLevelBasic, "Posted %d file(s).", 3)
+	reporter.ReportError("Error opening log file:", errors.New("permission denied"))
+
+	tests := []struct {
+		output string
+		want   []TJSONReport
+	}{
+		{stdout.String(), []TJSONReport{
+			{Level: progressLogLevel, Message: "Posted 3 file(s)."},
+		}},
+		{stderr.String(), []TJSONReport{
+			{Level: errorLogLevel, Message: "Error opening log file:"},
+			{Level: errorLogLevel, Message: "Error opening log file:", Args: []string{"permission denied"}},
+		}},
+	}
+
+	for _, test := range tests {
+		lines := strings.Split(strings.TrimSuffix(test.output, "\n"), "\n")
+		if len(lines) != len(test.want) {
+			t.Fatalf("reported %d line(s), want %d: %q", len(lines), len(test.want), test.output)
+		}
+
+		for i, line := range lines {
+			report := TJSONReport{}
+			if err := json.Unmarshal([]byte(line), &report); err != nil {
+				t.Fatalf("invalid JSON line %q: %s", line, err)
+			}
+			if report.Timestamp == "" {
+				t.Errorf("no timestamp in %q", line)
+			}
+
+			report.Timestamp = ""
+			if !reflect.DeepEqual(report, test.want[i]) {
+				t.Errorf("reported %+v, want %+v", report, test.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Retrying
+ *
+ * This component supports retrying operations that failed due to transient problems,
+ * such as I/O errors and timeouts, with an exponential backoff between the attempts.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	ProgressLevelVerbose = generics.ProgressLevelBasic + 1 // Progress level for verbose reporting, such as retry attempts
+)
+
+/*
+ * Retrying operations
+ */
+
+// Checking if an error is transient, i.e. caused by I/O problems or timeouts, so the
+// operation that failed may succeed when retried. As the Modelling Bus Connector reports
+// failing bus operations rather than returning them, errors reported while an operation
+// ran are considered transient as well, while usage errors are not.
+func IsRetryable(err error) bool {
+	// Failures reported by the Modelling Bus Connector
+	if errors.As(err, &TReportedErrors{}) {
+		return true
+	}
+
+	// Timeouts
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+
+	// Network and I/O problems
+	var opError *net.OpError
+	if errors.As(err, &opError) {
+		return true
+	}
+
+	return errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.EIO)
+}
+
+// Calling fn, and retrying it up to the given number of times as long as it fails with a
+// retryable error. The backoff between the attempts starts at backoff, and doubles after
+// each attempt. Returns the error of the last attempt.
+func Retry(reporter *generics.TReporter, retries int, backoff time.Duration, fn func() error) error {
+	err := fn()
+	for attempt := 1; attempt <= retries && err != nil && IsRetryable(err); attempt++ {
+		reporter.Progress(ProgressLevelVerbose, "Retrying in %s (attempt %d of %d), after: %s", backoff, attempt, retries, err)
+		time.Sleep(backoff)
+
+		err = fn()
+		backoff *= 2
+	}
+
+	return err
+}
+
+// Wrapping a handler, so it is retried when it returns a retryable error, or when errors
+// were reported while it ran. Once an attempt succeeds, the errors reported by the failed
+// attempts no longer count, so they do not fail the app.
+func WithRetries(reporter *generics.TReporter, retries int, backoff time.Duration, handler func() error) func() error {
+	// Without retries, there is nothing to wrap
+	if retries <= 0 {
+		return handler
+	}
+
+	return func() error {
+		// Counting the errors reported by the failed attempts
+		failedAttemptErrors := int64(0)
+		err := Retry(reporter, retries, backoff, func() error {
+			err := ErrorsReportedBy(handler)
+
+			reported := TReportedErrors{}
+			if errors.As(err, &reported) {
+				failedAttemptErrors += reported.Count
+			}
+
+			return err
+		})
+
+		// Discounting the errors of the failed attempts, once an attempt succeeded
+		if err == nil {
+			reportedErrors.Add(-failedAttemptErrors)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Retrying (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// An operation on a fake modelling bus, which fails a given number of times before it
+// succeeds. Like the Modelling Bus Connector, it only reports its failures, unless it is
+// given an error to return.
+type TFlakyOperation struct {
+	failures int   // Number of times to fail
+	err      error // The error to return when failing; nil for only reporting the failure
+
+	calls int // Number of calls so far
+}
+
+// Running the operation
+func (o *TFlakyOperation) run(reporter *generics.TReporter) error {
+	o.calls++
+	if o.calls > o.failures {
+		return nil
+	}
+
+	if o.err != nil {
+		return o.err
+	}
+
+	reporter.Error("Error posting to the modelling bus.")
+
+	return nil
+}
+
+// Retrying an operation that fails a number of times, where failures reported by the connector
+// and I/O errors are retried, but usage errors are not, and the errors of the failed attempts
+// do not count once an attempt succeeds
+func TestWithRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation TFlakyOperation
+		retries   int
+		wantCalls int
+		wantCode  int
+	}{
+		{"reported failures, then success", TFlakyOperation{failures: 2}, 3, 3, 0},
+		{"I/O errors, then success", TFlakyOperation{failures: 2, err: io.ErrUnexpectedEOF}, 3, 3, 0},
+		{"too many reported failures", TFlakyOperation{failures: 5}, 2, 3, ExitBusError},
+		{"usage error", TFlakyOperation{failures: 5, err: ExitWith(ExitUsageError)}, 3, 1, ExitUsageError},
+		{"no retries", TFlakyOperation{failures: 1}, 0, 1, ExitBusError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reporter := generics.CreateReporter(ProgressLevelSilent,
+				func(string) { reportedErrors.Add(1) },
+				func(string) {})
+			operation := test.operation
+
+			errorCount := ReportedErrorCount()
+			err := WithRetries(reporter, test.retries, time.Millisecond, func() error { return operation.run(reporter) })()
+			if err == nil {
+				err = ExitErrorSince(errorCount)
+			}
+
+			if operation.calls != test.wantCalls {
+				t.Errorf("called %d time(s), want %d", operation.calls, test.wantCalls)
+			}
+			if code := ExitCode(err); code != test.wantCode {
+				t.Errorf("exit code = %d, want %d (error: %v)", code, test.wantCode, err)
+			}
+		})
+	}
+}
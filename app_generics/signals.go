@@ -0,0 +1,32 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Signals
+ *
+ * This component supports shutting down long-running apps gracefully on SIGINT/SIGTERM.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+/*
+ * Handling signals
+ */
+
+// Creating a context that is cancelled once the app receives SIGINT or SIGTERM.
+// Calling the returned stop function restores the default handling of these signals.
+func ShutdownContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
@@ -0,0 +1,148 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Timestamps
+ *
+ * This component supports normalising the timestamps of postings on the modelling bus.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+ * Defining timestamp formats
+ */
+
+const (
+	TimestampRaw     = "raw"     // The timestamp as given by the modelling bus
+	TimestampRFC3339 = "rfc3339" // The timestamp in RFC 3339 format
+	TimestampUnix    = "unix"    // The timestamp in seconds since the Unix epoch
+)
+
+// The layout of the timestamps generated by the modelling bus (see generics.GetTimestamp),
+// in local time, which are followed by a counter within the second, as in "2025-12-18-10-22-33-00"
+const busTimestampLayout = "2006-01-02-15-04-05"
+
+// The layouts in which timestamps from the modelling bus are parsed
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"20060102150405",
+}
+
+/*
+ * Formatting timestamps
+ */
+
+// Checking if a timestamp format mode is known
+func IsTimestampFormat(mode string) bool {
+	return mode == TimestampRaw || mode == TimestampRFC3339 || mode == TimestampUnix
+}
+
+// Parsing a timestamp from the modelling bus, as generated by the modelling bus, in any of
+// the known layouts, or as Unix seconds
+func ParseTimestamp(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+
+	// Timestamps generated by the modelling bus, leaving out the counter
+	if counterSeparator := strings.LastIndex(raw, "-"); counterSeparator == len(busTimestampLayout) {
+		if timestamp, err := time.ParseInLocation(busTimestampLayout, raw[:counterSeparator], time.Local); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	for _, layout := range timestampLayouts {
+		if timestamp, err := time.Parse(layout, raw); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown timestamp format: %q", raw)
+}
+
+// Formatting a timestamp from the modelling bus in the given mode. If the timestamp
+// cannot be parsed, the raw timestamp is returned together with the error.
+func FormatTimestamp(raw, mode string) (string, error) {
+	switch mode {
+	case TimestampRaw:
+		return raw, nil
+	case TimestampRFC3339, TimestampUnix:
+		timestamp, err := ParseTimestamp(raw)
+		if err != nil {
+			return raw, err
+		}
+
+		if mode == TimestampUnix {
+			return strconv.FormatInt(timestamp.Unix(), 10), nil
+		}
+
+		return timestamp.Format(time.RFC3339), nil
+	default:
+		return raw, fmt.Errorf("unknown timestamp format mode: %s", mode)
+	}
+}
+
+/*
+ * Parsing ages
+ */
+
+// The units of ages, by their suffix
+var ageUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'h': time.Hour,
+	'm': time.Minute,
+}
+
+// Parsing an age, such as "7d", "12h", "30m", or combinations such as "1d12h"
+func ParseAge(age string) (time.Duration, error) {
+	total := time.Duration(0)
+	remaining := strings.TrimSpace(age)
+	if remaining == "" {
+		return 0, fmt.Errorf("empty age")
+	}
+
+	for remaining != "" {
+		// Taking the number
+		digits := 0
+		for digits < len(remaining) && remaining[digits] >= '0' && remaining[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 || digits == len(remaining) {
+			return 0, fmt.Errorf("invalid age %q; use a number followed by d, h, or m, e.g. 7d", age)
+		}
+		amount, err := strconv.Atoi(remaining[:digits])
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q: %w", age, err)
+		}
+
+		// Taking the unit
+		unit, known := ageUnits[remaining[digits]]
+		if !known {
+			return 0, fmt.Errorf("invalid age %q; unknown unit %q, use d, h, or m", age, remaining[digits])
+		}
+
+		total += time.Duration(amount) * unit
+		remaining = remaining[digits+1:]
+	}
+
+	return total, nil
+}
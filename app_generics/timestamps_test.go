@@ -0,0 +1,83 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Timestamps (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Formatting timestamps in the different modes, including the timestamps generated by the
+// modelling bus, which are in local time
+func TestFormatTimestamp(t *testing.T) {
+	busUnix := strconv.FormatInt(time.Date(2025, 12, 18, 10, 22, 33, 0, time.Local).Unix(), 10)
+
+	tests := []struct {
+		name, raw, mode, want string
+		wantErr               bool
+	}{
+		{"raw", "2025-12-18-10-22-33-07", TimestampRaw, "2025-12-18-10-22-33-07", false},
+		{"bus to unix", "2025-12-18-10-22-33-07", TimestampUnix, busUnix, false},
+		{"rfc3339 to rfc3339", "2025-12-18T10:22:33Z", TimestampRFC3339, "2025-12-18T10:22:33Z", false},
+		{"rfc3339 to unix", "2025-12-18T10:22:33Z", TimestampUnix, "1766053353", false},
+		{"compact to rfc3339", "20251218102233", TimestampRFC3339, "2025-12-18T10:22:33Z", false},
+		{"unix to rfc3339", " 1766053353 ", TimestampRFC3339, "2025-12-18T10:22:33Z", false},
+		{"unparsable", "yesterday", TimestampUnix, "yesterday", true},
+		{"bus with invalid date", "2025-13-18-10-22-33-07", TimestampUnix, "2025-13-18-10-22-33-07", true},
+		{"unknown mode", "1766053353", "julian", "1766053353", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := FormatTimestamp(test.raw, test.mode)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("FormatTimestamp(%q, %q) error = %v, want error %t", test.raw, test.mode, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("FormatTimestamp(%q, %q) = %q, want %q", test.raw, test.mode, got, test.want)
+			}
+		})
+	}
+}
+
+// Parsing ages with day, hour, and minute units, possibly combined
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		age     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"1d12h", 36 * time.Hour, false},
+		{" 0m ", 0, false},
+		{"", 0, true},
+		{"7", 0, true},
+		{"d", 0, true},
+		{"7w", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.age, func(t *testing.T) {
+			got, err := ParseAge(test.age)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseAge(%q) error = %v, want error %t", test.age, err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("ParseAge(%q) = %s, want %s", test.age, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Transfer progress
+ *
+ * This component supports reporting the progress of large transfers, by counting the
+ * bytes read, and periodically reporting them, with a percentage if the total is known.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	DefaultProgressInterval = 5 * time.Second // Default interval between progress reports of transfers
+)
+
+/*
+ * Counting transfers
+ */
+
+// A reader counting the bytes read through it, and periodically reporting them
+type TProgressReader struct {
+	reader      io.Reader           // The reader being counted
+	reporter    *generics.TReporter // The reporter to report progress to
+	description string              // Description of the transfer, as in "Retrieved"
+	total       int64               // Total number of bytes to transfer; 0 if unknown
+	interval    time.Duration       // Interval between progress reports
+	transferred int64               // Number of bytes transferred so far
+	lastReport  time.Time           // Time of the last progress report
+}
+
+// Creating a reader reporting the progress of reading from the given reader, every interval,
+// where the total may be 0 if it is unknown
+func CreateProgressReader(reader io.Reader, reporter *generics.TReporter, description string, total int64, interval time.Duration) *TProgressReader {
+	return &TProgressReader{
+		reader:      reader,
+		reporter:    reporter,
+		description: description,
+		total:       total,
+		interval:    interval,
+		lastReport:  time.Now(),
+	}
+}
+
+// Reading, and reporting the progress when the interval has passed
+func (p *TProgressReader) Read(buffer []byte) (int, error) {
+	read, err := p.reader.Read(buffer)
+	p.transferred += int64(read)
+
+	if p.interval > 0 && time.Since(p.lastReport) >= p.interval {
+		p.ReportProgress()
+	}
+
+	return read, err
+}
+
+// Reporting the number of bytes transferred so far
+func (p *TProgressReader) ReportProgress() {
+	p.lastReport = time.Now()
+
+	if p.total > 0 {
+		p.reporter.Progress(ProgressLevelVerbose, "%s %s of %s (%d%%).", p.description, FormatByteCount(p.transferred), FormatByteCount(p.total), p.transferred*100/p.total)
+	} else {
+		p.reporter.Progress(ProgressLevelVerbose, "%s %s.", p.description, FormatByteCount(p.transferred))
+	}
+}
+
+// The number of bytes transferred so far
+func (p *TProgressReader) Transferred() int64 {
+	return p.transferred
+}
+
+/*
+ * Support functions
+ */
+
+// Formatting a number of bytes, as in "512 B", "1.5 KB", or "2.0 GB"
+func FormatByteCount(count int64) string {
+	const unit = 1024
+	if count < unit {
+		return fmt.Sprintf("%d B", count)
+	}
+
+	divisor, exponent := int64(unit), 0
+	for remaining := count / unit; remaining >= unit; remaining /= unit {
+		divisor *= unit
+		exponent++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(count)/float64(divisor), "KMGTPE"[exponent])
+}
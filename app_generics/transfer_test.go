@@ -0,0 +1,137 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Transfers (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+const (
+	largeTransferSize = 256 << 20 // Size of the large transfer, well beyond any fixed buffer
+	maxTransferAlloc  = 1 << 20   // Maximum allocation allowed for copying the large transfer
+)
+
+// A reader of the given number of zero bytes, without holding them in memory
+type TZeroReader struct {
+	remaining int64 // Number of bytes still to be read
+}
+
+func (z *TZeroReader) Read(buffer []byte) (int, error) {
+	if z.remaining <= 0 {
+		return 0, io.EOF
+	}
+
+	read := min(int64(len(buffer)), z.remaining)
+	clear(buffer[:read])
+	z.remaining -= read
+
+	return int(read), nil
+}
+
+// A writer counting the bytes written, without offering ReadFrom, so io.Copy uses its own buffer
+type TCountingWriter struct {
+	written int64 // Number of bytes written
+}
+
+func (c *TCountingWriter) Write(buffer []byte) (int, error) {
+	c.written += int64(len(buffer))
+
+	return len(buffer), nil
+}
+
+// Copying a large transfer through a progress reader only allocates a fixed buffer
+func TestProgressReaderBoundedAllocation(t *testing.T) {
+	reporter := generics.CreateReporter(ProgressLevelSilent, func(string) {}, func(string) {})
+	reader := CreateProgressReader(&TZeroReader{remaining: largeTransferSize}, reporter, "Copied", largeTransferSize, time.Millisecond)
+	writer := &TCountingWriter{}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	copied, err := io.Copy(writer, reader)
+
+	runtime.ReadMemStats(&after)
+
+	if err != nil || copied != largeTransferSize || writer.written != largeTransferSize {
+		t.Fatalf("copied %d bytes (%v), wrote %d, want %d", copied, err, writer.written, largeTransferSize)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > maxTransferAlloc {
+		t.Fatalf("allocated %d bytes copying %d bytes, want at most %d", allocated, largeTransferSize, maxTransferAlloc)
+	}
+}
+
+// Reading a large transfer through a progress reader reports the bytes transferred, with a
+// percentage when the total is known, while an interval of 0 disables the reports
+func TestProgressReaderReports(t *testing.T) {
+	const size = 4 << 20
+
+	tests := []struct {
+		name     string
+		total    int64
+		interval time.Duration
+		want     string // The final report expected; none if empty
+	}{
+		{"known total", size, time.Nanosecond, "Retrieved 4.0 MB of 4.0 MB (100%)."},
+		{"unknown total", 0, time.Nanosecond, "Retrieved 4.0 MB."},
+		{"no reports", size, 0, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reports := []string{}
+			reporter := generics.CreateReporter(ProgressLevelVerbose, func(string) {}, func(message string) { reports = append(reports, message) })
+			reader := CreateProgressReader(&TZeroReader{remaining: size}, reporter, "Retrieved", test.total, test.interval)
+
+			if _, err := io.Copy(&TCountingWriter{}, reader); err != nil || reader.Transferred() != size {
+				t.Fatalf("transferred %d bytes (%v), want %d", reader.Transferred(), err, size)
+			}
+
+			if test.want == "" {
+				if len(reports) > 0 {
+					t.Errorf("reported %q, want no reports", reports)
+				}
+
+				return
+			}
+			if len(reports) == 0 || !strings.HasSuffix(reports[len(reports)-1], test.want) {
+				t.Errorf("reported %q, want a final report of %q", reports, test.want)
+			}
+		})
+	}
+}
+
+// Formatting byte counts in the largest fitting unit
+func TestFormatByteCount(t *testing.T) {
+	tests := []struct {
+		count int64
+		want  string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KB"},
+		{4 << 20, "4.0 MB"},
+		{2 << 30, "2.0 GB"},
+	}
+
+	for _, test := range tests {
+		if got := FormatByteCount(test.count); got != test.want {
+			t.Errorf("FormatByteCount(%d) = %q, want %q", test.count, got, test.want)
+		}
+	}
+}
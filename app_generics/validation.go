@@ -0,0 +1,134 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Validation
+ *
+ * This component provides validation of the values passed to the apps.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Validating environments
+ */
+
+// Environment names, such as "cdm-hello-world" or "experiment-12.10.2025"
+var environmentRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Validating the name of an environment
+func ValidateEnvironment(environment string) error {
+	if !environmentRegex.MatchString(environment) {
+		return fmt.Errorf("invalid environment name %q; use letters, digits, '.', '_', and '-', starting with a letter or digit", environment)
+	}
+
+	return nil
+}
+
+/*
+ * Validating topics
+ */
+
+// Topic paths, such as "project/alpha/status", consisting of non-empty segments separated
+// by '/', where each segment consists of letters, digits, '.', '_', and '-'. Leading and
+// trailing slashes, empty segments, and the MQTT wildcards '+' and '#' are not allowed.
+var topicRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+(/[A-Za-z0-9._-]+)*$`)
+
+// Validating a topic path, such as a coordination topic or an observation ID
+func ValidateTopic(path string) error {
+	switch {
+	case path == "":
+		return fmt.Errorf("empty topic path")
+	case strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/"):
+		return fmt.Errorf("invalid topic path %q; it may not start or end with '/'", path)
+	case strings.Contains(path, "//"):
+		return fmt.Errorf("invalid topic path %q; it may not contain empty segments", path)
+	case !topicRegex.MatchString(path):
+		return fmt.Errorf("invalid topic path %q; use letters, digits, '.', '_', and '-', in segments separated by '/'", path)
+	}
+
+	return nil
+}
+
+/*
+ * Checking configurations
+ */
+
+// Checking the configuration, by loading it and creating the Modelling Bus Connector from it,
+// without performing any operation on the modelling bus. Returns an ExitConfigError exit
+// error if this reported errors, and nil otherwise. As the connector offers no handshake,
+// problems that only surface when actually using the modelling bus are not detected.
+func CheckConfig(configFile string, overrides []TConfigOverride, postingOnly bool, reporter *generics.TReporter) error {
+	errorCount := ReportedErrorCount()
+
+	// Loading the configuration, and creating the connector
+	configData, err := LoadConfig(configFile, overrides, reporter)
+	if err != nil {
+		reporter.Error("Configuration check of %s failed.", configFile)
+
+		return err
+	}
+	connect.CreateModellingBusConnector(configData, reporter, postingOnly)
+
+	// Reporting the outcome
+	if ReportedErrorCount() > errorCount {
+		reporter.Error("Configuration check of %s failed.", configFile)
+
+		return ExitWith(ExitConfigError)
+	}
+
+	reporter.Progress(generics.ProgressLevelBasic, "Configuration check of %s passed.", configFile)
+
+	return nil
+}
+
+/*
+ * Validating work folders
+ */
+
+// Checking that the work folder exists and is writable, first creating it if requested, so
+// problems surface at startup rather than when the first file is written
+func CheckWorkFolder(workFolder string, create bool) error {
+	// Creating the work folder, if requested
+	if create {
+		if err := os.MkdirAll(workFolder, 0755); err != nil {
+			return fmt.Errorf("cannot create work folder %s: %w", workFolder, err)
+		}
+	}
+
+	// Checking that the work folder exists
+	info, err := os.Stat(workFolder)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("work folder %s does not exist; create it, or use -create_work_folder", workFolder)
+	} else if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("work folder %s is not a directory", workFolder)
+	}
+
+	// Checking that the work folder is writable, by writing a file to it
+	probe, err := os.CreateTemp(workFolder, ".write_check_*")
+	if err != nil {
+		return fmt.Errorf("work folder %s is not writable: %w", workFolder, err)
+	}
+	probe.Close()
+
+	return os.Remove(probe.Name())
+}
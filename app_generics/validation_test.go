@@ -0,0 +1,105 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Validation (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Validating topic paths
+func TestValidateTopic(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"models", false},
+		{"models/university-1.0/state", false},
+		{"a_b/c.d/e-f", false},
+		{"", true},
+		{"/models", true},
+		{"models/", true},
+		{"models//state", true},
+		{"models/+/state", true},
+		{"models/#", true},
+		{"models/uni versity", true},
+	}
+
+	for _, test := range tests {
+		if err := ValidateTopic(test.path); (err != nil) != test.wantErr {
+			t.Errorf("ValidateTopic(%q) = %v, want error %t", test.path, err, test.wantErr)
+		}
+	}
+}
+
+// Checking work folders, which must be existing, writable directories, unless they are to be created
+func TestCheckWorkFolder(t *testing.T) {
+	parent := t.TempDir()
+	file := filepath.Join(parent, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		workFolder string
+		create     bool
+		wantErr    string
+	}{
+		{"existing", parent, false, ""},
+		{"missing", filepath.Join(parent, "missing"), false, "does not exist; create it, or use -create_work_folder"},
+		{"created", filepath.Join(parent, "created", "work"), true, ""},
+		{"not a directory", file, false, "is not a directory"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckWorkFolder(test.workFolder, test.create)
+			if test.wantErr == "" && err != nil {
+				t.Errorf("CheckWorkFolder(%q, %t) = %v, want no error", test.workFolder, test.create, err)
+			}
+			if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+				t.Errorf("CheckWorkFolder(%q, %t) = %v, want an error containing %q", test.workFolder, test.create, err, test.wantErr)
+			}
+		})
+	}
+}
+
+// A work folder under a read-only parent can neither be created, nor written to
+func TestCheckWorkFolderReadOnly(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+
+	parent := t.TempDir()
+	existing := filepath.Join(parent, "existing")
+	if err := os.Mkdir(existing, 0555); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(parent, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chmod(existing, 0755)
+		os.Chmod(parent, 0755)
+	})
+
+	if err := CheckWorkFolder(filepath.Join(parent, "work"), true); err == nil || !strings.Contains(err.Error(), "cannot create work folder") {
+		t.Errorf("creating a work folder under a read-only parent = %v, want a creation error", err)
+	}
+	if err := CheckWorkFolder(existing, false); err == nil || !strings.Contains(err.Error(), "is not writable") {
+		t.Errorf("checking a read-only work folder = %v, want a not writable error", err)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Generic functionality shared by the Modelling Bus Apps
+ * Component:   Version information
+ *
+ * This component supports reporting which build of an app is running, combining the
+ * version of the app with the build metadata embedded by the Go toolchain.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package app_generics
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+)
+
+/*
+ * Reporting versions
+ */
+
+// Writing the version information of an app: its name and version, the VCS revision
+// it was built from (where available), and the Go runtime version
+func WriteVersion(w io.Writer, appName, appVersion string) {
+	fmt.Fprintf(w, "%s, version of %s\n", appName, appVersion)
+
+	// Adding the VCS metadata, if embedded in the build
+	if buildInfo, available := debug.ReadBuildInfo(); available {
+		settings := map[string]string{}
+		for _, setting := range buildInfo.Settings {
+			settings[setting.Key] = setting.Value
+		}
+
+		if revision := settings["vcs.revision"]; revision != "" {
+			if settings["vcs.modified"] == "true" {
+				revision += " (modified)"
+			}
+			fmt.Fprintf(w, "Revision: %s\n", revision)
+		}
+
+		if revisionTime := settings["vcs.time"]; revisionTime != "" {
+			fmt.Fprintf(w, "Revision time: %s\n", revisionTime)
+		}
+	}
+
+	fmt.Fprintf(w, "Go: %s\n", runtime.Version())
+}
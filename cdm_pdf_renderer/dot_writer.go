@@ -0,0 +1,228 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: LaTeX based PDF Renderer for CDM Models, Version 1
+ *
+ * This part of the application renders CDM models as a GraphViz DOT file, for a quick
+ * graph view of the model, as an alternative to rendering them as a PDF file using LaTeX.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+
+	"app_generics/cdm_tools"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	dotFileExtension = ".dot" // DOT file extension
+)
+
+/*
+ * Defining the CDM model DOT writer
+ */
+
+type TCDMModelDOTWriter struct {
+	cdm.TCDMModelListener // The CDM model listener
+
+	dotFile    string // Name of the DOT file
+	workFolder string // Working folder
+
+	shownVersions TShownVersions // The versions of the model elements to show
+
+	DOTfile *os.File // The DOT file
+
+	debouncer *TDebouncer // Coalescing rapid postings into a single rendering
+	watchdog  *TWatchdog  // Supervising the subscriptions, if requested
+
+	reporter *generics.TReporter // The Reporter to be used to report progress, errors, and panics
+}
+
+/*
+ *  String constants for DOT formatting, using GraphViz HTML-like labels
+ */
+
+const (
+	dotToAdd          = "<FONT COLOR=\"green\">%s</FONT>"
+	dotToDelete       = "<FONT COLOR=\"red\"><S>%s</S></FONT>"
+	dotConsiderAdd    = "<FONT COLOR=\"limegreen\">%s</FONT>"
+	dotConsiderDelete = "<FONT COLOR=\"orange\"><S>%s</S></FONT>"
+)
+
+// The formats used to mark changes in DOT
+var dotChangeFormats = TChangeFormats{
+	ToAdd:          dotToAdd,
+	ToDelete:       dotToDelete,
+	ConsiderAdd:    dotConsiderAdd,
+	ConsiderDelete: dotConsiderDelete,
+}
+
+/*
+ * Rendering elements with DOT formatting
+ */
+
+// Rendering model elements
+func (d *TCDMModelDOTWriter) RenderElement(s func(cdm.TCDMModel) string) string {
+	// Getting the shown current, updated, and considered model elements via the access function s
+	current, updated, considered := d.shownVersions.Select(s(d.CurrentModel), s(d.UpdatedModel), s(d.ConsideredModel))
+
+	return RenderChanges(dotChangeFormats, current, updated, considered)
+}
+
+// Render the model name
+func (d *TCDMModelDOTWriter) RenderModelName() string {
+	return d.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.ModelName)
+	})
+}
+
+// Render the type name
+func (d *TCDMModelDOTWriter) RenderTypeName(typeID string) string {
+	return d.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.TypeName[typeID])
+	})
+}
+
+// Render the primary relation type reading
+func (d *TCDMModelDOTWriter) RenderPrimaryRelationTypeReading(relationTypeID string) string {
+	return d.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(cdm_tools.PrimaryReadingSentence(m, relationTypeID))
+	})
+}
+
+// Determining the base type of an involvement type, from the most recent model that defines it
+func (d *TCDMModelDOTWriter) baseTypeOfInvolvementType(involvementType string) string {
+	for _, model := range []cdm.TCDMModel{d.ConsideredModel, d.UpdatedModel, d.CurrentModel} {
+		if baseType := model.BaseTypeOfInvolvementType[involvementType]; baseType != "" {
+			return baseType
+		}
+	}
+
+	return ""
+}
+
+/*
+ * Writing DOT files
+ */
+
+// Writing formatted strings to the DOT file
+func (d *TCDMModelDOTWriter) WriteDOT(format string, parameters ...any) {
+	// Writing to the DOT file
+	d.DOTfile.WriteString(fmt.Sprintf(format, parameters...))
+}
+
+// Writing the types as nodes of the given shape
+func (d *TCDMModelDOTWriter) WriteNodesToDOT(types map[string]bool, shape string) {
+	for _, tpe := range sortedTypes(&d.TCDMModelListener, types) {
+		d.WriteDOT("  \"%s\" [shape=%s, label=<%s>];\n", tpe, shape, d.RenderTypeName(tpe))
+	}
+}
+
+// Writing the model to a DOT file
+func (d *TCDMModelDOTWriter) WriteModelToDOT() {
+	// Creating the DOT file
+	d.DOTfile, _ = os.Create(d.workFolder + "/" + d.dotFile + dotFileExtension)
+
+	// Ensuring the DOT file is closed afterwards
+	defer d.DOTfile.Close()
+
+	// Writing the DOT file header
+	d.WriteDOT("digraph CDM {\n")
+	d.WriteDOT("  label=<CDM Model: %s>;\n", d.RenderModelName())
+	d.WriteDOT("  labelloc=t;\n")
+
+	// Writing the concrete individual types and quality types as nodes
+	d.WriteNodesToDOT(d.ConcreteIndividualTypes(), "box")
+	d.WriteNodesToDOT(d.QualityTypes(), "ellipse")
+
+	// Writing the relation types
+	for _, relationType := range sortedTypes(&d.TCDMModelListener, d.RelationTypes()) {
+		reading := d.RenderPrimaryRelationTypeReading(relationType)
+		if reading == "" {
+			reading = d.RenderTypeName(relationType)
+		}
+
+		involvementTypes := sortedInvolvementTypes(&d.TCDMModelListener, d.InvolvementTypesOfRelationType(relationType))
+		if len(involvementTypes) == 2 {
+			// Binary relation types become a labelled edge
+			d.WriteDOT("  \"%s\" -> \"%s\" [label=<%s>];\n",
+				d.baseTypeOfInvolvementType(involvementTypes[0]), d.baseTypeOfInvolvementType(involvementTypes[1]), reading)
+		} else {
+			// Other relation types become a node, linked to the involved types
+			d.WriteDOT("  \"%s\" [shape=diamond, label=<%s>];\n", relationType, reading)
+			for _, involvementType := range involvementTypes {
+				d.WriteDOT("  \"%s\" -> \"%s\" [arrowhead=none, label=<%s>];\n",
+					relationType, d.baseTypeOfInvolvementType(involvementType), d.RenderTypeName(involvementType))
+			}
+		}
+	}
+
+	// Writing the DOT file footer
+	d.WriteDOT("}\n")
+}
+
+// Updating the rendering based on the current model state
+func (d *TCDMModelDOTWriter) UpdateRendering(message string) {
+	// Reporting on the update
+	d.reporter.Progress(generics.ProgressLevelBasic, "%s", message)
+	ReportModelChanges(&d.TCDMModelListener, d.reporter)
+
+	// Signalling the watchdog that postings are arriving
+	d.watchdog.Beat()
+
+	// Writing the model to DOT, once no further postings arrive within the debounce window
+	d.debouncer.Call(d.WriteModelToDOT)
+}
+
+// Finishing the rendering in progress, before shutting down
+func (d *TCDMModelDOTWriter) Shutdown() {
+	d.debouncer.Flush()
+}
+
+// Setting up listening for model postings
+func (d *TCDMModelDOTWriter) ListenForModelPostings(agentID, modelID string) {
+	// Listening for model state, update, and considering postings
+	ListenForGuardedModelPostings(&d.TCDMModelListener, d.debouncer, agentID, modelID, d.UpdateRendering)
+}
+
+// Rendering the model once, based on the retained state, update, and considered postings
+func (d *TCDMModelDOTWriter) RenderOnce(agentID, modelID string) {
+	// Getting all three aspects of the model
+	GetAllModelAspects(&d.TCDMModelListener, d.reporter, agentID, modelID)
+
+	// Writing the model to DOT
+	d.WriteModelToDOT()
+}
+
+// Creating the CDM model DOT writer
+func CreateCDMDOTWriter(configData *generics.TConfigData, modelListener cdm.TCDMModelListener, reporter *generics.TReporter) *TCDMModelDOTWriter {
+	// Creating the CDM model DOT writer
+	CDMModelDOTWriter := &TCDMModelDOTWriter{}
+	CDMModelDOTWriter.reporter = reporter
+	CDMModelDOTWriter.TCDMModelListener = modelListener
+	CDMModelDOTWriter.shownVersions = allVersions
+
+	// Setting up the DOT writer based on the config data
+	CDMModelDOTWriter.workFolder = configData.GetValue("", "work_folder").String()
+	CDMModelDOTWriter.dotFile = configData.GetValue("", "latex").String()
+	CDMModelDOTWriter.debouncer = CreateDebouncer(configData, reporter)
+
+	// Returning the created DOT writer
+	return CDMModelDOTWriter
+}
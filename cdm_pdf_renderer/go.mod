@@ -2,7 +2,10 @@ module cdm_pdf_rendering
 
 go 1.24.0
 
-require github.com/erikproper/big-modelling-bus.go.v1 v1.0.32
+require (
+	app_generics v0.0.0
+	github.com/erikproper/big-modelling-bus.go.v1 v1.0.32
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -21,4 +24,10 @@ require (
 	golang.org/x/sync v0.19.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
+	plantuml v0.0.0 // indirect
+)
+
+replace (
+	app_generics => ../app_generics
+	plantuml => ../plantuml
 )
@@ -0,0 +1,298 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: LaTeX based PDF Renderer for CDM Models, Version 1
+ *
+ * This part of the application renders CDM models as a self-contained HTML file,
+ * as an alternative to rendering them as a PDF file using LaTeX.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	htmlFileExtension = ".html" // HTML file extension
+)
+
+/*
+ * Defining the CDM model HTML writer
+ */
+
+type TCDMModelHTMLWriter struct {
+	cdm.TCDMModelListener // The CDM model listener
+
+	htmlFile   string // Name of the HTML file
+	workFolder string // Working folder
+
+	primaryReadingsOnly bool           // Only render the primary readings of relation types
+	shownVersions       TShownVersions // The versions of the model elements to show
+
+	HTMLfile *os.File // The HTML file
+
+	debouncer *TDebouncer // Coalescing rapid postings into a single rendering
+	watchdog  *TWatchdog  // Supervising the subscriptions, if requested
+
+	reporter *generics.TReporter // The Reporter to be used to report progress, errors, and panics
+}
+
+/*
+ *  String constants for HTML formatting
+ */
+
+const (
+	htmlToAdd          = "<span style=\"color: green\">%s</span>"
+	htmlToDelete       = "<span style=\"color: red; text-decoration: line-through double\">%s</span>"
+	htmlConsiderAdd    = "<span style=\"color: lime\">%s</span>"
+	htmlConsiderDelete = "<span style=\"color: orange; text-decoration: line-through double\">%s</span>"
+)
+
+// The formats used to mark changes in HTML
+var htmlChangeFormats = TChangeFormats{
+	ToAdd:          htmlToAdd,
+	ToDelete:       htmlToDelete,
+	ConsiderAdd:    htmlConsiderAdd,
+	ConsiderDelete: htmlConsiderDelete,
+}
+
+/*
+ * Rendering elements with HTML formatting
+ */
+
+// Rendering model elements
+func (h *TCDMModelHTMLWriter) RenderElement(s func(cdm.TCDMModel) string) string {
+	// Getting the shown current, updated, and considered model elements via the access function s
+	current, updated, considered := h.shownVersions.Select(s(h.CurrentModel), s(h.UpdatedModel), s(h.ConsideredModel))
+
+	return RenderChanges(htmlChangeFormats, current, updated, considered)
+}
+
+// Render the model name
+func (h *TCDMModelHTMLWriter) RenderModelName() string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.ModelName)
+	})
+}
+
+// Render the type name of the base type of an involvement type
+func (h *TCDMModelHTMLWriter) RenderTypeNameOfBaseTypeOfInvolvementType(involvementType string) string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.TypeName[m.BaseTypeOfInvolvementType[involvementType]])
+	})
+}
+
+// Render the domain name of a quality type
+func (h *TCDMModelHTMLWriter) RenderDomainNameOfQualityType(typeID string) string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.DomainOfQualityType[typeID])
+	})
+}
+
+// Render the type name
+func (h *TCDMModelHTMLWriter) RenderTypeName(typeID string) string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return html.EscapeString(m.TypeName[typeID])
+	})
+}
+
+// Render a relation type reading
+func (h *TCDMModelHTMLWriter) RenderRelationTypeReading(m cdm.TCDMModel, reading string) string {
+	readingString := ""
+	// Building up the reading string
+	for involvementPosition, involvementType := range m.ReadingDefinition[reading].InvolvementTypes {
+		if involvementPosition == 0 {
+			readingString += html.EscapeString(m.ReadingDefinition[reading].ReadingElements[involvementPosition])
+		}
+
+		readingString += " " +
+			html.EscapeString(m.TypeName[m.BaseTypeOfInvolvementType[involvementType]]) +
+			" { " + html.EscapeString(m.TypeName[involvementType]) + " } " +
+			html.EscapeString(m.ReadingDefinition[reading].ReadingElements[involvementPosition+1])
+	}
+
+	// Returning the built reading string
+	return strings.TrimSpace(readingString)
+}
+
+// Render the primary relation type reading
+func (h *TCDMModelHTMLWriter) RenderPrimaryRelationTypeReading(relationTypeID string) string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return h.RenderRelationTypeReading(m, m.PrimaryReadingOfRelationType[relationTypeID])
+	})
+}
+
+// Render a relation type reading
+func (h *TCDMModelHTMLWriter) RenderAlternativeRelationTypeReading(reading string) string {
+	return h.RenderElement(func(m cdm.TCDMModel) string {
+		return h.RenderRelationTypeReading(m, reading)
+	})
+}
+
+/*
+ * Writing HTML files
+ */
+
+// Writing formatted strings to the HTML file
+func (h *TCDMModelHTMLWriter) WriteHTML(format string, parameters ...any) {
+	// Writing to the HTML file
+	h.HTMLfile.WriteString(fmt.Sprintf(format, parameters...))
+}
+
+// Writing types to the HTML file
+func (h *TCDMModelHTMLWriter) WriteTypesToHTML(sectionTitle string, types map[string]bool, writeTypeToHTML func(string)) {
+	// Let's assume the list is empty, by default.
+	empty := true
+	for _, tpe := range sortedTypes(&h.TCDMModelListener, types) {
+		// Writing the section header, if this is the first included type
+		if empty {
+			h.WriteHTML("<h2>%s</h2>\n", sectionTitle)
+			h.WriteHTML("<ul>\n")
+		}
+
+		// Marking that the list is not empty
+		empty = false
+
+		// Writing the type itself
+		writeTypeToHTML(tpe)
+	}
+
+	// Closing the list, if needed
+	if !empty {
+		h.WriteHTML("</ul>\n")
+	}
+}
+
+// Writing the model to an HTML file
+func (h *TCDMModelHTMLWriter) WriteModelToHTML() {
+	// Creating the HTML file
+	h.HTMLfile, _ = os.Create(h.workFolder + "/" + h.htmlFile + htmlFileExtension)
+
+	// Ensuring the HTML file is closed afterwards
+	defer h.HTMLfile.Close()
+
+	// Writing the HTML file header
+	h.WriteHTML("<!DOCTYPE html>\n")
+	h.WriteHTML("<html>\n")
+	h.WriteHTML("<head>\n")
+	h.WriteHTML("<meta charset=\"utf-8\">\n")
+	h.WriteHTML("<title>CDM Model</title>\n")
+	h.WriteHTML("<style>body { font-family: sans-serif; }</style>\n")
+	h.WriteHTML("</head>\n")
+	h.WriteHTML("<body>\n")
+	h.WriteHTML("<h1>CDM Model: %s</h1>\n", h.RenderModelName())
+
+	// Writing the quality types to the HTML file
+	h.WriteTypesToHTML("Quality types", h.QualityTypes(), func(qualityType string) {
+		h.WriteHTML("  <li>%s with domain %s</li>\n", h.RenderTypeName(qualityType), h.RenderDomainNameOfQualityType(qualityType))
+	})
+
+	// Writing the concrete individual types to the HTML file
+	h.WriteTypesToHTML("Concrete individual types", h.ConcreteIndividualTypes(), func(concreteIndividualType string) {
+		h.WriteHTML("  <li>%s</li>\n", h.RenderTypeName(concreteIndividualType))
+	})
+
+	// Writing the relation types to the HTML file
+	h.WriteTypesToHTML("Relation types", h.RelationTypes(), func(relationType string) {
+		h.WriteHTML("  <li>%s: { ", h.RenderTypeName(relationType))
+
+		// Writing the involvement types of the relation type
+		sep := ""
+		for _, involvementType := range sortedInvolvementTypes(&h.TCDMModelListener, h.InvolvementTypesOfRelationType(relationType)) {
+			h.WriteHTML("%s%s %s", sep, h.RenderTypeNameOfBaseTypeOfInvolvementType(involvementType), h.RenderTypeName(involvementType))
+			sep = "; "
+		}
+		h.WriteHTML(" }\n")
+
+		// Writing the primary reading of the relation type
+		if primaryRelationTypeReading := h.RenderPrimaryRelationTypeReading(relationType); primaryRelationTypeReading != "" {
+			h.WriteHTML("    <p>Primary reading:</p>\n")
+			h.WriteHTML("    <ul>\n")
+			h.WriteHTML("      <li>%s</li>\n", primaryRelationTypeReading)
+			h.WriteHTML("    </ul>\n")
+		}
+
+		// Writing the alternative readings of the relation type, unless only the primary readings are wanted
+		if !h.primaryReadingsOnly && len(h.AlternativeReadingsOfRelationType(relationType)) > 0 {
+			h.WriteHTML("    <p>Alternative reading(s):</p>\n")
+			h.WriteHTML("    <ul>\n")
+			for _, reading := range sortedReadings(&h.TCDMModelListener, h.AlternativeReadingsOfRelationType(relationType)) {
+				h.WriteHTML("      <li>%s</li>\n", h.RenderAlternativeRelationTypeReading(reading))
+			}
+			h.WriteHTML("    </ul>\n")
+		}
+
+		h.WriteHTML("  </li>\n")
+	})
+
+	// Writing the HTML file footer
+	h.WriteHTML("</body>\n")
+	h.WriteHTML("</html>\n")
+}
+
+// Updating the rendering based on the current model state
+func (h *TCDMModelHTMLWriter) UpdateRendering(message string) {
+	// Reporting on the update
+	h.reporter.Progress(generics.ProgressLevelBasic, "%s", message)
+	ReportModelChanges(&h.TCDMModelListener, h.reporter)
+
+	// Signalling the watchdog that postings are arriving
+	h.watchdog.Beat()
+
+	// Writing the model to HTML, once no further postings arrive within the debounce window
+	h.debouncer.Call(h.WriteModelToHTML)
+}
+
+// Finishing the rendering in progress, before shutting down
+func (h *TCDMModelHTMLWriter) Shutdown() {
+	h.debouncer.Flush()
+}
+
+// Setting up listening for model postings
+func (h *TCDMModelHTMLWriter) ListenForModelPostings(agentID, modelID string) {
+	// Listening for model state, update, and considering postings
+	ListenForGuardedModelPostings(&h.TCDMModelListener, h.debouncer, agentID, modelID, h.UpdateRendering)
+}
+
+// Rendering the model once, based on the retained state, update, and considered postings
+func (h *TCDMModelHTMLWriter) RenderOnce(agentID, modelID string) {
+	// Getting all three aspects of the model
+	GetAllModelAspects(&h.TCDMModelListener, h.reporter, agentID, modelID)
+
+	// Writing the model to HTML
+	h.WriteModelToHTML()
+}
+
+// Creating the CDM model HTML writer
+func CreateCDMHTMLWriter(configData *generics.TConfigData, modelListener cdm.TCDMModelListener, reporter *generics.TReporter) *TCDMModelHTMLWriter {
+	// Creating the CDM model HTML writer
+	CDMModelHTMLWriter := &TCDMModelHTMLWriter{}
+	CDMModelHTMLWriter.reporter = reporter
+	CDMModelHTMLWriter.TCDMModelListener = modelListener
+	CDMModelHTMLWriter.shownVersions = allVersions
+
+	// Setting up the HTML writer based on the config data
+	CDMModelHTMLWriter.workFolder = configData.GetValue("", "work_folder").String()
+	CDMModelHTMLWriter.htmlFile = configData.GetValue("", "latex").String()
+	CDMModelHTMLWriter.debouncer = CreateDebouncer(configData, reporter)
+
+	// Returning the created HTML writer
+	return CDMModelHTMLWriter
+}
@@ -15,13 +15,21 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"app_generics"
+	"app_generics/cdm_tools"
+
 	"github.com/erikproper/big-modelling-bus.go.v1/connect"
 	"github.com/erikproper/big-modelling-bus.go.v1/generics"
 	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
@@ -35,31 +43,108 @@ const (
 	defaultIni          = "config.ini" // Default configuration file name
 	latexFileExtension  = ".tex"       // LaTeX file extension
 	latexDefaultCommand = "pdflatex"   // Default LaTeX command
+
+	latexDefaultDocumentClass = "article" // Default LaTeX document class
+	latexDefaultClassOptions  = "a4paper" // Default options of the LaTeX document class
+
+	renderDebounceDefault = "500" // Default window, in milliseconds, for coalescing postings into one rendering
+
+	latexDefaultAuthor = "~~"                   // Default author of the LaTeX document
+	autoDate           = "auto"                 // The pdf_date setting for using the timestamp of the most recent state posting
+	autoDateFormat     = "2 January 2006 15:04" // Format of the date of the LaTeX document when using the auto setting
+
+	diagramColumns     = 3 // Number of columns in the grid layout of diagrams
+	diagramColumnWidth = 5 // Width, in cm, of the columns in the grid layout of diagrams
+	diagramRowHeight   = 2 // Height, in cm, of the rows in the grid layout of diagrams
+
+	pdfFormat  = "pdf"  // PDF output format, using LaTeX
+	htmlFormat = "html" // HTML output format
+	dotFormat  = "dot"  // GraphViz DOT output format
+
+	primaryReadings = "primary" // Only rendering the primary readings of relation types
+	allReadings     = "all"     // Rendering all readings of relation types
+
+	appName = "cdm_pdf_renderer" // Name of the app, as reported by -version
 )
 
+// Version of the app, as reported by -version; can be set at build time using
+// -ldflags "-X main.appVersion=..."
+var appVersion = "16.12.2025"
+
 /*
  * Defining flags
  */
 
 var (
-	configFlag      = flag.String("config", defaultIni, "Configuration file")               // Configuration file flag
-	reportLevelFlag = flag.Int("reporting", generics.ProgressLevelBasic, "Reporting level") // Reporting level flag
-	modelIDFlag     = flag.String("for_model", "", "Model ID to listen for")                // Model ID to listen for flag
-	agentIDFlag     = flag.String("from_agent", "", "Agent ID to listen to")                // Agent ID to listen to flag
+	flags = flag.NewFlagSet(appName, flag.ContinueOnError) // The flags of the app
+
+	configFlag           = flags.String("config", defaultIni, "Configuration file")                                                                                                                      // Configuration file flag
+	versionFlag          = flags.Bool("version", false, "Report the version of the app, and exit")                                                                                                       // Version flag
+	configSetFlag        = app_generics.ConfigOverridesFlag(flags, "set", "Configuration value to override, as key=value or section.key=value (repeatable)")                                             // Configuration override flag
+	reportLevelFlag      = flags.Int("reporting", generics.ProgressLevelBasic, "Reporting level")                                                                                                        // Reporting level flag
+	errorReportLevelFlag = flags.Int("error_reporting", generics.ProgressLevelBasic, "Error reporting level")                                                                                            // Error reporting level flag
+	logFileFlag          = flags.String("log_file", "", "Log file to write the reports to, instead of the standard output and standard error")                                                           // Log file flag
+	logMaxSizeFlag       = flags.Int64("log_max_size", 0, "Size in bytes beyond which the log file is rotated (0 for no rotation)")                                                                      // Log file rotation size flag
+	logFormatFlag        = flags.String("log_format", app_generics.TextLogFormat, "Format of the reports: text or json")                                                                                 // Log format flag
+	configCheckFlag      = flags.Bool("config_check", false, "Only check that the connector can be created from the configuration, and exit")                                                            // Configuration check flag
+	createWorkFolderFlag = flags.Bool("create_work_folder", false, "Create the work folder, if it does not exist yet")                                                                                   // Create work folder flag
+	modelIDFlag          = flags.String("for_model", "", "Model ID to listen for")                                                                                                                       // Model ID to listen for flag
+	agentIDFlag          = flags.String("from_agent", "", "Agent ID to listen to")                                                                                                                       // Agent ID to listen to flag
+	noCompileFlag        = flags.Bool("no_compile", false, "Only write the LaTeX file, without compiling it")                                                                                            // No compile flag
+	formatFlag           = flags.String("format", pdfFormat, "Output format. One of: "+pdfFormat+", "+htmlFormat+", or "+dotFormat+".")                                                                  // Output format flag
+	diagramFlag          = flags.Bool("diagram", false, "Include a TikZ diagram of the model in the PDF")                                                                                                // Diagram flag
+	noLegendFlag         = flags.Bool("no_legend", false, "Leave out the legend explaining the marking of changes in the PDF")                                                                           // No legend flag
+	onceFlag             = flags.Bool("once", false, "Render once, based on the retained state, update, and considered")                                                                                 // Render once flag
+	readingsFlag         = flags.String("readings", "", "Readings of relation types to render. One of: "+primaryReadings+", or "+allReadings+" (default from the readings setting, or "+allReadings+")") // Readings flag
+	showStateFlag        = flags.Bool("show_state", true, "Show the current state of the model elements")                                                                                                // Show state flag
+	showUpdateFlag       = flags.Bool("show_update", true, "Show the updates of the model elements, as changes to the shown state")                                                                      // Show update flag
+	showConsideredFlag   = flags.Bool("show_considered", true, "Show the considered versions of the model elements, as changes to the shown update")                                                     // Show considered flag
+	heartbeatTimeoutFlag = flags.Duration("heartbeat_timeout", 0, "Time without postings after which the subscriptions are re-established (0 to disable)")                                               // Heartbeat timeout flag
+	maxBackoffFlag       = flags.Duration("max_backoff", 5*time.Minute, "Maximum time between attempts to re-establish the subscriptions")                                                               // Maximum backoff flag
 )
 
 /*
- * Defining the CDM model LaTeX writer
+ * Defining the CDM model writers
  */
 
+// The functionality shared by the LaTeX, HTML, and DOT writers
+type TCDMModelWriter interface {
+	ListenForModelPostings(agentID, modelID string) // Setting up listening for model postings
+	RenderOnce(agentID, modelID string)             // Rendering the model once, based on the retained postings
+	Shutdown()                                      // Finishing the rendering in progress, before shutting down
+}
+
 type TCDMModelLaTeXWriter struct {
 	cdm.TCDMModelListener // The CDM model listener
 
 	latexFile    string // Name of the LaTeX file
 	latexCommand string // Command to run LaTeX
+	latexClass   string // Document class of the LaTeX document
+	latexOptions string // Options of the document class; empty for none
+	preambleFile string // File with additional preamble lines; empty for none
 	workFolder   string // Working folder
+	noCompile    bool   // Only write the LaTeX file, without creating the PDF
+	diagram      bool   // Include a diagram of the model
+	noLegend     bool   // Leave out the legend explaining the marking of changes
+
+	primaryReadingsOnly bool           // Only render the primary readings of relation types
+	shownVersions       TShownVersions // The versions of the model elements to show
+
+	author           string // Author of the LaTeX document
+	date             string // Date of the LaTeX document; empty for LaTeX's default, or auto
+	postingTimestamp string // Timestamp of the most recent state posting, as posted on the modelling bus
 
-	LaTeXfile *os.File // The LaTeX file
+	annotations cdm_tools.TCDMAnnotationsListener // The listener for the annotations of the model
+
+	LaTeXfile       *os.File // The LaTeX file
+	changesRendered bool     // Whether changes were rendered in the LaTeX file being written
+
+	debouncer *TDebouncer // Coalescing rapid postings into a single rendering
+	watchdog  *TWatchdog  // Supervising the subscriptions, if requested
+
+	renderLock      sync.Mutex         // Ensuring only one rendering runs at a time
+	cancelLock      sync.Mutex         // Guarding the cancellation of the current rendering
+	cancelRendering context.CancelFunc // Cancels the rendering currently in progress
 
 	reporter *generics.TReporter // The Reporter to be used to report progress, errors, and panics
 }
@@ -75,10 +160,26 @@ const (
 	considerDelete = "{\\color{orange} \\sout{\\sout{%s}}}"
 )
 
+// The formats used to mark changes in LaTeX
+var latexChangeFormats = TChangeFormats{
+	ToAdd:          toAdd,
+	ToDelete:       toDelete,
+	ConsiderAdd:    considerAdd,
+	ConsiderDelete: considerDelete,
+}
+
 /*
- * Rendering elements with LaTeX formatting
+ * Rendering changes between the current, updated, and considered versions of model elements
  */
 
+// Formats used to mark the changes between the current, updated, and considered versions
+type TChangeFormats struct {
+	ToAdd          string // Format for elements to be added
+	ToDelete       string // Format for elements to be deleted
+	ConsiderAdd    string // Format for elements considered to be added
+	ConsiderDelete string // Format for elements considered to be deleted
+}
+
 // Applying formatting
 func ApplyFormatting(format, value string) string {
 	if value == "" {
@@ -88,33 +189,155 @@ func ApplyFormatting(format, value string) string {
 	}
 }
 
-// Rendering model elements
-func (l *TCDMModelLaTeXWriter) RenderElement(s func(cdm.TCDMModel) string) string {
-	// Getting the current, updated, and considered model elements via the access function s
-	current := s(l.CurrentModel)
-	updated := s(l.UpdatedModel)
-	considered := s(l.ConsideredModel)
+// Kinds of changes between the current, updated, and considered versions of a model element
+type TDiffKind int
+
+const (
+	DiffUnchanged       TDiffKind = iota // Unchanged in all versions
+	DiffAdded                            // To be added
+	DiffDeleted                          // To be deleted
+	DiffConsiderAdded                    // Considered to be added
+	DiffConsiderDeleted                  // Considered to be deleted
+)
+
+// A span of text of a model element, with the kind of change it represents
+type TDiffSpan struct {
+	Text string    // The text of the span
+	Kind TDiffKind // The kind of change
+}
+
+// Determining the changes between the current, updated, and considered versions of a model element
+func DiffElement(current, updated, considered string) []TDiffSpan {
+	spans := []TDiffSpan{}
 
-	// Deciding on the formatting to apply
+	// Adding a span, if there is text to be shown
+	addSpan := func(kind TDiffKind, text string) {
+		if text != "" {
+			spans = append(spans, TDiffSpan{Text: text, Kind: kind})
+		}
+	}
+
+	// Deciding on the changes
 	if considered == updated {
 		// No changes between the considered version and the updated version
 		if updated == current {
 			// No changes between the current version and the updated version
-			return current
+			addSpan(DiffUnchanged, current)
 		} else {
 			// Changes between the current version and the updated version
-			return ApplyFormatting(toDelete, current) + ApplyFormatting(toAdd, updated)
+			addSpan(DiffDeleted, current)
+			addSpan(DiffAdded, updated)
 		}
 	} else {
 		// Changes between the considered version and the updated version
 		if updated == current {
 			// No changes between the current version and the updated version
-			return ApplyFormatting(considerDelete, updated) + ApplyFormatting(considerAdd, considered)
+			addSpan(DiffConsiderDeleted, updated)
+			addSpan(DiffConsiderAdded, considered)
 		} else {
 			// Changes between the current version and the updated version
-			return ApplyFormatting(toDelete, current) + ApplyFormatting(considerDelete, updated) + ApplyFormatting(considerAdd, considered)
+			addSpan(DiffDeleted, current)
+			addSpan(DiffConsiderDeleted, updated)
+			addSpan(DiffConsiderAdded, considered)
 		}
 	}
+
+	return spans
+}
+
+// Checking if there are changes between the current, updated, and considered versions of a model element
+func HasChanges(current, updated, considered string) bool {
+	for _, span := range DiffElement(current, updated, considered) {
+		if span.Kind != DiffUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Formatting a span, based on the kind of change it represents
+func (f TChangeFormats) FormatSpan(span TDiffSpan) string {
+	switch span.Kind {
+	case DiffAdded:
+		return ApplyFormatting(f.ToAdd, span.Text)
+	case DiffDeleted:
+		return ApplyFormatting(f.ToDelete, span.Text)
+	case DiffConsiderAdded:
+		return ApplyFormatting(f.ConsiderAdd, span.Text)
+	case DiffConsiderDeleted:
+		return ApplyFormatting(f.ConsiderDelete, span.Text)
+	default:
+		return span.Text
+	}
+}
+
+// The versions of model elements to show, where the changes are only rendered between the
+// shown versions
+type TShownVersions struct {
+	State      bool // Showing the current state
+	Update     bool // Showing the updated version
+	Considered bool // Showing the considered version
+}
+
+// Showing all versions
+var allVersions = TShownVersions{State: true, Update: true, Considered: true}
+
+// Selecting the versions of a model element to render the changes between, where a version that
+// is not shown is replaced by the preceding shown version, and the versions preceding the first
+// shown version by that version. When only the state is shown, all versions are the current one,
+// so no changes are rendered.
+func (v TShownVersions) Select(current, updated, considered string) (string, string, string) {
+	versions := []string{current, updated, considered}
+	shown := []bool{v.State, v.Update, v.Considered}
+
+	// Finding the first shown version, defaulting to the current one
+	first := slices.Index(shown, true)
+	if first < 0 {
+		first = 0
+	}
+
+	// Replacing the versions that are not shown
+	selected := make([]string, len(versions))
+	for version := range versions {
+		switch {
+		case version <= first:
+			selected[version] = versions[first]
+		case shown[version]:
+			selected[version] = versions[version]
+		default:
+			selected[version] = selected[version-1]
+		}
+	}
+
+	return selected[0], selected[1], selected[2]
+}
+
+// Rendering the changes between the current, updated, and considered versions of a model element
+func RenderChanges(formats TChangeFormats, current, updated, considered string) string {
+	rendering := ""
+	for _, span := range DiffElement(current, updated, considered) {
+		rendering += formats.FormatSpan(span)
+	}
+
+	return rendering
+}
+
+/*
+ * Rendering elements with LaTeX formatting
+ */
+
+// Rendering model elements
+func (l *TCDMModelLaTeXWriter) RenderElement(s func(cdm.TCDMModel) string) string {
+	// Getting the shown current, updated, and considered model elements via the access function s
+	current, updated, considered := l.shownVersions.Select(s(l.CurrentModel), s(l.UpdatedModel), s(l.ConsideredModel))
+
+	// Noting the changes, for the legend
+	if HasChanges(current, updated, considered) {
+		l.changesRendered = true
+	}
+
+	return RenderChanges(latexChangeFormats, current, updated, considered)
 }
 
 // Render the model name
@@ -145,6 +368,36 @@ func (l *TCDMModelLaTeXWriter) RenderTypeName(typeID string) string {
 	})
 }
 
+// Render the reference mode of a quality type, if any, such as " (nr)"
+func (l *TCDMModelLaTeXWriter) RenderReferenceModeOfQualityType(qualityType string) string {
+	referenceMode := l.annotations.Annotations.ReferenceMode(qualityType)
+	if referenceMode == "" {
+		return ""
+	}
+
+	return " (" + escapeLaTeX(referenceMode) + ")"
+}
+
+// Render the constraints on an involvement type, such as " [1..*, mandatory, unique]"
+func (l *TCDMModelLaTeXWriter) RenderInvolvementTypeConstraints(involvementType string) string {
+	constraints := []string{}
+	if multiplicity, hasMultiplicity := l.annotations.Annotations.InvolvementMultiplicity(involvementType); hasMultiplicity {
+		constraints = append(constraints, escapeLaTeX(multiplicity.String()))
+	}
+	if l.annotations.Annotations.IsMandatory(involvementType) {
+		constraints = append(constraints, "mandatory")
+	}
+	if l.annotations.Annotations.IsUnique(involvementType) {
+		constraints = append(constraints, "unique")
+	}
+
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	return " [" + strings.Join(constraints, ", ") + "]"
+}
+
 // Render a relation type reading
 func (l *TCDMModelLaTeXWriter) RenderRelationTypeReading(m cdm.TCDMModel, reading string) string {
 	readingString := ""
@@ -194,24 +447,22 @@ func (l *TCDMModelLaTeXWriter) WriteTypesToLaTeX(sectionTitle string, types map[
 
 	// Let's assume the list is empty, by default.
 	empty := true
-	for tpe, included := range types {
-		if included {
-			// Writing the type, if included
-			if empty {
-				// Writing the section header
-				l.WriteLaTeX("\\section{%s}\n", sectionTitle)
-				l.WriteLaTeX("\\begin{itemize}\n")
-			} else {
-				// Adding a new line between types
-				l.WriteLaTeX("\n")
-			}
+	for _, tpe := range sortedTypes(&l.TCDMModelListener, types) {
+		// Writing the type
+		if empty {
+			// Writing the section header
+			l.WriteLaTeX("\\section{%s}\n", sectionTitle)
+			l.WriteLaTeX("\\begin{itemize}\n")
+		} else {
+			// Adding a new line between types
+			l.WriteLaTeX("\n")
+		}
 
-			// Marking that the list is not empty
-			empty = false
+		// Marking that the list is not empty
+		empty = false
 
-			// Writing the type itself
-			writeTypeToLaTeX(tpe)
-		}
+		// Writing the type itself
+		writeTypeToLaTeX(tpe)
 	}
 
 	// Closing the itemize environment, if needed
@@ -221,30 +472,160 @@ func (l *TCDMModelLaTeXWriter) WriteTypesToLaTeX(sectionTitle string, types map[
 	}
 }
 
+// Writing the additional preamble lines from the preamble file, if any, to the LaTeX file
+func (l *TCDMModelLaTeXWriter) WritePreambleToLaTeX() {
+	if l.preambleFile == "" {
+		return
+	}
+
+	// Reading the preamble file
+	preamble, err := os.ReadFile(l.preambleFile)
+	if l.reporter.MaybeReportError("Error reading LaTeX preamble file:", err) {
+		return
+	}
+
+	// Writing the preamble, ensuring it ends with a new line
+	l.WriteLaTeX("%s", preamble)
+	if len(preamble) > 0 && !strings.HasSuffix(string(preamble), "\n") {
+		l.WriteLaTeX("\n")
+	}
+}
+
+// Writing a legend explaining the marking of changes to the LaTeX file
+func (l *TCDMModelLaTeXWriter) WriteLegendToLaTeX() {
+	l.WriteLaTeX("\\section{Legend}\n")
+	l.WriteLaTeX("\\begin{itemize}\n")
+	l.WriteLaTeX("    \\item %s: to be added\n", ApplyFormatting(toAdd, "green"))
+	l.WriteLaTeX("    \\item %s: to be deleted\n", ApplyFormatting(toDelete, "red, struck through"))
+	l.WriteLaTeX("    \\item %s: considered to be added\n", ApplyFormatting(considerAdd, "lime"))
+	l.WriteLaTeX("    \\item %s: considered to be deleted\n", ApplyFormatting(considerDelete, "orange, struck through"))
+	l.WriteLaTeX("\\end{itemize}\n")
+	l.WriteLaTeX("\n")
+}
+
+// Getting the base type of an involvement type, from the most recent version of the model defining it
+func (l *TCDMModelLaTeXWriter) baseTypeOfInvolvementType(involvementType string) string {
+	for _, model := range []cdm.TCDMModel{l.ConsideredModel, l.UpdatedModel, l.CurrentModel} {
+		if baseType := model.BaseTypeOfInvolvementType[involvementType]; baseType != "" {
+			return baseType
+		}
+	}
+
+	return ""
+}
+
+// Writing a diagram of the model to the LaTeX file, using a (naive) grid layout
+func (l *TCDMModelLaTeXWriter) WriteDiagramToLaTeX() {
+	l.WriteLaTeX("\\section{Diagram}\n")
+	l.WriteLaTeX("\\begin{center}\n")
+	l.WriteLaTeX("\\begin{tikzpicture}[\n")
+	l.WriteLaTeX("    concreteindividualtype/.style={draw, rounded corners, minimum height=2em},\n")
+	l.WriteLaTeX("    qualitytype/.style={draw, dashed, rounded corners, minimum height=2em},\n")
+	l.WriteLaTeX("    relationtype/.style={draw, ellipse, minimum height=2em},\n")
+	l.WriteLaTeX("    involvementtype/.style={font=\\scriptsize, sloped, above}]\n")
+
+	// The TikZ node names of the types
+	nodeNames := map[string]string{}
+
+	// Placing the types on the grid
+	column, row := 0, 0
+	placeNodes := func(types map[string]bool, style string) {
+		for _, tpe := range sortedTypes(&l.TCDMModelListener, types) {
+			nodeNames[tpe] = fmt.Sprintf("type%d", len(nodeNames))
+			l.WriteLaTeX("  \\node[%s] (%s) at (%d, %d) {\\sf %s};\n", style, nodeNames[tpe], column*diagramColumnWidth, -row*diagramRowHeight, l.RenderTypeName(tpe))
+
+			// Moving to the next position on the grid
+			column++
+			if column == diagramColumns {
+				column = 0
+				row++
+			}
+		}
+
+		// Starting the next kind of types on a new row
+		if column > 0 {
+			column = 0
+			row++
+		}
+	}
+	placeNodes(l.ConcreteIndividualTypes(), "concreteindividualtype")
+	placeNodes(l.QualityTypes(), "qualitytype")
+	placeNodes(l.RelationTypes(), "relationtype")
+
+	// Connecting the relation types to the base types of their involvement types
+	for _, relationType := range sortedTypes(&l.TCDMModelListener, l.RelationTypes()) {
+		for _, involvementType := range sortedInvolvementTypes(&l.TCDMModelListener, l.InvolvementTypesOfRelationType(relationType)) {
+			if baseTypeNode, placed := nodeNames[l.baseTypeOfInvolvementType(involvementType)]; placed {
+				l.WriteLaTeX("  \\draw (%s) -- node[involvementtype] {%s} (%s);\n", nodeNames[relationType], l.RenderTypeName(involvementType), baseTypeNode)
+			}
+		}
+	}
+
+	l.WriteLaTeX("\\end{tikzpicture}\n")
+	l.WriteLaTeX("\\end{center}\n")
+	l.WriteLaTeX("\n")
+}
+
+// Determining the date of the LaTeX document, where auto refers to the timestamp of the most
+// recent state posting. The connector only keeps the timestamps of state postings, so update
+// and considered postings carry the timestamp of the state they build on. Without a known
+// timestamp, LaTeX's default date is used.
+func (l *TCDMModelLaTeXWriter) documentDate() string {
+	if l.date == autoDate {
+		postingTime, err := app_generics.ParseTimestamp(l.postingTimestamp)
+		if err != nil {
+			return ""
+		}
+
+		return postingTime.Format(autoDateFormat)
+	}
+
+	return escapeLaTeX(l.date)
+}
+
 // Writing the model to a LaTeX file
 func (l *TCDMModelLaTeXWriter) WriteModelToLaTeX() {
 	// Creating the LaTeX file
 	l.LaTeXfile, _ = os.Create(l.workFolder + "/" + l.latexFile + latexFileExtension)
+	l.changesRendered = false
 
 	// Ensuring the LaTeX file is closed afterwards
 	defer l.LaTeXfile.Close()
 
 	// Writing the LaTeX file header
-	l.WriteLaTeX("\\documentclass[a4paper]{article}\n")
+	if l.latexOptions != "" {
+		l.WriteLaTeX("\\documentclass[%s]{%s}\n", l.latexOptions, l.latexClass)
+	} else {
+		l.WriteLaTeX("\\documentclass{%s}\n", l.latexClass)
+	}
 	l.WriteLaTeX("\\usepackage{a4wide}\n")
 	l.WriteLaTeX("\\usepackage{xcolor}\n")
 	l.WriteLaTeX("\\usepackage{ulem}\n")
+	if l.diagram {
+		l.WriteLaTeX("\\usepackage{tikz}\n")
+		l.WriteLaTeX("\\usetikzlibrary{shapes.geometric}\n")
+	}
+	l.WritePreambleToLaTeX()
 	l.WriteLaTeX("\n")
 	l.WriteLaTeX("\\title{CDM Model: %s}\n", l.RenderModelName())
-	l.WriteLaTeX("\\author{~~}\n")
+	l.WriteLaTeX("\\author{%s}\n", l.author)
+	if date := l.documentDate(); date != "" {
+		l.WriteLaTeX("\\date{%s}\n", date)
+	}
 	l.WriteLaTeX("\n")
 	l.WriteLaTeX("\\begin{document}\n")
 	l.WriteLaTeX("\\maketitle\n")
 	l.WriteLaTeX("\n")
 
+	// Writing the diagram of the model, if requested
+	if l.diagram {
+		l.WriteDiagramToLaTeX()
+	}
+
 	// Writing the quality types to the LaTeX file
 	l.WriteTypesToLaTeX("Quality types", l.QualityTypes(), func(qualityType string) {
-		l.WriteLaTeX("    \\item {\\sf %s} with domain {\\sf %s}\n", l.RenderTypeName(qualityType), l.RenderDomainNameOfQualityType(qualityType))
+		l.WriteLaTeX("    \\item {\\sf %s} with domain {\\sf %s}%s\n",
+			l.RenderTypeName(qualityType), l.RenderDomainNameOfQualityType(qualityType), l.RenderReferenceModeOfQualityType(qualityType))
 	})
 
 	// Writing the concrete individual types to the LaTeX file
@@ -258,11 +639,9 @@ func (l *TCDMModelLaTeXWriter) WriteModelToLaTeX() {
 
 		// Writing the involvement types of the relation type
 		sep := ""
-		for involvementType, included := range l.InvolvementTypesOfRelationType(relationType) {
-			if included {
-				l.WriteLaTeX("%s%s %s", sep, l.RenderTypeNameOfBaseTypeOfInvolvementType(involvementType), l.RenderTypeName(involvementType))
-				sep = "; "
-			}
+		for _, involvementType := range sortedInvolvementTypes(&l.TCDMModelListener, l.InvolvementTypesOfRelationType(relationType)) {
+			l.WriteLaTeX("%s%s %s%s", sep, l.RenderTypeNameOfBaseTypeOfInvolvementType(involvementType), l.RenderTypeName(involvementType), l.RenderInvolvementTypeConstraints(involvementType))
+			sep = "; "
 		}
 		l.WriteLaTeX(" $\\}$}\n")
 
@@ -275,13 +654,13 @@ func (l *TCDMModelLaTeXWriter) WriteModelToLaTeX() {
 			l.WriteLaTeX("          \\end{itemize}\n")
 		}
 
-		// Writing the alternative readings of the relation type
-		if len(l.AlternativeReadingsOfRelationType(relationType)) > 0 {
+		// Writing the alternative readings of the relation type, unless only the primary readings are wanted
+		if !l.primaryReadingsOnly && len(l.AlternativeReadingsOfRelationType(relationType)) > 0 {
 			l.WriteLaTeX("\n")
 			l.WriteLaTeX("          Alternative reading(s):\n")
 			l.WriteLaTeX("          \\begin{itemize}\n")
 			readingPosition := 0
-			for reading := range l.AlternativeReadingsOfRelationType(relationType) {
+			for _, reading := range sortedReadings(&l.TCDMModelListener, l.AlternativeReadingsOfRelationType(relationType)) {
 				if readingPosition > 0 {
 					l.WriteLaTeX("\n")
 				}
@@ -292,89 +671,407 @@ func (l *TCDMModelLaTeXWriter) WriteModelToLaTeX() {
 		}
 	})
 
+	// Writing the legend, if changes were rendered, unless it is not wanted
+	if l.changesRendered && !l.noLegend {
+		l.WriteLegendToLaTeX()
+	}
+
 	// Writing the LaTeX file footer
 	l.WriteLaTeX("\\end{document}\n")
 }
 
 // Creating the PDF file from the LaTeX file
-func (l *TCDMModelLaTeXWriter) CreatePDF() {
-	// Creating the PDF file using pdflatex
+func (l *TCDMModelLaTeXWriter) CreatePDF(ctx context.Context) {
+	// Creating the PDF file using the configured LaTeX command
 
 	// Set the LaTex command, which we ony need to run once for this application
-	cmd := exec.Command("pdflatex", l.latexFile+latexFileExtension)
+	cmd := exec.CommandContext(ctx, l.latexCommand, l.latexFile+latexFileExtension)
 
 	// Setting the working directory
 	cmd.Dir = l.workFolder
 
 	// Running the command
-	cmd.Run()
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			// The rendering was cancelled by a newer posting, so this is not an error
+			l.reporter.Progress(generics.ProgressLevelBasic, "Cancelled outdated rendering.")
+		} else {
+			l.reporter.ReportError("Error running LaTeX:", err)
+		}
+	}
+}
+
+// Cancelling the rendering still in progress (if any), as it has become outdated
+func (l *TCDMModelLaTeXWriter) cancelOutdatedRendering() {
+	l.cancelLock.Lock()
+	defer l.cancelLock.Unlock()
+
+	if l.cancelRendering != nil {
+		l.cancelRendering()
+		l.cancelRendering = nil
+	}
+}
+
+// Starting a new rendering, cancelling the one still in progress (if any)
+func (l *TCDMModelLaTeXWriter) startRendering() context.Context {
+	l.cancelLock.Lock()
+	defer l.cancelLock.Unlock()
+
+	// Cancelling the outdated rendering
+	if l.cancelRendering != nil {
+		l.cancelRendering()
+	}
+
+	// Creating the context for the new rendering
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancelRendering = cancel
+
+	return ctx
 }
 
 // Updating the rendering based on the current model state
 func (l *TCDMModelLaTeXWriter) UpdateRendering(message string) {
 	// Reporting on the update
 	l.reporter.Progress(generics.ProgressLevelBasic, "%s", message)
+	l.debouncer.Update(func() { l.postingTimestamp = l.ModelListener.CurrentTimestamp })
+	ReportModelChanges(&l.TCDMModelListener, l.reporter)
+
+	// Signalling the watchdog that postings are arriving
+	l.watchdog.Beat()
+
+	// Cancelling the compilation still in progress right away, rather than after the window
+	l.cancelOutdatedRendering()
+
+	// Rendering, once no further postings arrive within the debounce window
+	l.debouncer.Call(l.render)
+}
+
+// Finishing the rendering in progress, before shutting down
+func (l *TCDMModelLaTeXWriter) Shutdown() {
+	// Making the pending rendering, if any
+	l.debouncer.Flush()
 
-	// Writing the model to LaTeX and creating the PDF
+	// Waiting for the LaTeX compilation to finish
+	l.renderLock.Lock()
+	l.renderLock.Unlock()
+}
+
+// Rendering the current model state
+func (l *TCDMModelLaTeXWriter) render() {
+	// Cancelling the outdated rendering, if any
+	ctx := l.startRendering()
+
+	// Waiting for the outdated rendering to stop
+	l.renderLock.Lock()
+
+	// An even newer posting may have arrived while waiting
+	if ctx.Err() != nil {
+		l.renderLock.Unlock()
+		return
+	}
+
+	// Writing the model to LaTeX
 	l.WriteModelToLaTeX()
 
-	// Creating the PDF
-	l.CreatePDF()
+	// Only writing the LaTeX file, if so requested
+	if l.noCompile {
+		l.reporter.Progress(generics.ProgressLevelBasic, "Written LaTeX file: %s", l.workFolder+"/"+l.latexFile+latexFileExtension)
+		l.renderLock.Unlock()
+		return
+	}
+
+	// Creating the PDF in the background, so a newer posting can cancel it
+	go func() {
+		defer l.renderLock.Unlock()
+
+		l.CreatePDF(ctx)
+	}()
 }
 
 // Setting up listening for model postings
 func (l *TCDMModelLaTeXWriter) ListenForModelPostings(agentID, modelID string) {
-	// Listening for model state postings
-	l.ListenForModelStatePostings(agentID, modelID, func() {
-		l.UpdateRendering("Received state.")
-	})
+	// Listening for model state, update, and considering postings
+	ListenForGuardedModelPostings(&l.TCDMModelListener, l.debouncer, agentID, modelID, l.UpdateRendering)
 
-	// Listening for model update postings
-	l.ListenForModelUpdatePostings(agentID, modelID, func() {
-		l.UpdateRendering("Received update.")
+	// Listening for postings of the annotations of the model, which are also rendered
+	l.annotations.Guard = l.debouncer.Update
+	l.annotations.ListenForAnnotationsPostings(agentID, modelID, func() {
+		l.UpdateRendering("Received annotations.")
 	})
+}
 
-	// Listening for model considering postings
-	l.ListenForModelConsideringPostings(agentID, modelID, func() {
-		l.UpdateRendering("Received considered.")
-	})
+// Rendering the model once, based on the retained state, update, and considered postings
+func (l *TCDMModelLaTeXWriter) RenderOnce(agentID, modelID string) {
+	// Taking along the annotations of the model, if posted
+	l.annotations.GetAnnotations(agentID, modelID)
+
+	// Getting all three aspects of the model
+	GetAllModelAspects(&l.TCDMModelListener, l.reporter, agentID, modelID)
+
+	// Rendering the retrieved aspects
+	l.renderRetrievedModel()
+}
+
+// Rendering the retrieved state, update, and considered versions of the model once
+func (l *TCDMModelLaTeXWriter) renderRetrievedModel() {
+	l.postingTimestamp = l.ModelListener.CurrentTimestamp
+
+	// Writing the model to LaTeX
+	l.WriteModelToLaTeX()
+
+	// Creating the PDF, unless we only need the LaTeX file
+	if l.noCompile {
+		l.reporter.Progress(generics.ProgressLevelBasic, "Written LaTeX file: %s", l.workFolder+"/"+l.latexFile+latexFileExtension)
+	} else {
+		l.CreatePDF(context.Background())
+	}
+}
+
+/*
+ * Generic listening functionality, shared by the LaTeX, HTML, and DOT writers
+ */
+
+// Listening for the state, update, and considered postings of a model. The listener updates
+// the models on the bus goroutine, while the debounced renderings run on timer goroutines, so
+// the models are updated under the debouncer, rather than by the CDM model listener itself.
+func ListenForGuardedModelPostings(modelListener *cdm.TCDMModelListener, debouncer *TDebouncer, agentID, modelID string, handler func(message string)) {
+	aspects := []struct {
+		listen  func(agentID, artefactID string, handler func()) // Listening for postings of the aspect
+		message string                                           // Message reporting a received posting
+	}{
+		{modelListener.ModelListener.ListenForJSONArtefactStatePostings, "Received state."},
+		{modelListener.ModelListener.ListenForJSONArtefactUpdatePostings, "Received update."},
+		{modelListener.ModelListener.ListenForJSONArtefactConsideringPostings, "Received considered."},
+	}
+
+	for _, aspect := range aspects {
+		aspect.listen(agentID, modelID, func() {
+			debouncer.Update(modelListener.UpdateModelsFromBus)
+			handler(aspect.message)
+		})
+	}
+}
+
+// Getting the retained state, update, and considered postings of a model from the modelling
+// bus, and updating the models accordingly. Getting the considered posting also gets the
+// state and update postings.
+func GetAllModelAspects(modelListener *cdm.TCDMModelListener, reporter *generics.TReporter, agentID, modelID string) {
+	reporter.Progress(generics.ProgressLevelBasic, "Getting state, update, and considered.")
+
+	modelListener.ModelListener.GetJSONArtefactConsidering(agentID, modelID)
+	modelListener.UpdateModelsFromBus()
+}
+
+// Reporting what the update of a model changes with regard to its state
+func ReportModelChanges(modelListener *cdm.TCDMModelListener, reporter *generics.TReporter) {
+	reporter.Progress(app_generics.ProgressLevelVerbose, "Changes in update: %s.", cdm_tools.DiffCDMModels(modelListener.CurrentModel, modelListener.UpdatedModel))
+}
+
+// Coalescing rapid calls into a single call, made once no new call arrived within the window
+type TDebouncer struct {
+	window  time.Duration // The window within which calls are coalesced
+	lock    sync.Mutex    // Guarding the timer and the pending call
+	timer   *time.Timer   // The timer for the pending call
+	pending func()        // The pending call, if any
+	running sync.Mutex    // Held while a call is running
+}
+
+// Running the pending call, if any
+func (d *TDebouncer) run() {
+	d.running.Lock()
+	defer d.running.Unlock()
+
+	// Taking the pending call
+	d.lock.Lock()
+	f := d.pending
+	d.pending = nil
+	d.lock.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
+
+// Calling f, once no new call arrives within the window
+func (d *TDebouncer) Call(f func()) {
+	d.lock.Lock()
+	d.pending = f
+
+	// Without a window, there is nothing to coalesce
+	if d.window <= 0 {
+		d.lock.Unlock()
+		d.run()
+
+		return
+	}
+
+	defer d.lock.Unlock()
+
+	// Restarting the window
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.window, d.run)
+}
+
+// Updating the data used by the calls, while no call is running, so a call that fired from
+// its window never sees a half-updated model
+func (d *TDebouncer) Update(update func()) {
+	d.running.Lock()
+	defer d.running.Unlock()
+
+	update()
+}
+
+// Flushing the debouncer, by making the pending call (if any) right away, and waiting
+// for a call that is already running to finish
+func (d *TDebouncer) Flush() {
+	// Stopping the window
+	d.lock.Lock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.lock.Unlock()
+
+	// Making the pending call, which waits for a running call first
+	d.run()
+}
+
+// Creating a debouncer, based on the render_debounce_ms setting in the config data
+func CreateDebouncer(configData *generics.TConfigData, reporter *generics.TReporter) *TDebouncer {
+	// Getting the debounce window
+	windowSetting := configData.GetValue("", "render_debounce_ms").StringWithDefault(renderDebounceDefault)
+	window, err := strconv.Atoi(windowSetting)
+	if err != nil {
+		reporter.Error("Invalid render_debounce_ms setting: %s. Using %s instead.", windowSetting, renderDebounceDefault)
+		window, _ = strconv.Atoi(renderDebounceDefault)
+	}
+
+	// Returning the created debouncer
+	return &TDebouncer{window: time.Duration(window) * time.Millisecond}
 }
 
 // Creating the CDM model LaTeX writer
-func CreateCDMLaTeXWriter(configData *generics.TConfigData, modelListener cdm.TCDMModelListener, reporter *generics.TReporter) TCDMModelLaTeXWriter {
+func CreateCDMLaTeXWriter(configData *generics.TConfigData, modelListener cdm.TCDMModelListener, reporter *generics.TReporter) *TCDMModelLaTeXWriter {
 	// Creating the CDM model LaTeX writer
-	CDMModelLaTeXWriter := TCDMModelLaTeXWriter{}
+	CDMModelLaTeXWriter := &TCDMModelLaTeXWriter{}
 	CDMModelLaTeXWriter.reporter = reporter
 	CDMModelLaTeXWriter.TCDMModelListener = modelListener
+	CDMModelLaTeXWriter.shownVersions = allVersions
 
 	// Setting up the LaTeX writer based on the config data
 	CDMModelLaTeXWriter.workFolder = configData.GetValue("", "work_folder").String()
 	CDMModelLaTeXWriter.latexFile = configData.GetValue("", "latex").String()
 	CDMModelLaTeXWriter.latexCommand = configData.GetValue("", "latex_command").StringWithDefault(latexDefaultCommand)
+	CDMModelLaTeXWriter.latexClass = configData.GetValue("", "latex_documentclass").StringWithDefault(latexDefaultDocumentClass)
+	CDMModelLaTeXWriter.latexOptions = configData.GetValue("", "latex_classoptions").StringWithDefault(latexDefaultClassOptions)
+	CDMModelLaTeXWriter.preambleFile = configData.GetValue("", "latex_preamble_file").String()
+	CDMModelLaTeXWriter.date = configData.GetValue("", "pdf_date").String()
+
+	// The author is escaped, except for the default, which deliberately uses LaTeX's ~ for spacing
+	CDMModelLaTeXWriter.author = latexDefaultAuthor
+	if author := configData.GetValue("", "pdf_author").String(); author != "" {
+		CDMModelLaTeXWriter.author = escapeLaTeX(author)
+	}
+	CDMModelLaTeXWriter.debouncer = CreateDebouncer(configData, reporter)
 
 	// Returning the created LaTeX writer
 	return CDMModelLaTeXWriter
 }
 
+/*
+ * Support functions
+ */
+
+// Escaping the characters with a special meaning in LaTeX
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`_`, `\_`,
+	`^`, `\textasciicircum{}`,
+	`~`, `\textasciitilde{}`,
+)
+
+// Escaping a plain text for use in LaTeX
+func escapeLaTeX(text string) string {
+	return latexEscaper.Replace(text)
+}
+
 /*
  * Main function
  */
 
 func main() {
+	os.Exit(app_generics.ExitCode(run(os.Args[1:], os.Stdout, os.Stderr)))
+}
+
+// Running the app with the given arguments and outputs, returning an exit error when it fails
+func run(arguments []string, stdout, stderr io.Writer) error {
 	// Parsing flags
-	flag.Parse()
+	flags.SetOutput(stderr)
+	parsed, err := app_generics.ParseFlags(flags, arguments)
+	if !parsed {
+		return err
+	}
+
+	// Counting the errors reported from here on
+	errorCount := app_generics.ReportedErrorCount()
+
+	// Only reporting the version, if requested
+	if *versionFlag {
+		app_generics.WriteVersion(stdout, appName, appVersion)
+
+		return nil
+	}
+
+	// Directing the progress reports to the output, and the errors to the error output
+	app_generics.SetReportOutput(stdout, stderr)
 
 	// Creating the reporter
-	reporter := generics.CreateReporter(*reportLevelFlag, generics.ReportError, generics.ReportProgress)
+	reporter := app_generics.CreateReporter(*reportLevelFlag, *errorReportLevelFlag)
 
-	// Validating agent ID flag
-	if reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+	// Formatting the reports as requested
+	if reporter.MaybeReportError("Error in log format flag:", app_generics.SetLogFormat(*logFormatFlag)) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
-	// Validating model ID flag
-	if reporter.MaybeReportEmptyFlagError(modelIDFlag, "No model ID specified.") {
-		return
+	// Directing the reports to a log file, if requested
+	if *logFileFlag != "" {
+		if reporter.MaybeReportError("Error opening log file:", app_generics.SetLogFile(*logFileFlag, *logMaxSizeFlag)) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+	}
+
+	// Only checking the configuration, if requested
+	if *configCheckFlag {
+		return app_generics.CheckConfig(*configFlag, *configSetFlag, !connect.PostingOnly, reporter)
+	}
+
+	// We need to know which model to render, and from which agent
+	if !app_generics.RequireFlags(reporter, "rendering",
+		app_generics.TRequiredFlag{Value: agentIDFlag, Name: "from_agent"},
+		app_generics.TRequiredFlag{Value: modelIDFlag, Name: "for_model"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating format flag
+	if *formatFlag != pdfFormat && *formatFlag != htmlFormat && *formatFlag != dotFormat {
+		reporter.Error("Unknown output format specified: %s.", *formatFlag)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating readings flag
+	if *readingsFlag != "" && *readingsFlag != primaryReadings && *readingsFlag != allReadings {
+		reporter.Error("Unknown readings specified: %s.", *readingsFlag)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Reporting progress
@@ -382,7 +1079,20 @@ func main() {
 	reporter.Progress(generics.ProgressLevelBasic, "Listening for model ID '%s' from agent ID '%s'", *modelIDFlag, *agentIDFlag)
 
 	// Note: the config data can be used to contain config data for different aspects
-	configData := generics.LoadConfig(*configFlag, reporter)
+	configData, err := app_generics.LoadConfig(*configFlag, *configSetFlag, reporter)
+	if err != nil {
+		return err
+	}
+
+	// Checking the required configuration
+	if !app_generics.RequireConfigKeys(configData, reporter, "work_folder", "latex") {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
+
+	// Checking the work folder
+	if reporter.MaybeReportError("Error in work folder:", app_generics.CheckWorkFolder(configData.GetValue("", "work_folder").String(), *createWorkFolderFlag)) {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
 
 	// Note: One ModellingBusConnector can be used for different models of different kinds.
 	ModellingBusConnector := connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
@@ -390,14 +1100,73 @@ func main() {
 	// Creating the CDM model listener
 	CDMModellingBusListener := cdm.CreateCDMListener(ModellingBusConnector, reporter)
 
-	// Creating the CDM model LaTeX writer
-	CDMLaTeXWriter := CreateCDMLaTeXWriter(configData, CDMModellingBusListener, reporter)
+	// Determining which readings to render, where the flag takes precedence over the configuration
+	readings := *readingsFlag
+	if readings == "" {
+		readings = configData.GetValue("", "readings").StringWithDefault(allReadings)
+	}
 
-	// Setting up listening for model postings
-	CDMLaTeXWriter.ListenForModelPostings(*agentIDFlag, *modelIDFlag)
+	// Determining which versions of the model elements to show
+	shownVersions := TShownVersions{State: *showStateFlag, Update: *showUpdateFlag, Considered: *showConsideredFlag}
+
+	// Creating the watchdog, if requested
+	var watchdog *TWatchdog
+	if *heartbeatTimeoutFlag > 0 {
+		watchdog = CreateWatchdog(*heartbeatTimeoutFlag, *maxBackoffFlag, reporter)
+	}
+
+	// Creating the CDM model writer for the requested format
+	var CDMWriter TCDMModelWriter
+	if *formatFlag == htmlFormat {
+		CDMHTMLWriter := CreateCDMHTMLWriter(configData, CDMModellingBusListener, reporter)
+		CDMHTMLWriter.primaryReadingsOnly = readings == primaryReadings
+		CDMHTMLWriter.shownVersions = shownVersions
+		CDMHTMLWriter.watchdog = watchdog
+		CDMWriter = CDMHTMLWriter
+	} else if *formatFlag == dotFormat {
+		CDMDOTWriter := CreateCDMDOTWriter(configData, CDMModellingBusListener, reporter)
+		CDMDOTWriter.shownVersions = shownVersions
+		CDMDOTWriter.watchdog = watchdog
+		CDMWriter = CDMDOTWriter
+	} else {
+		CDMLaTeXWriter := CreateCDMLaTeXWriter(configData, CDMModellingBusListener, reporter)
+		CDMLaTeXWriter.noCompile = *noCompileFlag
+		CDMLaTeXWriter.diagram = *diagramFlag
+		CDMLaTeXWriter.noLegend = *noLegendFlag
+		CDMLaTeXWriter.primaryReadingsOnly = readings == primaryReadings
+		CDMLaTeXWriter.shownVersions = shownVersions
+		CDMLaTeXWriter.annotations = cdm_tools.CreateCDMAnnotationsListener(ModellingBusConnector, *modelIDFlag, reporter)
+		CDMLaTeXWriter.watchdog = watchdog
+		CDMWriter = CDMLaTeXWriter
+	}
 
-	// Keeping the application running
-	for {
-		time.Sleep(1 * time.Second)
+	// Rendering only once, if so requested, failing with an error code if errors were reported
+	if *onceFlag {
+		CDMWriter.RenderOnce(*agentIDFlag, *modelIDFlag)
+
+		return app_generics.ExitErrorSince(errorCount)
+	}
+
+	// Shutting down gracefully on SIGINT/SIGTERM
+	shutdownContext, stopSignals := app_generics.ShutdownContext()
+	defer stopSignals()
+
+	// Setting up listening for model postings
+	CDMWriter.ListenForModelPostings(*agentIDFlag, *modelIDFlag)
+
+	// Keeping the application running, until asked to shut down, while supervising
+	// the subscriptions if requested
+	if watchdog != nil {
+		watchdog.Supervise(shutdownContext, func() {
+			CDMWriter.ListenForModelPostings(*agentIDFlag, *modelIDFlag)
+		})
+	} else {
+		<-shutdownContext.Done()
 	}
+
+	// Letting the rendering in progress finish
+	reporter.Progress(generics.ProgressLevelBasic, "Shutting down")
+	CDMWriter.Shutdown()
+
+	return nil
 }
@@ -0,0 +1,830 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: LaTeX based PDF Renderer for CDM Models, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"app_generics"
+	"app_generics/cdm_tools"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// Flag for updating the golden files, rather than comparing against them
+var updateGoldenFlag = flag.Bool("update", false, "Update the golden files in testdata")
+
+// Creating a LaTeX writer for an empty model, writing to a temporary work folder, which
+// passes its progress reports to the given function, and reports its errors to the test
+func testLaTeXWriter(t *testing.T, progress func(string)) *TCDMModelLaTeXWriter {
+	reporter := generics.CreateReporter(generics.ProgressLevelBasic,
+		func(message string) { t.Errorf("reported error: %s", message) },
+		progress)
+
+	return &TCDMModelLaTeXWriter{
+		TCDMModelListener: cdm.TCDMModelListener{
+			CurrentModel:    cdm.CreateCDMModel(reporter),
+			UpdatedModel:    cdm.CreateCDMModel(reporter),
+			ConsideredModel: cdm.CreateCDMModel(reporter),
+		},
+		latexFile:     "model",
+		latexCommand:  latexDefaultCommand,
+		latexClass:    latexDefaultDocumentClass,
+		latexOptions:  latexDefaultClassOptions,
+		workFolder:    t.TempDir(),
+		author:        latexDefaultAuthor,
+		shownVersions: allVersions,
+		debouncer:     &TDebouncer{},
+		reporter:      reporter,
+	}
+}
+
+// Setting the state, update, and considered models of the writer to the same model, with a
+// relation type between students and study programmes
+func setTestModel(t *testing.T, writer *TCDMModelLaTeXWriter) (studies, isStudiedBy string) {
+	model := &writer.CurrentModel
+	model.SetModelName("University")
+
+	student := model.AddConcreteIndividualType("Student")
+	programme := model.AddConcreteIndividualType("Study Programme")
+	model.AddQualityType("Student Number", "Integer")
+	studies = model.AddInvolvementType("studies", student)
+	isStudiedBy = model.AddInvolvementType("is studied by", programme)
+	enrolment := model.AddRelationType("Enrolment", studies, isStudiedBy)
+	model.AddRelationTypeReading(enrolment, "", studies, "studies", isStudiedBy, "")
+
+	// Copying the model, so all versions share the same element IDs
+	modelJSON, ok := model.GetModelAsJSON()
+	if !ok || !writer.UpdatedModel.SetModelFromJSON(modelJSON) || !writer.ConsideredModel.SetModelFromJSON(modelJSON) {
+		t.Fatal("could not copy the model")
+	}
+
+	return studies, isStudiedBy
+}
+
+// Writing a LaTeX command to the work folder, as a shell script with the given body
+func writeLaTeXCommand(t *testing.T, workFolder, body string) string {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell to run the LaTeX command")
+	}
+
+	command := filepath.Join(workFolder, "latex.sh")
+	if err := os.WriteFile(command, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	return command
+}
+
+// Determining the changes between the current, updated, and considered versions of a model element
+func TestDiffElement(t *testing.T) {
+	tests := []struct {
+		name                         string
+		current, updated, considered string
+		want                         []TDiffSpan
+		changes                      bool
+	}{
+		{"unchanged", "Student", "Student", "Student",
+			[]TDiffSpan{{"Student", DiffUnchanged}}, false},
+		{"updated", "Student", "Pupil", "Pupil",
+			[]TDiffSpan{{"Student", DiffDeleted}, {"Pupil", DiffAdded}}, true},
+		{"considered", "Student", "Student", "Pupil",
+			[]TDiffSpan{{"Student", DiffConsiderDeleted}, {"Pupil", DiffConsiderAdded}}, true},
+		{"updated and considered", "Student", "Pupil", "Learner",
+			[]TDiffSpan{{"Student", DiffDeleted}, {"Pupil", DiffConsiderDeleted}, {"Learner", DiffConsiderAdded}}, true},
+		{"added", "", "Student", "Student",
+			[]TDiffSpan{{"Student", DiffAdded}}, true},
+		{"deleted", "Student", "", "",
+			[]TDiffSpan{{"Student", DiffDeleted}}, true},
+		{"considered to be added", "", "", "Student",
+			[]TDiffSpan{{"Student", DiffConsiderAdded}}, true},
+		{"considered to be deleted", "Student", "Student", "",
+			[]TDiffSpan{{"Student", DiffConsiderDeleted}}, true},
+		{"absent", "", "", "",
+			[]TDiffSpan{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DiffElement(test.current, test.updated, test.considered); !slices.Equal(got, test.want) {
+				t.Errorf("DiffElement(%q, %q, %q) = %v, want %v", test.current, test.updated, test.considered, got, test.want)
+			}
+			if got := HasChanges(test.current, test.updated, test.considered); got != test.changes {
+				t.Errorf("HasChanges(%q, %q, %q) = %t, want %t", test.current, test.updated, test.considered, got, test.changes)
+			}
+		})
+	}
+}
+
+// Listing the included elements of a set, leaving out the excluded ones, such as removed alternative readings
+func TestSortedIncluded(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements map[string]bool
+		want     []string
+	}{
+		{"empty", map[string]bool{}, []string{}},
+		{"all included", map[string]bool{"takes": true, "is taken by": true}, []string{"is taken by", "takes"}},
+		{"some excluded", map[string]bool{"takes": true, "attends": false, "is taken by": true}, []string{"is taken by", "takes"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sortedIncluded(test.elements); !slices.Equal(got, test.want) {
+				t.Errorf("sortedIncluded(%v) = %q, want %q", test.elements, got, test.want)
+			}
+		})
+	}
+}
+
+// A newer posting cancels the compilation still in progress right away, rather than only
+// once the debounce window has passed
+func TestUpdateRenderingCancelsCompilation(t *testing.T) {
+	progress := make(chan string, 100)
+	writer := testLaTeXWriter(t, func(message string) { progress <- message })
+
+	// The first compilation keeps running, until it is cancelled
+	writer.latexCommand = writeLaTeXCommand(t, writer.workFolder, "[ -e started ] && exit 0\ntouch started\nexec sleep 60\n")
+	writer.debouncer.window = time.Hour
+
+	// Starting the first compilation, without waiting for the window
+	writer.UpdateRendering("Received state.")
+	writer.debouncer.Flush()
+	for started := false; !started; {
+		time.Sleep(10 * time.Millisecond)
+		_, err := os.Stat(filepath.Join(writer.workFolder, "started"))
+		started = err == nil
+	}
+
+	// Receiving a newer posting, well within the window
+	writer.UpdateRendering("Received update.")
+
+	deadline := time.After(10 * time.Second)
+	for cancelled := false; !cancelled; {
+		select {
+		case message := <-progress:
+			cancelled = strings.HasPrefix(message, "Cancelled outdated rendering")
+		case <-deadline:
+			t.Fatal("the first compilation was not cancelled by the newer posting")
+		}
+	}
+
+	// Making the pending rendering for the newer posting
+	writer.Shutdown()
+}
+
+// Without compiling, only the LaTeX file is written, and the LaTeX command is not run
+func TestNoCompile(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	writer.latexCommand = writeLaTeXCommand(t, writer.workFolder, "touch compiled\n")
+	writer.noCompile = true
+
+	writer.UpdateRendering("Received state.")
+	writer.Shutdown()
+
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatalf("LaTeX file not written: %s", err)
+	}
+	if !strings.HasSuffix(string(latex), "\\end{document}\n") {
+		t.Errorf("incomplete LaTeX file:\n%s", latex)
+	}
+
+	if _, err := os.Stat(filepath.Join(writer.workFolder, "compiled")); err == nil {
+		t.Error("the LaTeX command was run")
+	}
+}
+
+// Calls arriving within the debounce window of each other are coalesced into a single call
+func TestDebouncer(t *testing.T) {
+	debouncer := &TDebouncer{window: 500 * time.Millisecond}
+
+	var renders atomic.Int32
+	for range 3 {
+		debouncer.Call(func() { renders.Add(1) })
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Waiting for the window to pass, after which nothing is pending anymore
+	time.Sleep(debouncer.window + 200*time.Millisecond)
+	debouncer.Flush()
+
+	if got := renders.Load(); got != 1 {
+		t.Errorf("rendered %d time(s), want 1", got)
+	}
+}
+
+// Mandatory and unique involvement types are marked as such in the LaTeX file, while
+// involvement types without constraints are left unmarked
+func TestInvolvementTypeConstraints(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	studies, _ := setTestModel(t, writer)
+
+	writer.annotations.Annotations = cdm_tools.CreateCDMModelAnnotations()
+	writer.annotations.Annotations.SetMandatory(studies, true)
+	writer.annotations.Annotations.SetUnique(studies, true)
+	writer.WriteModelToLaTeX()
+
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Student studies [mandatory, unique]; Study Programme is studied by $\\}$"; !strings.Contains(string(latex), want) {
+		t.Errorf("LaTeX file does not contain %q:\n%s", want, latex)
+	}
+}
+
+// The multiplicity of an involvement type is rendered after it, also without other constraints
+func TestInvolvementTypeMultiplicity(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	studies, _ := setTestModel(t, writer)
+
+	writer.annotations.Annotations = cdm_tools.CreateCDMModelAnnotations()
+	writer.annotations.Annotations.SetInvolvementMultiplicity(studies, "1", "*")
+	writer.WriteModelToLaTeX()
+
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Student studies [1..*]; Study Programme is studied by $\\}$"; !strings.Contains(string(latex), want) {
+		t.Errorf("LaTeX file does not contain %q:\n%s", want, latex)
+	}
+}
+
+// The reference mode of a quality type is rendered, escaped, in parentheses after its domain
+func TestReferenceModes(t *testing.T) {
+	tests := []struct {
+		name          string
+		referenceMode string
+		want          string
+	}{
+		{"reference mode", "nr", "{\\sf Student Number} with domain {\\sf Integer} (nr)\n"},
+		{"escaped reference mode", "nr_1", "{\\sf Student Number} with domain {\\sf Integer} (nr\\_1)\n"},
+		{"no reference mode", "", "{\\sf Student Number} with domain {\\sf Integer}\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := testLaTeXWriter(t, func(string) {})
+			setTestModel(t, writer)
+
+			writer.annotations.Annotations = cdm_tools.CreateCDMModelAnnotations()
+			for qualityType := range writer.CurrentModel.QualityTypes {
+				writer.annotations.Annotations.AddReferenceMode(qualityType, test.referenceMode)
+			}
+			writer.WriteModelToLaTeX()
+
+			latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(latex), test.want) {
+				t.Errorf("LaTeX file does not contain %q:\n%s", test.want, latex)
+			}
+		})
+	}
+}
+
+// Writing the university scenario's final model to LaTeX twice gives byte-identical files,
+// matching the golden file
+func TestWriteModelToLaTeXGolden(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+
+	// Running the scenario, with all versions sharing the final model
+	writer.annotations.Annotations = cdm_tools.CreateCDMModelAnnotations()
+	cdm_tools.RunScenario(cdm_tools.UniversityScenario(), &writer.CurrentModel, &writer.annotations.Annotations, cdm_tools.TNoPoster{})
+	modelJSON, ok := writer.CurrentModel.GetModelAsJSON()
+	if !ok || !writer.UpdatedModel.SetModelFromJSON(modelJSON) || !writer.ConsideredModel.SetModelFromJSON(modelJSON) {
+		t.Fatal("could not copy the model")
+	}
+
+	// Writing the model twice
+	latexFiles := [][]byte{}
+	for range 2 {
+		writer.WriteModelToLaTeX()
+		latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+		if err != nil {
+			t.Fatal(err)
+		}
+		latexFiles = append(latexFiles, latex)
+	}
+	if !bytes.Equal(latexFiles[0], latexFiles[1]) {
+		t.Errorf("writing the same model twice gives different LaTeX files:\n%s\n---\n%s", latexFiles[0], latexFiles[1])
+	}
+
+	// Comparing with the golden file
+	goldenFile := filepath.Join("testdata", "university"+latexFileExtension)
+	if *updateGoldenFlag {
+		if err := os.WriteFile(goldenFile, latexFiles[0], 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	golden, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(latexFiles[0], golden) {
+		t.Errorf("LaTeX file differs from %s:\n%s", goldenFile, latexFiles[0])
+	}
+}
+
+// Rendering once loads the state, update, and considered versions, as retrieved by the
+// connector, into the writer's models, and compiles the LaTeX file showing their changes once
+func TestRenderRetrievedModel(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	writer.latexCommand = writeLaTeXCommand(t, writer.workFolder, "echo compiled >> compilations\n")
+
+	// Retrieving a state, an update adding a type, and a considered version adding another one
+	model := cdm.CreateCDMModel(writer.reporter)
+	model.SetModelName("University")
+	aspects := [][]byte{}
+	for _, typeName := range []string{"Student", "Study Programme", "Course"} {
+		model.AddConcreteIndividualType(typeName)
+		modelJSON, ok := model.GetModelAsJSON()
+		if !ok {
+			t.Fatal("could not convert the model to JSON")
+		}
+		aspects = append(aspects, modelJSON)
+	}
+	writer.ModelListener.CurrentContent = aspects[0]
+	writer.ModelListener.UpdatedContent = aspects[1]
+	writer.ModelListener.ConsideredContent = aspects[2]
+	writer.UpdateModelsFromBus()
+
+	writer.renderRetrievedModel()
+
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\\item {\\sf Student}\n",
+		"\\item {\\sf " + ApplyFormatting(toAdd, "Study Programme") + "}\n",
+		"\\item {\\sf " + ApplyFormatting(considerAdd, "Course") + "}\n",
+	} {
+		if !strings.Contains(string(latex), want) {
+			t.Errorf("LaTeX file does not contain %q:\n%s", want, latex)
+		}
+	}
+
+	compilations, err := os.ReadFile(filepath.Join(writer.workFolder, "compilations"))
+	if err != nil || string(compilations) != "compiled\n" {
+		t.Errorf("compilations = %q (%v), want a single one", compilations, err)
+	}
+}
+
+// Rendering elements only folds the shown versions into their changes, where showing only the
+// state renders the current version without marking any changes
+func TestShownVersions(t *testing.T) {
+	tests := []struct {
+		name          string
+		shown         TShownVersions
+		want          []string
+		wantNot       []string
+		wantUnchanged bool
+	}{
+		{name: "state only", shown: TShownVersions{State: true},
+			want: []string{"University"}, wantNot: []string{"\\color", "Vienna", "Wien"}, wantUnchanged: true},
+		{name: "state and update", shown: TShownVersions{State: true, Update: true},
+			want: []string{"\\color{green}", "Vienna"}, wantNot: []string{"\\color{lime}", "\\color{orange}", "Wien"}},
+		{name: "all versions", shown: allVersions,
+			want: []string{"\\color{lime}", "Wien"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := testLaTeXWriter(t, func(string) {})
+			setTestModel(t, writer)
+			writer.UpdatedModel.SetModelName("University of Vienna")
+			writer.ConsideredModel.SetModelName("TU Wien")
+			writer.shownVersions = test.shown
+
+			rendering := writer.RenderModelName()
+			if test.wantUnchanged && rendering != "University" {
+				t.Errorf("RenderModelName() = %q, want %q", rendering, "University")
+			}
+			for _, want := range test.want {
+				if !strings.Contains(rendering, want) {
+					t.Errorf("RenderModelName() = %q, want it to contain %q", rendering, want)
+				}
+			}
+			for _, wantNot := range test.wantNot {
+				if strings.Contains(rendering, wantNot) {
+					t.Errorf("RenderModelName() = %q, want it not to contain %q", rendering, wantNot)
+				}
+			}
+			if writer.changesRendered == test.wantUnchanged {
+				t.Errorf("changes rendered = %t, want %t", writer.changesRendered, !test.wantUnchanged)
+			}
+		})
+	}
+}
+
+// The legend explaining the marking of changes is only written for a changed model, unless it
+// is left out
+func TestLegend(t *testing.T) {
+	tests := []struct {
+		name       string
+		changed    bool
+		noLegend   bool
+		wantLegend bool
+	}{
+		{"changed model", true, false, true},
+		{"unchanged model", false, false, false},
+		{"changed model without legend", true, true, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := testLaTeXWriter(t, func(string) {})
+			setTestModel(t, writer)
+			if test.changed {
+				writer.UpdatedModel.AddConcreteIndividualType("Course")
+			}
+			writer.noLegend = test.noLegend
+
+			writer.WriteModelToLaTeX()
+			latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hasLegend := strings.Contains(string(latex), "\\section{Legend}"); hasLegend != test.wantLegend {
+				t.Errorf("legend written = %t, want %t:\n%s", hasLegend, test.wantLegend, latex)
+			}
+		})
+	}
+}
+
+// A custom document class and preamble file are written ahead of the document, after the
+// built-in packages
+func TestCustomPreamble(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	setTestModel(t, writer)
+	writer.latexClass = "report"
+	writer.latexOptions = "11pt,twoside"
+	writer.preambleFile = filepath.Join(t.TempDir(), "preamble.tex")
+	if err := os.WriteFile(writer.preambleFile, []byte("\\usepackage{tuwien}\n\\newcommand{\\cdm}{CDM}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	writer.WriteModelToLaTeX()
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Finding the parts of the preamble in order
+	remaining := string(latex)
+	for _, want := range []string{
+		"\\documentclass[11pt,twoside]{report}\n",
+		"\\usepackage{xcolor}\n",
+		"\\usepackage{ulem}\n",
+		"\\usepackage{tuwien}\n\\newcommand{\\cdm}{CDM}\n",
+		"\\begin{document}\n",
+	} {
+		index := strings.Index(remaining, want)
+		if index < 0 {
+			t.Fatalf("LaTeX file does not contain %q in order:\n%s", want, latex)
+		}
+		remaining = remaining[index+len(want):]
+	}
+}
+
+// Posting scenario models into the content of the writer's model listener, the way the bus
+// delivers them: a state also resets the update and the considered variant, and an update the
+// considered variant
+type TListenerContentPoster struct {
+	t      *testing.T            // The test posting
+	writer *TCDMModelLaTeXWriter // The writer receiving the postings
+	posted []string              // The kinds of the postings, in order
+}
+
+func (p *TListenerContentPoster) post(kind string, model cdm.TCDMModel, contents ...*json.RawMessage) {
+	modelJSON, ok := model.GetModelAsJSON()
+	if !ok {
+		p.t.Fatal("could not convert the model to JSON")
+	}
+	for _, content := range contents {
+		*content = modelJSON
+	}
+	p.writer.UpdateModelsFromBus()
+	p.posted = append(p.posted, kind)
+}
+
+func (p *TListenerContentPoster) PostState(model cdm.TCDMModel) {
+	listener := &p.writer.ModelListener
+	p.post("state", model, &listener.CurrentContent, &listener.UpdatedContent, &listener.ConsideredContent)
+}
+
+func (p *TListenerContentPoster) PostUpdate(model cdm.TCDMModel) {
+	listener := &p.writer.ModelListener
+	p.post("update", model, &listener.UpdatedContent, &listener.ConsideredContent)
+}
+
+func (p *TListenerContentPoster) PostConsidering(model cdm.TCDMModel) {
+	p.post("considering", model, &p.writer.ModelListener.ConsideredContent)
+}
+
+func (p *TListenerContentPoster) PostAnnotations(cdm_tools.TCDMModelAnnotations) {}
+
+// Posting a considered variant in a scenario reaches the renderer's considered model, so the
+// types it adds are rendered as considered to be added
+func TestScenarioConsidering(t *testing.T) {
+	writer := testLaTeXWriter(t, func(string) {})
+	poster := &TListenerContentPoster{t: t, writer: writer}
+
+	model := cdm.CreateCDMModel(writer.reporter)
+	annotations := cdm_tools.CreateCDMModelAnnotations()
+	steps := []cdm_tools.TScenarioStep{
+		{Description: "Students", Post: cdm_tools.PostState, Mutate: func(m *cdm.TCDMModel) {
+			m.SetModelName("University")
+			m.AddConcreteIndividualType("Student")
+		}},
+		{Description: "Considering courses", Post: cdm_tools.PostConsidering, Mutate: func(m *cdm.TCDMModel) {
+			m.AddConcreteIndividualType("Course")
+		}},
+	}
+	cdm_tools.RunScenario(steps, &model, &annotations, poster)
+
+	if want := []string{"state", "considering"}; !slices.Equal(poster.posted, want) {
+		t.Errorf("posted %v, want %v", poster.posted, want)
+	}
+
+	writer.WriteModelToLaTeX()
+	latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"\\item {\\sf Student}\n",
+		"\\item {\\sf " + ApplyFormatting(considerAdd, "Course") + "}\n",
+	} {
+		if !strings.Contains(string(latex), want) {
+			t.Errorf("LaTeX file does not contain %q:\n%s", want, latex)
+		}
+	}
+}
+
+// Writing the university model to DOT gives its concrete individual types and quality types as
+// nodes, and its relation types as edges labelled with their primary reading, marking the types
+// that are only in the updated model as added
+func TestWriteModelToDOT(t *testing.T) {
+	latexWriter := testLaTeXWriter(t, func(string) {})
+	writer := &TCDMModelDOTWriter{
+		TCDMModelListener: latexWriter.TCDMModelListener,
+		dotFile:           "model",
+		workFolder:        latexWriter.workFolder,
+		shownVersions:     allVersions,
+		debouncer:         &TDebouncer{},
+		reporter:          latexWriter.reporter,
+	}
+
+	// Running the scenario, with all versions sharing the final model
+	annotations := cdm_tools.CreateCDMModelAnnotations()
+	cdm_tools.RunScenario(cdm_tools.UniversityScenario(), &writer.CurrentModel, &annotations, cdm_tools.TNoPoster{})
+	modelJSON, ok := writer.CurrentModel.GetModelAsJSON()
+	if !ok || !writer.UpdatedModel.SetModelFromJSON(modelJSON) || !writer.ConsideredModel.SetModelFromJSON(modelJSON) {
+		t.Fatal("could not copy the model")
+	}
+
+	// Adding a type to the updated and considered models only
+	lecturer := writer.UpdatedModel.AddConcreteIndividualType("Lecturer")
+	lecturerJSON, ok := writer.UpdatedModel.GetModelAsJSON()
+	if !ok || !writer.ConsideredModel.SetModelFromJSON(lecturerJSON) {
+		t.Fatal("could not copy the updated model")
+	}
+
+	// Finding the IDs of the types, which are the node names
+	typeID := map[string]string{}
+	for id, name := range writer.CurrentModel.TypeName {
+		typeID[name] = id
+	}
+
+	writer.WriteModelToDOT()
+
+	dot, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+dotFileExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"digraph CDM {\n",
+		"label=<CDM Model: University>;\n",
+		"\"" + typeID["Student"] + "\" [shape=box, label=<Student>];\n",
+		"\"" + typeID["Study Programme"] + "\" [shape=box, label=<Study Programme>];\n",
+		"\"" + typeID["Student Name"] + "\" [shape=ellipse, label=<Student Name>];\n",
+		"\"" + lecturer + "\" [shape=box, label=<" + fmt.Sprintf(dotToAdd, "Lecturer") + ">];\n",
+		"\"" + typeID["Student"] + "\" -> \"" + typeID["Study Programme"] + "\" [label=<Student studies Study Programme>];\n",
+		"\"" + typeID["Student"] + "\" -> \"" + typeID["Student Name"] + "\" [label=<Student has Student Name>];\n",
+		"}\n",
+	} {
+		if !strings.Contains(string(dot), want) {
+			t.Errorf("DOT file does not contain %q:\n%s", want, dot)
+		}
+	}
+}
+
+// Writing the university model to LaTeX only renders the alternative readings of its relation
+// types when not restricted to the primary readings
+func TestPrimaryReadingsOnly(t *testing.T) {
+	tests := []struct {
+		name                string
+		primaryReadingsOnly bool
+	}{
+		{"all readings", false},
+		{"primary readings", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := testLaTeXWriter(t, func(string) {})
+			writer.primaryReadingsOnly = test.primaryReadingsOnly
+
+			// Running the scenario, with all versions sharing the final model
+			writer.annotations.Annotations = cdm_tools.CreateCDMModelAnnotations()
+			cdm_tools.RunScenario(cdm_tools.UniversityScenario(), &writer.CurrentModel, &writer.annotations.Annotations, cdm_tools.TNoPoster{})
+			modelJSON, ok := writer.CurrentModel.GetModelAsJSON()
+			if !ok || !writer.UpdatedModel.SetModelFromJSON(modelJSON) || !writer.ConsideredModel.SetModelFromJSON(modelJSON) {
+				t.Fatal("could not copy the model")
+			}
+
+			writer.WriteModelToLaTeX()
+
+			latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(latex), "Primary reading:") {
+				t.Errorf("LaTeX file has no primary readings:\n%s", latex)
+			}
+			if alternatives := strings.Contains(string(latex), "Alternative reading(s):"); alternatives == test.primaryReadingsOnly {
+				t.Errorf("alternative readings rendered = %t, want %t:\n%s", alternatives, !test.primaryReadingsOnly, latex)
+			}
+		})
+	}
+}
+
+// The watchdog leaves the subscriptions alone while postings arrive, and re-establishes them
+// once postings stop arriving, as when the connection drops
+func TestWatchdog(t *testing.T) {
+	var progress atomic.Int32
+	reporter := generics.CreateReporter(generics.ProgressLevelBasic,
+		func(message string) { t.Errorf("reported error: %s", message) },
+		func(string) { progress.Add(1) })
+	watchdog := CreateWatchdog(50*time.Millisecond, 200*time.Millisecond, reporter)
+
+	resubscribed := make(chan bool, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watchdog.Supervise(ctx, func() { resubscribed <- true })
+
+	// Postings arriving well within the timeout
+	for range 10 {
+		time.Sleep(10 * time.Millisecond)
+		watchdog.Beat()
+	}
+	select {
+	case <-resubscribed:
+		t.Fatal("re-subscribed while postings were arriving")
+	default:
+	}
+
+	// Simulating a connection drop, by no longer receiving postings
+	select {
+	case <-resubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("not re-subscribed after the postings stopped")
+	}
+	if progress.Load() == 0 {
+		t.Error("re-subscription not reported")
+	}
+}
+
+// The configured author and date of the LaTeX document are escaped in its preamble, where the
+// auto date is the timestamp of the most recent state posting
+func TestDocumentMetadata(t *testing.T) {
+	tests := []struct {
+		name             string
+		settings         string
+		postingTimestamp string
+		want             []string
+		unwanted         []string
+	}{
+		{
+			name:     "defaults",
+			want:     []string{"\\author{~~}\n"},
+			unwanted: []string{"\\date{"},
+		},
+		{
+			name:     "configured",
+			settings: "pdf_author = Proper & Co\npdf_date = 18 December 2025\n",
+			want:     []string{"\\author{Proper \\& Co}\n", "\\date{18 December 2025}\n"},
+		},
+		{
+			name:             "auto date",
+			settings:         "pdf_date = auto\n",
+			postingTimestamp: "2025-12-18-10-22-33-00",
+			want:             []string{"\\date{18 December 2025 10:22}\n"},
+		},
+		{
+			name:     "auto date without a posting",
+			settings: "pdf_date = auto\n",
+			unwanted: []string{"\\date{"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testWriter := testLaTeXWriter(t, func(string) {})
+
+			configFile := filepath.Join(t.TempDir(), "config.ini")
+			settings := "work_folder = " + testWriter.workFolder + "\nlatex = model\n" + test.settings
+			if err := os.WriteFile(configFile, []byte(settings), 0644); err != nil {
+				t.Fatal(err)
+			}
+			configData := generics.LoadConfig(configFile, testWriter.reporter)
+
+			writer := CreateCDMLaTeXWriter(configData, testWriter.TCDMModelListener, testWriter.reporter)
+			writer.postingTimestamp = test.postingTimestamp
+			writer.WriteModelToLaTeX()
+
+			latex, err := os.ReadFile(filepath.Join(writer.workFolder, "model"+latexFileExtension))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(string(latex), want) {
+					t.Errorf("LaTeX file does not contain %q:\n%s", want, latex)
+				}
+			}
+			for _, unwanted := range test.unwanted {
+				if strings.Contains(string(latex), unwanted) {
+					t.Errorf("LaTeX file contains %q:\n%s", unwanted, latex)
+				}
+			}
+		})
+	}
+}
+
+// Running the app with command line arguments, capturing its outputs, exits with the exit code
+// of the outcome, without needing a modelling bus when failing before connecting to it
+func TestRunCommandLine(t *testing.T) {
+	missingConfig := filepath.Join(t.TempDir(), "missing.ini")
+
+	tests := []struct {
+		name      string
+		arguments []string
+		exitCode  int
+		stdout    string
+		stderr    string
+	}{
+		{"version", []string{"-version"}, 0, "cdm_pdf_renderer, version of " + appVersion, ""},
+		{"help", []string{"-h"}, 0, "", "-config string"},
+		{"unknown flag", []string{"-colour"}, app_generics.ExitUsageError, "", "flag provided but not defined: -colour"},
+		{"missing required flags", []string{}, app_generics.ExitUsageError, "", "Missing required flag(s) for rendering: -from_agent, -for_model."},
+		{"missing configuration", []string{"-config", missingConfig, "-from_agent", "agent", "-for_model", "university"}, app_generics.ExitConfigError, "", "Error loading configuration file:"},
+	}
+
+	t.Cleanup(func() { app_generics.SetReportOutput(os.Stdout, os.Stderr) })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(test.arguments, &stdout, &stderr)
+
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v, output: %s%s)", exitCode, test.exitCode, err, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("output does not contain %q:\n%s", test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("error output does not contain %q:\n%s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
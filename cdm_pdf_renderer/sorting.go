@@ -0,0 +1,98 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: LaTeX based PDF Renderer for CDM Models, Version 1
+ *
+ * This part of the application supports listing the elements of CDM models in a
+ * deterministic order, shared by the LaTeX, HTML, and DOT writers.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package main
+
+import (
+	"sort"
+
+	"app_generics/cdm_tools"
+
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Support functions
+ */
+
+// Listing the included elements of a set, in sorted order of their IDs
+func sortedIncluded(elements map[string]bool) []string {
+	included := []string{}
+	for element, isIncluded := range elements {
+		if isIncluded {
+			included = append(included, element)
+		}
+	}
+	sort.Strings(included)
+
+	return included
+}
+
+// Listing the included elements of a set, sorted by the given key, and by their IDs for equal keys.
+// The IDs are timestamps, which do not sort in the order of their creation, so listing by ID
+// alone would order the same model differently each time it is built.
+func sortedIncludedBy(elements map[string]bool, key func(string) string) []string {
+	included := sortedIncluded(elements)
+	sort.SliceStable(included, func(i, j int) bool {
+		return key(included[i]) < key(included[j])
+	})
+
+	return included
+}
+
+// The most recent version of the model in which an element is defined: the considered
+// version, the updated version, or the state
+func latestModelWith(modelListener *cdm.TCDMModelListener, defines func(cdm.TCDMModel) bool) cdm.TCDMModel {
+	for _, model := range []cdm.TCDMModel{modelListener.ConsideredModel, modelListener.UpdatedModel} {
+		if defines(model) {
+			return model
+		}
+	}
+
+	return modelListener.CurrentModel
+}
+
+// Listing the included types of a set, sorted by their most recent names
+func sortedTypes(modelListener *cdm.TCDMModelListener, types map[string]bool) []string {
+	return sortedIncludedBy(types, func(tpe string) string {
+		return latestModelWith(modelListener, func(model cdm.TCDMModel) bool {
+			_, defined := model.TypeName[tpe]
+			return defined
+		}).TypeName[tpe]
+	})
+}
+
+// Listing the included involvement types of a set, sorted by the most recent names of their
+// base types, and then by their own most recent names
+func sortedInvolvementTypes(modelListener *cdm.TCDMModelListener, involvementTypes map[string]bool) []string {
+	return sortedIncludedBy(involvementTypes, func(involvementType string) string {
+		model := latestModelWith(modelListener, func(model cdm.TCDMModel) bool {
+			_, defined := model.TypeName[involvementType]
+			return defined
+		})
+
+		return model.TypeName[model.BaseTypeOfInvolvementType[involvementType]] + "\x00" + model.TypeName[involvementType]
+	})
+}
+
+// Listing the included relation type readings of a set, sorted by their most recent sentences
+func sortedReadings(modelListener *cdm.TCDMModelListener, readings map[string]bool) []string {
+	return sortedIncludedBy(readings, func(reading string) string {
+		return cdm_tools.ReadingSentence(latestModelWith(modelListener, func(model cdm.TCDMModel) bool {
+			_, defined := model.ReadingDefinition[reading]
+			return defined
+		}), reading)
+	})
+}
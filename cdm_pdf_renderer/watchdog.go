@@ -0,0 +1,92 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: LaTeX based PDF Renderer for CDM Models, Version 1
+ *
+ * This part of the application supervises the listening for model postings. When no
+ * posting arrives within the heartbeat timeout, the connection may have dropped, so
+ * the subscriptions are re-established, with an increasing backoff between attempts.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 16.12.2025
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining the watchdog
+ */
+
+type TWatchdog struct {
+	timeout    time.Duration // Time without postings after which the subscriptions are re-established
+	maxBackoff time.Duration // Maximum time between attempts to re-establish the subscriptions
+
+	lastBeat atomic.Int64 // Time, in Unix nanoseconds, of the most recent posting
+
+	reporter *generics.TReporter // The Reporter to be used to report progress, errors, and panics
+}
+
+// Signalling that a posting has been received. A nil watchdog ignores the signal.
+func (w *TWatchdog) Beat() {
+	if w != nil {
+		w.lastBeat.Store(time.Now().UnixNano())
+	}
+}
+
+// Supervising the subscriptions until the context is cancelled, re-establishing them using
+// resubscribe whenever no posting arrived within the timeout
+func (w *TWatchdog) Supervise(ctx context.Context, resubscribe func()) {
+	// Starting the first timeout now
+	w.Beat()
+
+	attempt := 0
+	backoff := w.timeout
+	wait := w.timeout
+	for {
+		// Waiting for the next check, or a shutdown
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		// Checking if postings are still arriving
+		silence := time.Since(time.Unix(0, w.lastBeat.Load()))
+		if silence < w.timeout {
+			attempt = 0
+			backoff = w.timeout
+			wait = w.timeout - silence
+
+			continue
+		}
+
+		// Re-establishing the subscriptions
+		attempt++
+		w.reporter.Progress(generics.ProgressLevelBasic, "No postings received for %s. Re-establishing subscriptions (attempt %d).", silence.Round(time.Second), attempt)
+		resubscribe()
+
+		// Backing off before the next attempt
+		wait = backoff
+		backoff = min(2*backoff, w.maxBackoff)
+	}
+}
+
+// Creating a watchdog with the given heartbeat timeout and maximum backoff
+func CreateWatchdog(timeout, maxBackoff time.Duration, reporter *generics.TReporter) *TWatchdog {
+	return &TWatchdog{
+		timeout:    timeout,
+		maxBackoff: max(timeout, maxBackoff),
+		reporter:   reporter,
+	}
+}
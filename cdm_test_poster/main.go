@@ -20,7 +20,12 @@ import (
 	"bufio"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"time"
+
+	"app_generics"
+	"app_generics/cdm_tools"
 
 	"github.com/erikproper/big-modelling-bus.go.v1/connect"
 	"github.com/erikproper/big-modelling-bus.go.v1/generics"
@@ -33,6 +38,8 @@ import (
 
 const (
 	defaultIni = "config.ini" // Default configuration file name
+
+	appName = "cdm_test_poster" // Name of the app
 )
 
 /*
@@ -40,16 +47,55 @@ const (
  */
 
 var (
-	configFlag      = flag.String("config", defaultIni, "Configuration file")               // Configuration file flag
-	reportLevelFlag = flag.Int("reporting", generics.ProgressLevelBasic, "Reporting level") // Reporting level flag
+	flags = flag.NewFlagSet(appName, flag.ContinueOnError) // The flags of the app
+
+	configFlag           = flags.String("config", defaultIni, "Configuration file")                                                                          // Configuration file flag
+	configSetFlag        = app_generics.ConfigOverridesFlag(flags, "set", "Configuration value to override, as key=value or section.key=value (repeatable)") // Configuration override flag
+	reportLevelFlag      = flags.Int("reporting", generics.ProgressLevelBasic, "Reporting level")                                                            // Reporting level flag
+	errorReportLevelFlag = flags.Int("error_reporting", generics.ProgressLevelBasic, "Error reporting level")                                                // Error reporting level flag
+	logFileFlag          = flags.String("log_file", "", "Log file to write the reports to, instead of the standard output and standard error")               // Log file flag
+	logMaxSizeFlag       = flags.Int64("log_max_size", 0, "Size in bytes beyond which the log file is rotated (0 for no rotation)")                          // Log file rotation size flag
+	logFormatFlag        = flags.String("log_format", app_generics.TextLogFormat, "Format of the reports: text or json")                                     // Log format flag
+	configCheckFlag      = flags.Bool("config_check", false, "Only check that the connector can be created from the configuration, and exit")                // Configuration check flag
+	createWorkFolderFlag = flags.Bool("create_work_folder", false, "Create the work folder, if it does not exist yet")                                       // Create work folder flag
+	printFlag            = flags.Bool("print", false, "Print a summary of the model to the standard output, without posting")                                // Print flag
+	saveFlag             = flags.String("save", "", "Save the final model of the scenario to the given file, without posting")                               // Save flag
+	validateFlag         = flags.Bool("validate", false, "Validate the models before posting them, refusing to post inconsistent ones")                      // Validate flag
+	noPauseFlag          = flags.Bool("no_pause", false, "Do not pause between the posting stages")                                                          // No pause flag
+	pauseSecondsFlag     = flags.Int("pause_seconds", 0, "Pause this many seconds between the posting stages, instead of waiting for a key")                 // Pause seconds flag
 )
 
+/*
+ * Validating models
+ */
+
+// Validating the models before posting them, if requested
+func maybeValidating(poster cdm_tools.TScenarioPoster, reporter *generics.TReporter) cdm_tools.TScenarioPoster {
+	if *validateFlag {
+		return cdm_tools.CreateValidatingPoster(poster, reporter)
+	}
+
+	return poster
+}
+
 /*
  * Pausing during posting. Just needed for testing purposes.
  */
 
-func Pause() {
-	fmt.Println("Press any key")
+func Pause(output io.Writer) {
+	// Not pausing at all, if requested
+	if *noPauseFlag {
+		return
+	}
+
+	// Pausing for a fixed interval, if requested
+	if *pauseSecondsFlag > 0 {
+		time.Sleep(time.Duration(*pauseSecondsFlag) * time.Second)
+
+		return
+	}
+
+	fmt.Fprintln(output, "Press any key")
 	input := bufio.NewScanner(os.Stdin)
 	input.Scan()
 }
@@ -59,14 +105,83 @@ func Pause() {
  */
 
 func main() {
+	os.Exit(app_generics.ExitCode(run(os.Args[1:], os.Stdout, os.Stderr)))
+}
+
+// Running the app with the given arguments and outputs, returning an exit error when it fails
+func run(arguments []string, stdout, stderr io.Writer) (err error) {
 	// Parsing command line flags
-	flag.Parse()
+	flags.SetOutput(stderr)
+	parsed, err := app_generics.ParseFlags(flags, arguments)
+	if !parsed {
+		return err
+	}
+
+	// Failing with an error code if errors were reported, once all else is done
+	errorCount := app_generics.ReportedErrorCount()
+	defer func() {
+		if err == nil {
+			err = app_generics.ExitErrorSince(errorCount)
+		}
+	}()
+
+	// Directing the progress reports to the output, and the errors to the error output
+	app_generics.SetReportOutput(stdout, stderr)
 
 	// Creating the reporter
-	reporter := generics.CreateReporter(*reportLevelFlag, generics.ReportError, generics.ReportProgress)
+	reporter := app_generics.CreateReporter(*reportLevelFlag, *errorReportLevelFlag)
+
+	// Formatting the reports as requested
+	if reporter.MaybeReportError("Error in log format flag:", app_generics.SetLogFormat(*logFormatFlag)) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Directing the reports to a log file, if requested
+	if *logFileFlag != "" {
+		if reporter.MaybeReportError("Error opening log file:", app_generics.SetLogFile(*logFileFlag, *logMaxSizeFlag)) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+	}
+
+	// Only checking the configuration, if requested
+	if *configCheckFlag {
+		return app_generics.CheckConfig(*configFlag, *configSetFlag, connect.PostingOnly, reporter)
+	}
+
+	// Only printing a summary of the scenario's final model, and/or saving it, if requested
+	if *printFlag || *saveFlag != "" {
+		CDMModel := cdm.CreateCDMModel(reporter)
+		annotations := cdm_tools.CreateCDMModelAnnotations()
+		cdm_tools.RunScenario(cdm_tools.UniversityScenario(), &CDMModel, &annotations, maybeValidating(cdm_tools.TNoPoster{}, reporter))
+
+		if *printFlag {
+			cdm_tools.WriteModelSummary(stdout, CDMModel)
+		}
+
+		if *saveFlag != "" {
+			if reporter.MaybeReportError("Error saving model:", cdm_tools.SaveCDMModel(CDMModel, *saveFlag)) {
+				return app_generics.ExitWith(app_generics.ExitBusError)
+			}
+		}
+
+		return nil
+	}
 
 	// Loading the configuration
-	configData := generics.LoadConfig(*configFlag, reporter)
+	configData, err := app_generics.LoadConfig(*configFlag, *configSetFlag, reporter)
+	if err != nil {
+		return err
+	}
+
+	// Checking the required configuration
+	if !app_generics.RequireConfigKeys(configData, reporter, "work_folder") {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
+
+	// Checking the work folder
+	if reporter.MaybeReportError("Error in work folder:", app_generics.CheckWorkFolder(configData.GetValue("", "work_folder").String(), *createWorkFolderFlag)) {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
 
 	// Creating the Modelling Bus Connector
 	ModellingBusConnector := connect.CreateModellingBusConnector(configData, reporter, connect.PostingOnly)
@@ -80,57 +195,13 @@ func main() {
 	//		ModellingBusConnector.DeleteRawArtefact("context", "golang", "test.go")
 
 	// Note that the 0001 is for local use. No issue to e.g. make this into 0001/02 to indicate version numbers
-	CDMModellingBusPoster := cdm.CreateCDMPoster(ModellingBusConnector, "0001")
+	CDMModellingBusPoster := maybeValidating(cdm_tools.CreateScenarioBusPoster(ModellingBusConnector, "0001"), reporter)
 
 	CDMModel := cdm.CreateCDMModel(reporter)
-	CDMModel.SetModelName("Empty university")
-
-	fmt.Println("1) empty model")
-	CDMModellingBusPoster.PostState(CDMModel)
-	fmt.Println("Posted state")
-	Pause()
-
-	Student := CDMModel.AddConcreteIndividualType("Student")
-	StudyProgramme := CDMModel.AddConcreteIndividualType("Study Programme")
-	StudentName := CDMModel.AddQualityType("Student Name", "string")
-	StudyProgrammeName := CDMModel.AddQualityType("Study Programme Name", "string")
-	CDMModel.SetModelName("Basic university")
-
-	fmt.Println("2) basic model")
-	CDMModellingBusPoster.PostUpdate(CDMModel)
-	fmt.Println("Posted update")
-	Pause()
-
-	fmt.Println("3) basic model")
-	CDMModellingBusPoster.PostState(CDMModel)
-	fmt.Println("Posted state")
-	Pause()
-
-	StudyProgrammeStudied := CDMModel.AddInvolvementType("studied by", StudyProgramme)
-	StudentStudying := CDMModel.AddInvolvementType("studying", Student)
-	Studies := CDMModel.AddRelationType("Studies", StudyProgrammeStudied, StudentStudying)
-	CDMModel.AddRelationTypeReading(Studies, "", StudentStudying, "studies", StudyProgrammeStudied, "")
-	CDMModel.AddRelationTypeReading(Studies, "", StudyProgrammeStudied, "studied by", StudentStudying, "")
-
-	StudentReferred := CDMModel.AddInvolvementType("referred", Student)
-	StudentNameReferring := CDMModel.AddInvolvementType("referring", StudentName)
-	StudentNaming := CDMModel.AddRelationType("Student Naming", StudentReferred, StudentNameReferring)
-	CDMModel.AddRelationTypeReading(StudentNaming, "", StudentReferred, "has", StudentNameReferring, "")
-	CDMModel.AddRelationTypeReading(StudentNaming, "", StudentNameReferring, "of", StudentReferred, "")
-
-	StudyProgrammeReferred := CDMModel.AddInvolvementType("referred", StudyProgramme)
-	StudyProgrammeNameReferring := CDMModel.AddInvolvementType("referring", StudyProgrammeName)
-	StudyProgrammeNaming := CDMModel.AddRelationType("Programme Naming", StudyProgrammeReferred, StudyProgrammeNameReferring)
-	CDMModel.AddRelationTypeReading(StudyProgrammeNaming, "", StudyProgrammeReferred, "goes by", StudyProgrammeNameReferring, "")
-	CDMModel.AddRelationTypeReading(StudyProgrammeNaming, "", StudyProgrammeNameReferring, "of", StudyProgrammeReferred, "")
-	CDMModel.SetModelName("University")
-
-	fmt.Println("4) larger model")
-	CDMModellingBusPoster.PostUpdate(CDMModel)
-	fmt.Println("Posted update")
-	Pause()
-
-	// Reference modes
+	annotations := cdm_tools.CreateCDMModelAnnotations()
+
+	// Running the university scenario, pausing between the steps
+	cdm_tools.PostModelStages(cdm_tools.UniversityScenario(), &CDMModel, &annotations, CDMModellingBusPoster, func() { Pause(stdout) }, stdout)
 
 	// CONSTRAINTS
 	//
@@ -138,6 +209,5 @@ func main() {
 	// push_model
 	// push_update
 
-	fmt.Println("5) final model")
-	CDMModellingBusPoster.PostState(CDMModel)
+	return nil
 }
@@ -0,0 +1,114 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Poster for CDM Models, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"app_generics"
+	"app_generics/cdm_tools"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+// With -no_pause, all posting stages of the scenario are posted without reading the input,
+// which is a pipe that never delivers anything
+func TestNoPause(t *testing.T) {
+	if _, err := app_generics.ParseFlags(flags, []string{"-no_pause"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { app_generics.ParseFlags(flags, nil) })
+
+	// Replacing the input by a pipe that is never written to
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		writer.Close()
+		reader.Close()
+	})
+
+	// Posting the stages, which would block when pausing for a key
+	var output bytes.Buffer
+	posted := make(chan bool)
+	go func() {
+		reporter := generics.CreateReporter(app_generics.ProgressLevelSilent,
+			func(message string) { t.Errorf("reported error: %s", message) },
+			func(string) {})
+		model := cdm.CreateCDMModel(reporter)
+		annotations := cdm_tools.CreateCDMModelAnnotations()
+		cdm_tools.PostModelStages(cdm_tools.UniversityScenario(), &model, &annotations, cdm_tools.TNoPoster{}, func() { Pause(&output) }, &output)
+		close(posted)
+	}()
+
+	select {
+	case <-posted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("posting the stages blocked on the input")
+	}
+
+	if stages, want := strings.Count(output.String(), "Posted "), len(cdm_tools.UniversityScenario()); stages != want {
+		t.Errorf("posted %d stage(s), want %d:\n%s", stages, want, output.String())
+	}
+	if strings.Contains(output.String(), "Press any key") {
+		t.Errorf("asked for a key:\n%s", output.String())
+	}
+}
+
+// Running the app with command line arguments, capturing its outputs, exits with the exit code
+// of the outcome, without needing a modelling bus when failing before connecting to it
+func TestRunCommandLine(t *testing.T) {
+	missingConfig := filepath.Join(t.TempDir(), "missing.ini")
+
+	tests := []struct {
+		name      string
+		arguments []string
+		exitCode  int
+		stdout    string
+		stderr    string
+	}{
+		{"print", []string{"-print"}, 0, "Model: University\n", ""},
+		{"help", []string{"-h"}, 0, "", "-config string"},
+		{"unknown flag", []string{"-colour"}, app_generics.ExitUsageError, "", "flag provided but not defined: -colour"},
+		{"missing configuration", []string{"-config", missingConfig}, app_generics.ExitConfigError, "", "Error loading configuration file:"},
+	}
+
+	t.Cleanup(func() { app_generics.SetReportOutput(os.Stdout, os.Stderr) })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(test.arguments, &stdout, &stderr)
+
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v, output: %s%s)", exitCode, test.exitCode, err, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("output does not contain %q:\n%s", test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("error output does not contain %q:\n%s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
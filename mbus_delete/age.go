@@ -0,0 +1,88 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1
+ *
+ * This part of the application supports only deleting observations that are older
+ * than a given age, based on the timestamp of their posting.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"os"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	timestampProbeFile = "mbus_delete_timestamp_probe" // Name of the file a raw observation is retrieved to, for its timestamp
+)
+
+/*
+ * Age support
+ */
+
+// Getting the timestamp of the posting of an observation, by retrieving it
+func observationTimestamp(deletion *TDeletionContext, kind, observationID string) string {
+	switch kind {
+	case rawObservationDeletion:
+		filePath, timestamp := deletion.Bus.GetRawObservation(deletion.OwnerAgentID, observationID, timestampProbeFile)
+		if filePath != "" {
+			os.Remove(filePath)
+		}
+
+		return timestamp
+	case jsonObservationDeletion:
+		_, timestamp := deletion.Bus.GetJSONObservation(deletion.OwnerAgentID, observationID)
+
+		return timestamp
+	default:
+		_, timestamp := deletion.Bus.GetStreamedObservation(deletion.OwnerAgentID, observationID)
+
+		return timestamp
+	}
+}
+
+// Checking if an observation is old enough to be deleted. Observations posted exactly
+// at the cutoff are kept. Without an age, all observations are old enough. Returns an
+// error when the age of the observation cannot be determined.
+func oldEnoughForDeletion(deletion *TDeletionContext, kind, observationID string) (bool, error) {
+	if deletion.OlderThan == "" {
+		return true, nil
+	}
+
+	// Determining when the observation was posted
+	rawTimestamp := observationTimestamp(deletion, kind, observationID)
+	if rawTimestamp == "" {
+		deletion.Reporter.Error("No timestamp found for %s: %s", kind, observationID)
+
+		return false, app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	timestamp, err := app_generics.ParseTimestamp(rawTimestamp)
+	if deletion.Reporter.MaybeReportError("Error parsing the timestamp of "+observationID+":", err) {
+		return false, err
+	}
+
+	// Skipping observations that are too recent
+	if !timestamp.Before(deletion.Cutoff) {
+		deletion.Reporter.Progress(generics.ProgressLevelBasic, "Skipping %s %s, posted at %s, which is not older than %s.", kind, observationID, rawTimestamp, deletion.OlderThan)
+
+		return false, nil
+	}
+
+	return true, nil
+}
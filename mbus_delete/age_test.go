@@ -0,0 +1,70 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Deleting observations by age only deletes those posted before the cutoff, keeping an
+// observation posted exactly at the cutoff
+func TestAgeBasedDeletion(t *testing.T) {
+	tests := []struct {
+		name    string
+		cutoff  time.Duration // The cutoff, relative to the posting of the observation
+		deleted bool
+	}{
+		{"posted before the cutoff", time.Second, true},
+		{"posted at the cutoff", 0, false},
+		{"posted after the cutoff", -time.Second, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := app_generics.CreateFakeModellingBus("agent", t.TempDir())
+			bus.PostJSONObservation("sensors/temperature", []byte(`{"celsius":21}`))
+
+			posted, _ := bus.Posting(app_generics.FakeJSONObservation, "agent", "sensors/temperature")
+			postingTime, err := app_generics.ParseTimestamp(posted.Timestamp)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			deletion := &TDeletionContext{
+				Kind:          jsonObservationDeletion,
+				ObservationID: "sensors/temperature",
+				OwnerAgentID:  "agent",
+				OlderThan:     "7d",
+				Cutoff:        postingTime.Add(test.cutoff),
+				Yes:           true,
+				Bus:           bus,
+				Reporter: generics.CreateReporter(app_generics.ProgressLevelSilent,
+					func(message string) { t.Errorf("reported error: %s", message) },
+					func(string) {}),
+			}
+
+			if err := handleJSONObservationDeletion(deletion); err != nil {
+				t.Fatalf("deletion failed: %v", err)
+			}
+
+			if _, present := bus.Posting(app_generics.FakeJSONObservation, "agent", "sensors/temperature"); present == test.deleted {
+				t.Errorf("observation present = %t, want %t", present, !test.deleted)
+			}
+		})
+	}
+}
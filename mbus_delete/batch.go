@@ -0,0 +1,93 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1
+ *
+ * This part of the application supports deleting a batch of postings, with the IDs
+ * (or topics) taken from a file.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"app_generics"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	idsFileComment = "#" // Prefix of comment lines in ID files
+)
+
+/*
+ * Batch support
+ */
+
+// Reading the IDs from a newline-delimited file, skipping blank lines and comment lines
+func readIDsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ids := []string{}
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		if id := strings.TrimSpace(lines.Text()); id != "" && !strings.HasPrefix(id, idsFileComment) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, lines.Err()
+}
+
+// Determining the field of the deletion context holding the ID, or topic, of the deletion kind
+func deletionID(deletion *TDeletionContext) *string {
+	switch deletion.Kind {
+	case rawArtefactDeletion, jsonArtefactDeletion:
+		return &deletion.ArtefactID
+	case coordinationDeletion:
+		return &deletion.CoordinationTopic
+	case environmentDeletion:
+		return &deletion.Environment
+	default:
+		return &deletion.ObservationID
+	}
+}
+
+// Handling the deletion of a batch of postings, with the IDs taken from the IDs file
+func handleBatchDeletion(deletion *TDeletionContext, deletionHandler TDeletionHandler) error {
+	// Reading the IDs
+	ids, err := readIDsFile(deletion.IDsFile)
+	if deletion.Reporter.MaybeReportError("Error reading IDs file:", err) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	// Deleting the postings, one at a time, as the errors reported by each deletion are counted
+	results := app_generics.RunTasks(app_generics.Sequential, deletion.FailFast, ids, func(id string) error {
+		idDeletion := *deletion
+		*deletionID(&idDeletion) = id
+
+		return app_generics.ErrorsReportedBy(func() error { return deletionHandler(&idDeletion) })
+	})
+
+	// Reporting the summary
+	if !app_generics.ReportTaskSummary(deletion.Reporter, "Deleted", "postings", results) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	return nil
+}
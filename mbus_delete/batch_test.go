@@ -0,0 +1,85 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Deleting a batch applies the deletion to each ID in the IDs file, skipping blank and comment
+// lines, and, with a failing deletion in the middle, either continues or stops when failing fast,
+// reporting the partial progress and failing in both cases
+func TestBatchDeletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		failFast bool
+		deleted  []string
+		summary  []string
+	}{
+		{"continuing", false, []string{"sensors/a", "sensors/c"}, []string{"Deleted 2 of 3 postings."}},
+		{"failing fast", true, []string{"sensors/a"}, []string{"Deleted 1 of 3 postings.", "Stopped after the first failure, leaving 1 of 3 postings unprocessed."}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idsFile := filepath.Join(t.TempDir(), "ids.txt")
+			if err := os.WriteFile(idsFile, []byte("sensors/a\n# retired sensors\n\nsensors/b\nsensors/c\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			progress := []string{}
+			errorsReported := 0
+			deletion := &TDeletionContext{
+				Kind:     jsonObservationDeletion,
+				IDsFile:  idsFile,
+				FailFast: test.failFast,
+				Reporter: generics.CreateReporter(generics.ProgressLevelBasic,
+					func(string) { errorsReported++ },
+					func(message string) { progress = append(progress, message) }),
+			}
+
+			deleted := []string{}
+			err := handleBatchDeletion(deletion, func(deletion *TDeletionContext) error {
+				if deletion.ObservationID == "sensors/b" {
+					return errors.New("deletion failed")
+				}
+				deleted = append(deleted, deletion.ObservationID)
+
+				return nil
+			})
+
+			if exitCode := app_generics.ExitCode(err); exitCode != app_generics.ExitBusError {
+				t.Errorf("exit code = %d, want %d (error: %v)", exitCode, app_generics.ExitBusError, err)
+			}
+			if !slices.Equal(deleted, test.deleted) {
+				t.Errorf("deleted %q, want %q", deleted, test.deleted)
+			}
+			for _, summary := range test.summary {
+				if !slices.Contains(progress, summary) {
+					t.Errorf("progress %q does not report %q", progress, summary)
+				}
+			}
+			if errorsReported != 1 {
+				t.Errorf("reported %d error(s), want the failed postings", errorsReported)
+			}
+		})
+	}
+}
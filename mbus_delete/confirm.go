@@ -0,0 +1,120 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1
+ *
+ * This part of the application asks for confirmation before deleting, as deletions
+ * cannot be undone, or only previews the deletions in a dry run.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Confirming deletions
+ */
+
+// Checking if the input is a terminal, so we can prompt the user
+func inputIsTerminal(input io.Reader) bool {
+	file, isFile := input.(*os.File)
+	if !isFile {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Confirming the deletion of the given kind and ID, where:
+//   - -no declines all deletions;
+//   - -yes confirms all deletions;
+//   - -force confirms all deletions, except those of environments;
+//   - otherwise, the user is prompted, which requires the input to be a terminal.
+//
+// Returns an error when the deletion cannot be confirmed, rather than being declined.
+func confirmDeletion(deletion *TDeletionContext, kind, id string) (bool, error) {
+	// Declining, if so requested
+	if deletion.No {
+		deletion.Reporter.Progress(generics.ProgressLevelBasic, "Declined deletion of %s: %s", kind, id)
+
+		return false, nil
+	}
+
+	// Confirming, if so requested
+	if deletion.Yes || (deletion.Force && kind != environmentDeletion) {
+		return true, nil
+	}
+
+	// We can only prompt on a terminal, rather than waiting for input that never comes
+	if !deletion.Interactive {
+		deletion.Reporter.Error("Refusing to delete %s %s without confirmation. Use -yes to confirm when not running interactively.", kind, id)
+
+		return false, app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Prompting the user
+	fmt.Fprintf(deletion.Output, "Delete %s %s? [y/N] ", kind, id)
+	input := bufio.NewScanner(deletion.Input)
+	input.Scan()
+
+	answer := strings.ToLower(strings.TrimSpace(input.Text()))
+	if answer == "y" || answer == "yes" {
+		return true, nil
+	}
+
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Declined deletion of %s: %s", kind, id)
+
+	return false, nil
+}
+
+/*
+ * Previewing deletions
+ */
+
+// Listing the postings in an environment that its deletion would remove, as far as the connector can enumerate them
+func previewEnvironmentDeletion(deletion *TDeletionContext, environment string) {
+	artefactIDs, err := app_generics.ListArtefacts(*deletion.Connector, deletion.AgentID)
+	if err != nil {
+		deletion.Reporter.Progress(generics.ProgressLevelBasic, "Cannot list the postings in environment %s: %s", environment, err)
+
+		return
+	}
+
+	for _, artefactID := range artefactIDs {
+		deletion.Reporter.Progress(generics.ProgressLevelBasic, "Would delete artefact: %s", artefactID)
+	}
+}
+
+// Deciding if the deletion of the given kind and ID should proceed. In a dry run, the
+// deletion is only previewed, and otherwise it needs to be confirmed.
+func proceedWithDeletion(deletion *TDeletionContext, kind, id string) (bool, error) {
+	// Only previewing the deletion in a dry run
+	if deletion.DryRun {
+		deletion.Reporter.Progress(generics.ProgressLevelBasic, "Would delete %s: %s", kind, id)
+		if kind == environmentDeletion {
+			previewEnvironmentDeletion(deletion, id)
+		}
+
+		return false, nil
+	}
+
+	return confirmDeletion(deletion, kind, id)
+}
@@ -2,7 +2,10 @@ module mbus_delete
 
 go 1.24.0
 
-require github.com/erikproper/big-modelling-bus.go.v1 v1.0.32
+require (
+	app_generics v0.0.0
+	github.com/erikproper/big-modelling-bus.go.v1 v1.0.32
+)
 
 require (
 	github.com/eclipse/paho.mqtt.golang v1.5.1 // indirect
@@ -18,4 +21,10 @@ require (
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
+	plantuml v0.0.0 // indirect
+)
+
+replace (
+	app_generics => ../app_generics
+	plantuml => ../plantuml
 )
@@ -16,6 +16,11 @@ package main
 
 import (
 	"flag"
+	"io"
+	"os"
+	"time"
+
+	"app_generics"
 
 	"github.com/erikproper/big-modelling-bus.go.v1/connect"
 	"github.com/erikproper/big-modelling-bus.go.v1/generics"
@@ -35,17 +40,24 @@ const (
 	streamedObservationDeletion = "streamed_observation" // Streamed observation deletion kind
 	coordinationDeletion        = "coordination"         // Coordination deletion kind
 	environmentDeletion         = "environment"          // Environment deletion kind
+
+	appName = "mbus_delete" // Name of the app, as reported by -version
 )
 
+// Version of the app, as reported by -version; can be set at build time using
+// -ldflags "-X main.appVersion=..."
+var appVersion = "18.12.2025"
+
 /*
  * Key variables
  */
 
 var (
-	modellingBusConnector connect.TModellingBusConnector // The Modelling Bus Connector
+	// The flags of the app
+	flags = flag.NewFlagSet(appName, flag.ContinueOnError)
 
 	// Handlers for different deletion kinds
-	deletionHandlers = map[string]func(){
+	deletionHandlers = map[string]TDeletionHandler{
 		rawArtefactDeletion:         handleRawArtefactDeletion,         // Handler for raw artefact deletion
 		jsonArtefactDeletion:        handleJSONArtefactDeletion,        // Handler for JSON artefact deletion
 		rawObservationDeletion:      handleRawObservationDeletion,      // Handler for raw observation deletion
@@ -55,138 +67,276 @@ var (
 		environmentDeletion:         handleEnvironmentDeletion,         // Handler for environment deletion
 	}
 
-	// Explaining the deletion kind flag
-	deletionKindExplain = "Kind of deletion to make. One of: " +
-		rawArtefactDeletion + ", " +
-		jsonArtefactDeletion + ", " +
-		rawObservationDeletion + ", " +
-		jsonObservationDeletion + ", " +
-		streamedObservationDeletion + ", " +
-		coordinationDeletion + ", or " +
-		environmentDeletion + "."
-
-	configFlag            = flag.String("config", defaultIni, "Configuration file")                  // Configuration file flag
-	reportLevelFlag       = flag.Int("reporting", generics.ProgressLevelBasic, "Reporting level")    // Reporting level flag
-	observationIDFlag     = flag.String("observation_id", "", "Observation ID")                      // Observation ID flag
-	coordinationTopicFlag = flag.String("coordination_topic", "", "Coordination topic path")         // Coordination topic path flag
-	deletionKindFlag      = flag.String("kind", "", deletionKindExplain)                             // Deletion kind flag
-	jsonVersionFlag       = flag.String("json_version", "", "JSON version of JSON artefact content") // JSON version flag
-	artefactIDFlag        = flag.String("artefact_id", "", "Artefact ID")                            // Artefact ID flag
-	environmentFlag       = flag.String("environment", "", "Environment")                            // Environment flag
+	configFlag              = flags.String("config", defaultIni, "Configuration file")                                                                                 // Configuration file flag
+	versionFlag             = flags.Bool("version", false, "Report the version of the app, and exit")                                                                  // Version flag
+	configSetFlag           = app_generics.ConfigOverridesFlag(flags, "set", "Configuration value to override, as key=value or section.key=value (repeatable)")        // Configuration override flag
+	reportLevelFlag         = flags.Int("reporting", generics.ProgressLevelBasic, "Reporting level")                                                                   // Reporting level flag
+	quietFlag               = flags.Bool("quiet", false, "Only report errors, suppressing all progress reports (same as -reporting=0)")                                // Quiet flag
+	errorReportLevelFlag    = flags.Int("error_reporting", generics.ProgressLevelBasic, "Error reporting level")                                                       // Error reporting level flag
+	logFileFlag             = flags.String("log_file", "", "Log file to write the reports to, instead of the standard output and standard error")                      // Log file flag
+	logMaxSizeFlag          = flags.Int64("log_max_size", 0, "Size in bytes beyond which the log file is rotated (0 for no rotation)")                                 // Log file rotation size flag
+	logFormatFlag           = flags.String("log_format", app_generics.TextLogFormat, "Format of the reports: text or json")                                            // Log format flag
+	configCheckFlag         = flags.Bool("config_check", false, "Only check that the connector can be created from the configuration, and exit")                       // Configuration check flag
+	observationIDFlag       = flags.String("observation_id", "", "Observation ID")                                                                                     // Observation ID flag
+	coordinationTopicFlag   = flags.String("coordination_topic", "", "Coordination topic path")                                                                        // Coordination topic path flag
+	skipTopicValidationFlag = flags.Bool("skip_topic_validation", false, "Do not validate topic paths before using them")                                              // Skip topic validation flag
+	deletionKindFlag        = flags.String("kind", "", "Kind of deletion to make")                                                                                     // Deletion kind flag
+	jsonVersionFlag         = flags.String("json_version", "", "JSON version of JSON artefact content")                                                                // JSON version flag
+	artefactIDFlag          = flags.String("artefact_id", "", "Artefact ID")                                                                                           // Artefact ID flag
+	environmentFlag         = flags.String("environment", "", "Environment")                                                                                           // Environment flag
+	agentIDFlag             = flags.String("agent_id", "", "Agent ID of the agent owning the postings to delete; defaults to the configured agent")                    // Agent ID flag
+	yesFlag                 = flags.Bool("yes", false, "Confirm all deletions, including those of environments, without prompting")                                    // Yes flag
+	forceFlag               = flags.Bool("force", false, "Confirm all deletions, except those of environments, without prompting")                                     // Force flag
+	noFlag                  = flags.Bool("no", false, "Decline all deletions, without prompting")                                                                      // No flag
+	dryRunFlag              = flags.Bool("dry_run", false, "Only report what would be deleted, without deleting it")                                                   // Dry run flag
+	idsFileFlag             = flags.String("ids_file", "", "File with the IDs, or topics, to delete, one per line; blank lines and lines starting with # are skipped") // IDs file flag
+	olderThanFlag           = flags.String("older_than", "", "Only delete observations posted longer ago than this age, e.g. 7d, 12h, or 30m")                         // Older than flag
+	failFastFlag            = flags.Bool("fail_fast", false, "Stop a batch operation at the first failure, rather than continuing and reporting a summary")            // Fail fast flag
 )
 
+/*
+ * Deletion context
+ */
+
+// The context of a deletion, carrying the connector and the parameters of the deletion,
+// as resolved from the flags by run
+type TDeletionContext struct {
+	Connector *connect.TModellingBusConnector      // The Modelling Bus Connector
+	Bus       app_generics.TModellingBusOperations // The operations on the modelling bus; the connector, unless replaced by a fake
+	Reporter  *generics.TReporter                  // The reporter
+	Output    io.Writer                            // The output, for prompting the user
+	Input     io.Reader                            // The input, for reading the answers of the user
+
+	Kind              string    // Kind of deletion
+	ArtefactID        string    // Artefact ID
+	JSONVersion       string    // JSON version of JSON artefact content
+	ObservationID     string    // Observation ID
+	CoordinationTopic string    // Coordination topic path
+	Environment       string    // Environment
+	AgentID           string    // Agent ID, as requested
+	OwnerAgentID      string    // The agent owning the postings to delete
+	OlderThan         string    // Age beyond which observations are deleted, as requested
+	Cutoff            time.Time // Only observations posted before the cutoff are deleted, when deleting by age
+
+	Interactive         bool   // The input is a terminal, so the user can be prompted
+	No                  bool   // Decline all deletions
+	Yes                 bool   // Confirm all deletions
+	Force               bool   // Confirm all deletions, except those of environments
+	DryRun              bool   // Only preview the deletions
+	SkipTopicValidation bool   // Do not validate topic paths before using them
+	IDsFile             string // File with the IDs to delete as a batch
+	FailFast            bool   // Stop a batch deletion at the first failure
+}
+
+// A handler for a deletion kind
+type TDeletionHandler func(deletion *TDeletionContext) error
+
+/*
+ * Supported kinds
+ */
+
+// Listing the supported deletion kinds, in sorted order
+func SupportedKinds() []string {
+	return app_generics.SortedKinds(deletionHandlers)
+}
+
+// Explaining the deletion kind flag, based on the supported kinds.
+// As the handler map is a package variable, this can only be done once it is initialised.
+func init() {
+	flags.Lookup("kind").Usage = "Kind of deletion to make. One of: " + app_generics.ExplainOptions(SupportedKinds()) + "."
+}
+
+/*
+ * Validating topics
+ */
+
+// Validating a topic path given by the named flag, unless validation is skipped
+func validTopic(deletion *TDeletionContext, flagName, topic string) bool {
+	if deletion.SkipTopicValidation {
+		return true
+	}
+
+	return !deletion.Reporter.MaybeReportError("Error in "+flagName+" flag:", app_generics.ValidateTopic(topic))
+}
+
 /*
  * Handlers for different deletion kinds
  */
 
 // Handler for raw artefact deletion
-func handleRawArtefactDeletion() {
+func handleRawArtefactDeletion(deletion *TDeletionContext) error {
 	// We need an artefact ID for artefact deletions
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact deletion.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.ArtefactID, "No artefact ID specified for artefact deletion.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Create the modelling bus artefact deleter
-	modellingBusArtefactDeleter := connect.CreateModellingBusArtefactConnector(modellingBusConnector, "", *artefactIDFlag)
+	modellingBusArtefactDeleter := connect.CreateModellingBusArtefactConnector(*deletion.Connector, "", deletion.ArtefactID)
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, rawArtefactDeletion, deletion.ArtefactID); !proceed {
+		return err
+	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Raw artefact deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Raw artefact deletion.")
 
 	// Deleting the raw artefact
-	modellingBusArtefactDeleter.DeleteRawArtefact(*artefactIDFlag)
+	modellingBusArtefactDeleter.DeleteRawArtefact(deletion.ArtefactID)
+
+	return nil
 }
 
 // Handler for JSON artefact deletion
-func handleJSONArtefactDeletion() {
-	// We need a JSON version for JSON artefact deletions
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(jsonVersionFlag, "No JSON version specified for JSON artefact deletion.") {
-		return
-	}
-
-	// We also need an artefact ID for artefact deletions
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact deletion.") {
-		return
+func handleJSONArtefactDeletion(deletion *TDeletionContext) error {
+	// We need the flags required for JSON artefact deletion
+	if !app_generics.RequireFlags(deletion.Reporter, "JSON artefact deletion",
+		app_generics.TRequiredFlag{Value: &deletion.JSONVersion, Name: "json_version"},
+		app_generics.TRequiredFlag{Value: &deletion.ArtefactID, Name: "artefact_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Create the modelling bus artefact deleter
-	modellingBusArtefactDeleter := connect.CreateModellingBusArtefactConnector(modellingBusConnector, *jsonVersionFlag, *artefactIDFlag)
+	modellingBusArtefactDeleter := connect.CreateModellingBusArtefactConnector(*deletion.Connector, deletion.JSONVersion, deletion.ArtefactID)
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, jsonArtefactDeletion, deletion.ArtefactID); !proceed {
+		return err
+	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "JSON artefact deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "JSON artefact deletion.")
 
 	// Deleting the JSON artefact
-	modellingBusArtefactDeleter.DeleteJSONArtefact(*artefactIDFlag)
+	modellingBusArtefactDeleter.DeleteJSONArtefact(deletion.ArtefactID)
+
+	return nil
 }
 
 // Handler for raw observation deletion
-func handleRawObservationDeletion() {
+func handleRawObservationDeletion(deletion *TDeletionContext) error {
 	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.ObservationID, "No observation ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Only deleting observations that are old enough, if so requested
+	if oldEnough, err := oldEnoughForDeletion(deletion, rawObservationDeletion, deletion.ObservationID); !oldEnough {
+		return err
+	}
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, rawObservationDeletion, deletion.ObservationID); !proceed {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation deletion.")
 
 	// Posting the raw observation
-	modellingBusConnector.DeleteRawObservation(*observationIDFlag)
+	deletion.Bus.DeleteRawObservation(deletion.ObservationID)
+
+	return nil
 }
 
 // Handler for JSON observation deletion
-func handleJSONObservationDeletion() {
+func handleJSONObservationDeletion(deletion *TDeletionContext) error {
 	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.ObservationID, "No observation ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Only deleting observations that are old enough, if so requested
+	if oldEnough, err := oldEnoughForDeletion(deletion, jsonObservationDeletion, deletion.ObservationID); !oldEnough {
+		return err
+	}
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, jsonObservationDeletion, deletion.ObservationID); !proceed {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation deletion.")
 
 	// Deleting the JSON observation
-	modellingBusConnector.DeleteJSONObservation(*observationIDFlag)
+	deletion.Bus.DeleteJSONObservation(deletion.ObservationID)
+
+	return nil
 }
 
 // Handler for streamed observation deletion
-func handleStreamedObservationDeletion() {
+func handleStreamedObservationDeletion(deletion *TDeletionContext) error {
 	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.ObservationID, "No observation ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Only deleting observations that are old enough, if so requested
+	if oldEnough, err := oldEnoughForDeletion(deletion, streamedObservationDeletion, deletion.ObservationID); !oldEnough {
+		return err
+	}
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, streamedObservationDeletion, deletion.ObservationID); !proceed {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation deletion.")
 
 	// Deleting the streamed observation
-	modellingBusConnector.DeleteStreamedObservation(*observationIDFlag)
+	deletion.Bus.DeleteStreamedObservation(deletion.ObservationID)
+
+	return nil
 }
 
 // Handler for coordination deletion
-func handleCoordinationDeletion() {
+func handleCoordinationDeletion(deletion *TDeletionContext) error {
 	// We must have a coordination topic
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(coordinationTopicFlag, "No coordination topic specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.CoordinationTopic, "No coordination topic specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating the coordination topic
+	if !validTopic(deletion, "coordination_topic", deletion.CoordinationTopic) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, coordinationDeletion, deletion.CoordinationTopic); !proceed {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Coordination deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Coordination deletion.")
 
 	// Deleting the coordination
-	modellingBusConnector.DeleteCoordination(*coordinationTopicFlag)
+	deletion.Bus.DeleteCoordination(deletion.CoordinationTopic)
 
+	return nil
 }
 
 // Handler for environment deletion
-func handleEnvironmentDeletion() {
+func handleEnvironmentDeletion(deletion *TDeletionContext) error {
 	// We must have an environment flag
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(environmentFlag, "No environment specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.Environment, "No environment specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Confirming the deletion, unless only previewing it
+	if proceed, err := proceedWithDeletion(deletion, environmentDeletion, deletion.Environment); !proceed {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Environment deletion.")
+	deletion.Reporter.Progress(generics.ProgressLevelBasic, "Environment deletion.")
 
 	// Deleting the environment
-	modellingBusConnector.DeleteEnvironment(*environmentFlag)
+	deletion.Bus.DeleteEnvironment(deletion.Environment)
+
+	return nil
 }
 
 /*
@@ -194,33 +344,145 @@ func handleEnvironmentDeletion() {
  */
 
 func main() {
+	os.Exit(app_generics.ExitCode(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)))
+}
+
+// Running the app with the given arguments, input, and outputs, returning an exit error when it fails
+func run(arguments []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
 	// Parsing flags
-	flag.Parse()
+	flags.SetOutput(stderr)
+	parsed, err := app_generics.ParseFlags(flags, arguments)
+	if !parsed {
+		return err
+	}
+
+	// Failing with an error code if errors were reported, once all else is done
+	errorCount := app_generics.ReportedErrorCount()
+	defer func() {
+		if err == nil {
+			err = app_generics.ExitErrorSince(errorCount)
+		}
+	}()
+
+	// Only reporting the version, if requested
+	if *versionFlag {
+		app_generics.WriteVersion(stdout, appName, appVersion)
+
+		return nil
+	}
 
-	// Creating the reporter
-	reporter := generics.CreateReporter(*reportLevelFlag, generics.ReportError, generics.ReportProgress)
+	// Directing the progress reports to the output, and the errors to the error output
+	app_generics.SetReportOutput(stdout, stderr)
+
+	// Creating the reporter, where only errors are reported when quiet
+	progressLevel := *reportLevelFlag
+	if *quietFlag {
+		progressLevel = app_generics.ProgressLevelSilent
+	}
+	reporter := app_generics.CreateReporter(progressLevel, *errorReportLevelFlag)
+
+	// Formatting the reports as requested
+	if reporter.MaybeReportError("Error in log format flag:", app_generics.SetLogFormat(*logFormatFlag)) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Directing the reports to a log file, if requested
+	if *logFileFlag != "" {
+		if reporter.MaybeReportError("Error opening log file:", app_generics.SetLogFile(*logFileFlag, *logMaxSizeFlag)) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+	}
+
+	// Deleting on behalf of the requested agent, if any, as the connector scopes
+	// its deletions to the configured agent
+	configOverrides := []app_generics.TConfigOverride{}
+	if *agentIDFlag != "" {
+		configOverrides = append(configOverrides, app_generics.TConfigOverride{Key: "agent", Value: *agentIDFlag})
+	}
+
+	// Overriding the configuration values given on the command line, where the
+	// dedicated flags above take precedence
+	configOverrides = append(append([]app_generics.TConfigOverride{}, *configSetFlag...), configOverrides...)
+
+	// Only checking the configuration, if requested
+	if *configCheckFlag {
+		return app_generics.CheckConfig(*configFlag, configOverrides, !connect.PostingOnly, reporter)
+	}
 
 	// Loading the configuration
-	configData := generics.LoadConfig(*configFlag, reporter)
+	configData, err := app_generics.LoadConfig(*configFlag, configOverrides, reporter)
+	if err != nil {
+		return err
+	}
 
 	// Creating the Modelling Bus Connector
-	modellingBusConnector = connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
+	modellingBusConnector := connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
+
+	// Creating the deletion context from the flags, where the agent owning the postings
+	// to delete is the configured one
+	deletion := &TDeletionContext{
+		Connector: &modellingBusConnector,
+		Bus:       &modellingBusConnector,
+		Reporter:  modellingBusConnector.Reporter,
+		Output:    stdout,
+		Input:     stdin,
+
+		Kind:              *deletionKindFlag,
+		ArtefactID:        *artefactIDFlag,
+		JSONVersion:       *jsonVersionFlag,
+		ObservationID:     *observationIDFlag,
+		CoordinationTopic: *coordinationTopicFlag,
+		Environment:       *environmentFlag,
+		AgentID:           *agentIDFlag,
+		OwnerAgentID:      configData.GetValue("", "agent").String(),
+		OlderThan:         *olderThanFlag,
+
+		Interactive:         inputIsTerminal(stdin),
+		No:                  *noFlag,
+		Yes:                 *yesFlag,
+		Force:               *forceFlag,
+		DryRun:              *dryRunFlag,
+		SkipTopicValidation: *skipTopicValidationFlag,
+		IDsFile:             *idsFileFlag,
+		FailFast:            *failFastFlag,
+	}
 
 	// We must have a deletion kind
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(deletionKindFlag, "No deletion kind specified.") {
-		return
+	if deletion.Reporter.MaybeReportEmptyFlagError(&deletion.Kind, "No deletion kind specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the deletion handler
-	deletionHandler := deletionHandlers[*deletionKindFlag]
+	deletionHandler := deletionHandlers[deletion.Kind]
 
 	// Validating deletion handler
 	if deletionHandler == nil {
-		modellingBusConnector.Reporter.Error("Unknown deletion kind specified: %s.", *deletionKindFlag)
+		deletion.Reporter.Error("Unknown deletion kind specified: %s.", deletion.Kind)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Determining the cutoff, when deleting by age, which is only possible for observations
+	if deletion.OlderThan != "" {
+		if deletion.Kind != rawObservationDeletion && deletion.Kind != jsonObservationDeletion && deletion.Kind != streamedObservationDeletion {
+			deletion.Reporter.Error("Deleting by age is only supported for observations.")
+
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		age, err := app_generics.ParseAge(deletion.OlderThan)
+		if deletion.Reporter.MaybeReportError("Error in older_than flag:", err) {
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		deletion.Cutoff = time.Now().Add(-age)
+	}
 
-		return
+	// Deleting a batch, if requested
+	if deletion.IDsFile != "" {
+		return handleBatchDeletion(deletion, deletionHandler)
 	}
 
 	// Calling the deletion handler
-	deletionHandler()
+	return deletionHandler(deletion)
 }
@@ -0,0 +1,304 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic deleter for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Each deletion kind is dispatched to its handler, which deletes the posting once the deletion
+// is confirmed by the flags or the answer of the user, and fails with a usage error when it
+// cannot be confirmed or a required parameter is missing
+func TestDeletionDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		deletion TDeletionContext
+		input    string
+		deleted  bool
+		exitCode int
+	}{
+		{
+			name:     "confirmed by -yes",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", Yes: true},
+			deleted:  true,
+		},
+		{
+			name:     "confirmed by -force",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", Force: true},
+			deleted:  true,
+		},
+		{
+			name:     "declined by -no",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", No: true, Yes: true},
+		},
+		{
+			name:     "previewed in a dry run",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", DryRun: true, Yes: true},
+		},
+		{
+			name:     "confirmed by the user",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", Interactive: true},
+			input:    "yes\n",
+			deleted:  true,
+		},
+		{
+			name:     "declined by the user",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature", Interactive: true},
+			input:    "\n",
+		},
+		{
+			name:     "no confirmation without a terminal",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature"},
+			input:    "yes\n",
+			exitCode: app_generics.ExitUsageError,
+		},
+		{
+			name:     "no observation ID",
+			deletion: TDeletionContext{Kind: jsonObservationDeletion, Yes: true},
+			exitCode: app_generics.ExitUsageError,
+		},
+		{
+			name:     "invalid coordination topic",
+			deletion: TDeletionContext{Kind: coordinationDeletion, CoordinationTopic: "render/+", Yes: true},
+			exitCode: app_generics.ExitUsageError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := app_generics.CreateFakeModellingBus("agent", t.TempDir())
+			bus.PostJSONObservation("sensors/temperature", []byte(`{"celsius":21}`))
+
+			deletion := test.deletion
+			deletion.Bus = bus
+			deletion.Reporter = generics.CreateReporter(app_generics.ProgressLevelSilent, func(string) {}, func(string) {})
+			deletion.Output = io.Discard
+			deletion.Input = strings.NewReader(test.input)
+
+			err := deletionHandlers[deletion.Kind](&deletion)
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v)", exitCode, test.exitCode, err)
+			}
+
+			_, present := bus.Posting(app_generics.FakeJSONObservation, "agent", "sensors/temperature")
+			if present == test.deleted {
+				t.Errorf("observation present = %t, want %t", present, !test.deleted)
+			}
+		})
+	}
+}
+
+// A fake modelling bus counting the deletions made on it
+type TCountingBus struct {
+	*app_generics.TFakeModellingBus
+
+	deletions int // The number of deletions
+}
+
+func (c *TCountingBus) DeleteRawObservation(observationID string)      { c.deletions++ }
+func (c *TCountingBus) DeleteJSONObservation(observationID string)     { c.deletions++ }
+func (c *TCountingBus) DeleteStreamedObservation(observationID string) { c.deletions++ }
+func (c *TCountingBus) DeleteCoordination(coordinationID string)       { c.deletions++ }
+func (c *TCountingBus) DeleteEnvironment(environment ...string)        { c.deletions++ }
+
+// In a dry run, each deletion kind previews its deletion, without deleting anything, even when confirmed
+func TestDryRun(t *testing.T) {
+	tests := []struct {
+		deletion TDeletionContext
+		want     string
+	}{
+		{TDeletionContext{Kind: rawObservationDeletion, ObservationID: "sensors/image"}, "Would delete raw_observation: sensors/image"},
+		{TDeletionContext{Kind: jsonObservationDeletion, ObservationID: "sensors/temperature"}, "Would delete json_observation: sensors/temperature"},
+		{TDeletionContext{Kind: streamedObservationDeletion, ObservationID: "sensors/humidity"}, "Would delete streamed_observation: sensors/humidity"},
+		{TDeletionContext{Kind: coordinationDeletion, CoordinationTopic: "render/request"}, "Would delete coordination: render/request"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.deletion.Kind, func(t *testing.T) {
+			progress := []string{}
+			bus := &TCountingBus{TFakeModellingBus: app_generics.CreateFakeModellingBus("agent", t.TempDir())}
+
+			deletion := test.deletion
+			deletion.Bus = bus
+			deletion.Reporter = generics.CreateReporter(generics.ProgressLevelBasic,
+				func(message string) { t.Errorf("reported error: %s", message) },
+				func(message string) { progress = append(progress, message) })
+			deletion.DryRun = true
+			deletion.Yes = true
+
+			if err := deletionHandlers[deletion.Kind](&deletion); err != nil {
+				t.Fatalf("dry run failed: %v", err)
+			}
+
+			if bus.deletions != 0 {
+				t.Errorf("made %d deletion(s) in a dry run", bus.deletions)
+			}
+			if !slices.ContainsFunc(progress, func(message string) bool { return strings.Contains(message, test.want) }) {
+				t.Errorf("progress %q does not preview %q", progress, test.want)
+			}
+		})
+	}
+}
+
+// Deleting an environment is only confirmed by -yes, or by the user, whereas -force suffices for
+// other kinds; the user is prompted with the kind and ID of the posting to delete
+func TestConfirmDeletion(t *testing.T) {
+	tests := []struct {
+		name      string
+		deletion  TDeletionContext
+		kind      string
+		input     string
+		confirmed bool
+		prompted  bool
+		exitCode  int
+	}{
+		{
+			name:      "observation confirmed by -force",
+			deletion:  TDeletionContext{Force: true},
+			kind:      jsonObservationDeletion,
+			confirmed: true,
+		},
+		{
+			name:     "environment not confirmed by -force",
+			deletion: TDeletionContext{Force: true},
+			kind:     environmentDeletion,
+			exitCode: app_generics.ExitUsageError,
+		},
+		{
+			name:      "environment confirmed by -yes",
+			deletion:  TDeletionContext{Yes: true},
+			kind:      environmentDeletion,
+			confirmed: true,
+		},
+		{
+			name:      "environment confirmed by the user despite -force",
+			deletion:  TDeletionContext{Force: true, Interactive: true},
+			kind:      environmentDeletion,
+			input:     "y\n",
+			confirmed: true,
+			prompted:  true,
+		},
+		{
+			name:     "environment declined by the user",
+			deletion: TDeletionContext{Interactive: true},
+			kind:     environmentDeletion,
+			input:    "n\n",
+			prompted: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output strings.Builder
+			deletion := test.deletion
+			deletion.Reporter = generics.CreateReporter(app_generics.ProgressLevelSilent, func(string) {}, func(string) {})
+			deletion.Output = &output
+			deletion.Input = strings.NewReader(test.input)
+
+			confirmed, err := confirmDeletion(&deletion, test.kind, "university")
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v)", exitCode, test.exitCode, err)
+			}
+			if confirmed != test.confirmed {
+				t.Errorf("confirmed = %t, want %t", confirmed, test.confirmed)
+			}
+
+			prompt := "Delete " + test.kind + " university? [y/N] "
+			if prompted := output.String() == prompt; prompted != test.prompted {
+				t.Errorf("output = %q, want prompt %t", output.String(), test.prompted)
+			}
+		})
+	}
+}
+
+// Input that is not a terminal, such as a pipe or a file, cannot be prompted
+func TestInputIsTerminal(t *testing.T) {
+	if inputIsTerminal(strings.NewReader("yes\n")) {
+		t.Error("a string reader is taken to be a terminal")
+	}
+
+	file, err := os.CreateTemp(t.TempDir(), "input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if inputIsTerminal(file) {
+		t.Error("a file is taken to be a terminal")
+	}
+}
+
+// Running the app with command line arguments, capturing its outputs, exits with the exit code
+// of the outcome, without needing a modelling bus when failing before connecting to it
+func TestRunCommandLine(t *testing.T) {
+	missingConfig := filepath.Join(t.TempDir(), "missing.ini")
+
+	tests := []struct {
+		name      string
+		arguments []string
+		exitCode  int
+		stdout    string
+		stderr    string
+	}{
+		{"version", []string{"-version"}, 0, "mbus_delete, version of " + appVersion, ""},
+		{"help", []string{"-h"}, 0, "", "-config string"},
+		{"unknown flag", []string{"-colour"}, app_generics.ExitUsageError, "", "flag provided but not defined: -colour"},
+		{"missing configuration", []string{"-config", missingConfig, "-kind", jsonObservationDeletion}, app_generics.ExitConfigError, "", "Error loading configuration file:"},
+	}
+
+	t.Cleanup(func() { app_generics.SetReportOutput(os.Stdout, os.Stderr) })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(test.arguments, strings.NewReader(""), &stdout, &stderr)
+
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v, output: %s%s)", exitCode, test.exitCode, err, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("output does not contain %q:\n%s", test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("error output does not contain %q:\n%s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+// The supported kinds are the kinds of the handler map, in sorted order, each explained by the kind flag
+func TestSupportedKinds(t *testing.T) {
+	kinds := SupportedKinds()
+	if !slices.IsSorted(kinds) || len(kinds) != len(deletionHandlers) {
+		t.Errorf("SupportedKinds() = %q, want the sorted kinds of the deletion handlers", kinds)
+	}
+
+	usage := flags.Lookup("kind").Usage
+	for _, kind := range kinds {
+		if _, handled := deletionHandlers[kind]; !handled {
+			t.Errorf("supported kind %q has no deletion handler", kind)
+		}
+		if !strings.Contains(usage, kind) {
+			t.Errorf("kind flag usage %q does not explain %q", usage, kind)
+		}
+	}
+}
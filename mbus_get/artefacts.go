@@ -0,0 +1,144 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic get application for the Modelling Bus, Version 1
+ *
+ * This part of the application defines the retrieval of the state, update, and considered
+ * versions of JSON artefacts, as offered by the artefact connector, so the JSON artefact
+ * handler can also be exercised with a fake retriever.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/connect"
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining JSON artefact versions
+ */
+
+// A version of a JSON artefact
+type TJSONArtefactVersion struct {
+	Content   []byte // Content of the version
+	Timestamp string // Timestamp of the version
+}
+
+/*
+ * Defining JSON artefact retrievers
+ */
+
+// The retrieval of the versions of a JSON artefact, as offered by TConnectorJSONArtefactRetriever,
+// unless replaced by a fake
+type TJSONArtefactRetriever interface {
+	ListenForJSONArtefactStatePostings(agentID, artefactID string, handler func())
+	ListenForJSONArtefactUpdatePostings(agentID, artefactID string, handler func())
+	ListenForJSONArtefactConsideringPostings(agentID, artefactID string, handler func())
+
+	GetJSONArtefactConsidering(agentID, artefactID string) // Getting all versions, as the considered version is based on the others
+
+	State() TJSONArtefactVersion      // The state version, as last received
+	Update() TJSONArtefactVersion     // The update version, as last received
+	Considered() TJSONArtefactVersion // The considered version, as last received
+}
+
+// Creating a retriever for the JSON artefact with the given JSON version and artefact ID
+type TJSONArtefactRetrieverCreator func(JSONVersion, artefactID string) TJSONArtefactRetriever
+
+// A JSON artefact retriever based on an artefact connector. The artefact connector only keeps the
+// timestamp of the state, so the update and considered versions are given the time they were
+// received, unless they are the same as the version they are based on.
+type TConnectorJSONArtefactRetriever struct {
+	artefactConnector connect.TModellingBusArtefactConnector // The artefact connector
+
+	updateTimestamp     string // Timestamp of the update version
+	consideredTimestamp string // Timestamp of the considered version
+}
+
+// Creating a creator of connector based JSON artefact retrievers
+func ConnectorJSONArtefactRetrievers(connector connect.TModellingBusConnector) TJSONArtefactRetrieverCreator {
+	return func(JSONVersion, artefactID string) TJSONArtefactRetriever {
+		return &TConnectorJSONArtefactRetriever{
+			artefactConnector: connect.CreateModellingBusArtefactConnector(connector, JSONVersion, artefactID),
+		}
+	}
+}
+
+// Stamping the versions after the state was received, where the update and considered
+// versions are reset to the state by the artefact connector
+func (r *TConnectorJSONArtefactRetriever) stateReceived() {
+	r.updateTimestamp = r.artefactConnector.CurrentTimestamp
+	r.consideredTimestamp = r.artefactConnector.CurrentTimestamp
+}
+
+// Stamping the versions after an update was received, where the considered version is
+// reset to the update by the artefact connector
+func (r *TConnectorJSONArtefactRetriever) updateReceived() {
+	r.updateTimestamp = generics.GetTimestamp()
+	r.consideredTimestamp = r.updateTimestamp
+}
+
+// Stamping the considered version after it was received
+func (r *TConnectorJSONArtefactRetriever) consideredReceived() {
+	r.consideredTimestamp = generics.GetTimestamp()
+}
+
+func (r *TConnectorJSONArtefactRetriever) ListenForJSONArtefactStatePostings(agentID, artefactID string, handler func()) {
+	r.artefactConnector.ListenForJSONArtefactStatePostings(agentID, artefactID, func() {
+		r.stateReceived()
+		handler()
+	})
+}
+
+func (r *TConnectorJSONArtefactRetriever) ListenForJSONArtefactUpdatePostings(agentID, artefactID string, handler func()) {
+	r.artefactConnector.ListenForJSONArtefactUpdatePostings(agentID, artefactID, func() {
+		r.updateReceived()
+		handler()
+	})
+}
+
+func (r *TConnectorJSONArtefactRetriever) ListenForJSONArtefactConsideringPostings(agentID, artefactID string, handler func()) {
+	r.artefactConnector.ListenForJSONArtefactConsideringPostings(agentID, artefactID, func() {
+		r.consideredReceived()
+		handler()
+	})
+}
+
+// Stamping the versions after they were retrieved, where an update or considered version
+// that could not be applied (or was not retrieved) is left the same as the version it applies to
+func (r *TConnectorJSONArtefactRetriever) versionsRetrieved() {
+	r.stateReceived()
+	if !bytes.Equal(r.artefactConnector.UpdatedContent, r.artefactConnector.CurrentContent) {
+		r.updateReceived()
+	}
+	if !bytes.Equal(r.artefactConnector.ConsideredContent, r.artefactConnector.UpdatedContent) {
+		r.consideredReceived()
+	}
+}
+
+// Getting the considered version, where the artefact connector also gets the state and the update
+func (r *TConnectorJSONArtefactRetriever) GetJSONArtefactConsidering(agentID, artefactID string) {
+	r.artefactConnector.GetJSONArtefactConsidering(agentID, artefactID)
+	r.versionsRetrieved()
+}
+
+func (r *TConnectorJSONArtefactRetriever) State() TJSONArtefactVersion {
+	return TJSONArtefactVersion{Content: r.artefactConnector.CurrentContent, Timestamp: r.artefactConnector.CurrentTimestamp}
+}
+
+func (r *TConnectorJSONArtefactRetriever) Update() TJSONArtefactVersion {
+	return TJSONArtefactVersion{Content: r.artefactConnector.UpdatedContent, Timestamp: r.updateTimestamp}
+}
+
+func (r *TConnectorJSONArtefactRetriever) Considered() TJSONArtefactVersion {
+	return TJSONArtefactVersion{Content: r.artefactConnector.ConsideredContent, Timestamp: r.consideredTimestamp}
+}
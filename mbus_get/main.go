@@ -15,11 +15,24 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	"app_generics"
+
 	"github.com/erikproper/big-modelling-bus.go.v1/connect"
 	"github.com/erikproper/big-modelling-bus.go.v1/generics"
 )
@@ -37,104 +50,513 @@ const (
 	jsonObservationRetrieval     = "json_observation"     // JSON observation retrieval kind
 	streamedObservationRetrieval = "streamed_observation" // Streamed observation retrieval kind
 	coordinationRetrieval        = "coordination"         // Coordination retrieval kind
+	listRetrieval                = "list"                 // Listing of the available artefacts
+	coordinationListRetrieval    = "coordination_list"    // Listing of the available coordination topics
+
+	waitModeAll         = ""            // Wait mode for any posting, saving all aspects
+	waitModeState       = "state"       // Wait mode for a state posting
+	waitModeUpdate      = "update"      // Wait mode for an update posting
+	waitModeConsidering = "considering" // Wait mode for a considering posting
+	waitModeFirst       = "first"       // Wait mode for whichever posting arrives first
+	waitModeLatest      = "latest"      // Wait mode for the newest posting, by timestamp
+
+	coordinationWildcard = "*" // Suffix of coordination topics, retrieving all coordinations under the preceding prefix
 
+	jsonExtension      = ".json"
 	timestampExtension = ".timestamp"
+	checksumExtension  = ".sha256"
+
+	rawArtefactsTopicPathElement = "artefacts/raw" // Topic path element of raw artefacts, as used by the Modelling Bus Connector
+
+	appName = "mbus_get" // Name of the app, as reported by -version
 )
 
+// Version of the app, as reported by -version; can be set at build time using
+// -ldflags "-X main.appVersion=..."
+var appVersion = "19.12.2025"
+
 /*
  * Key variables
  */
 
 var (
-	modellingBusConnector connect.TModellingBusConnector // The Modelling Bus Connector
-
-	localFilePath string // The local file path to store retrieved artefact
+	flags = flag.NewFlagSet(appName, flag.ContinueOnError) // The flags of the app
+
+	savingLock sync.Mutex // Held while saving a retrieved posting
+
+	// The known wait modes
+	waitModes = map[string]bool{
+		waitModeAll:         true,
+		waitModeState:       true,
+		waitModeUpdate:      true,
+		waitModeConsidering: true,
+		waitModeFirst:       true,
+		waitModeLatest:      true,
+	}
 
 	// Handlers for different retrieval kinds
-	retrievalHandlers = map[string]func(){
+	retrievalHandlers = map[string]TRetrievalHandler{
 		rawArtefactRetrieval:         handleRawArtefactRetrieval,         // Handler for raw artefact retrieval
 		jsonArtefactRetrieval:        handleJSONArtefactRetrieval,        // Handler for JSON artefact retrieval
 		rawObservationRetrieval:      handleRawObservationRetrieval,      // Handler for raw observation retrieval
 		jsonObservationRetrieval:     handleJSONObservationRetrieval,     // Handler for JSON observation retrieval
 		streamedObservationRetrieval: handleStreamedObservationRetrieval, // Handler for streamed observation retrieval
 		coordinationRetrieval:        handleCoordinationRetrieval,        // Handler for coordination retrieval
+		listRetrieval:                handleListRetrieval,                // Handler for listing the available artefacts
+		coordinationListRetrieval:    handleCoordinationListRetrieval,    // Handler for listing the available coordination topics
 	}
 
-	// Explaining the retrieval kind flag
-	retrievalKindExplain = "Kind of retrieval to conduct. One of: " +
-		rawArtefactRetrieval + ", " +
-		jsonArtefactRetrieval + ", " +
-		rawObservationRetrieval + ", " +
-		jsonObservationRetrieval + ", " +
-		streamedObservationRetrieval + ", or " +
-		coordinationRetrieval + "."
-
-	configFlag            = flag.String("config", defaultIni, "Configuration file")                  // Configuration file flag
-	reportLevelFlag       = flag.Int("reporting", generics.ProgressLevelBasic, "Reporting level")    // Reporting level flag
-	agentIDFlag           = flag.String("agent_id", "", "Agent ID")                                  // Agent ID flag
-	fileNameFlag          = flag.String("file_name", "", "Local file name to store retrieved files") // Local file name flag
-	observationIDFlag     = flag.String("observation_id", "", "Observation ID")                      // Observation ID flag
-	coordinationTopicFlag = flag.String("coordination_topic", "", "Coordination topic path")         // Coordination topic path flag
-	retrievalKindFlag     = flag.String("kind", "", retrievalKindExplain)                            // Retrieval kind flag
-	jsonVersionFlag       = flag.String("json_version", "", "JSON version of JSON artefact content") // JSON version flag
-	artefactIDFlag        = flag.String("artefact_id", "", "Artefact ID")                            // Artefact ID flag
-	waitFlag              = flag.Bool("wait", false, "wait for a posting")                           // Wait flag
-	waitModeFlag          = flag.String("wait_mode", "", "wait mode when waiting for a posting")     // Wait mode flag
+	configFlag              = flags.String("config", defaultIni, "Configuration file")                                                                                                                                                          // Configuration file flag
+	versionFlag             = flags.Bool("version", false, "Report the version of the app, and exit")                                                                                                                                           // Version flag
+	configSetFlag           = app_generics.ConfigOverridesFlag(flags, "set", "Configuration value to override, as key=value or section.key=value (repeatable)")                                                                                 // Configuration override flag
+	reportLevelFlag         = flags.Int("reporting", generics.ProgressLevelBasic, "Reporting level")                                                                                                                                            // Reporting level flag
+	quietFlag               = flags.Bool("quiet", false, "Only report errors, suppressing all progress reports (same as -reporting=0)")                                                                                                         // Quiet flag
+	errorReportLevelFlag    = flags.Int("error_reporting", generics.ProgressLevelBasic, "Error reporting level")                                                                                                                                // Error reporting level flag
+	logFileFlag             = flags.String("log_file", "", "Log file to write the reports to, instead of the standard output and standard error")                                                                                               // Log file flag
+	logMaxSizeFlag          = flags.Int64("log_max_size", 0, "Size in bytes beyond which the log file is rotated (0 for no rotation)")                                                                                                          // Log file rotation size flag
+	logFormatFlag           = flags.String("log_format", app_generics.TextLogFormat, "Format of the reports: text or json")                                                                                                                     // Log format flag
+	configCheckFlag         = flags.Bool("config_check", false, "Only check that the connector can be created from the configuration, and exit")                                                                                                // Configuration check flag
+	createWorkFolderFlag    = flags.Bool("create_work_folder", false, "Create the work folder, if it does not exist yet")                                                                                                                       // Create work folder flag
+	agentIDFlag             = flags.String("agent_id", "", "Agent ID")                                                                                                                                                                          // Agent ID flag
+	fileNameFlag            = flags.String("file_name", "", "Local file name to store retrieved files")                                                                                                                                         // Local file name flag
+	observationIDFlag       = flags.String("observation_id", "", "Observation ID")                                                                                                                                                              // Observation ID flag
+	coordinationTopicFlag   = flags.String("coordination_topic", "", "Coordination topic path; a trailing "+coordinationWildcard+" retrieves all coordinations under the preceding prefix, e.g. project/alpha/"+coordinationWildcard)           // Coordination topic path flag
+	skipTopicValidationFlag = flags.Bool("skip_topic_validation", false, "Do not validate topic paths before using them")                                                                                                                       // Skip topic validation flag
+	retrievalKindFlag       = flags.String("kind", "", "Kind of retrieval to conduct")                                                                                                                                                          // Retrieval kind flag
+	jsonVersionFlag         = flags.String("json_version", "", "JSON version of JSON artefact content")                                                                                                                                         // JSON version flag
+	artefactIDFlag          = flags.String("artefact_id", "", "Artefact ID, or a comma-separated list of artefact IDs")                                                                                                                         // Artefact ID flag
+	environmentFlag         = flags.String("environment", "", "Environment to scope the operation to")                                                                                                                                          // Environment flag
+	waitFlag                = flags.Bool("wait", false, "wait for a posting")                                                                                                                                                                   // Wait flag
+	waitModeFlag            = flags.String("wait_mode", waitModeAll, "wait mode when waiting for a posting. One of: "+waitModeState+", "+waitModeUpdate+", "+waitModeConsidering+", "+waitModeFirst+", "+waitModeLatest+", or empty for all")   // Wait mode flag
+	waitTimeoutFlag         = flags.Duration("wait_timeout", 0, "Maximum time to wait for a posting, e.g. 30s (0 for no limit)")                                                                                                                // Wait timeout flag
+	watchFlag               = flags.Bool("watch", false, "Keep waiting for postings, storing each one, until shut down (implies -wait)")                                                                                                        // Watch flag
+	onChangeFlag            = flags.String("on_change", "", "Shell command to run for each stored file, where "+app_generics.ChangedFilePlaceholder+" is replaced by its path, and its timestamp is passed in "+app_generics.TimestampVariable) // On change flag
+	debounceFlag            = flags.Duration("debounce", time.Second, "Time a stored file must be left unchanged before running the -on_change command for it")                                                                                 // Debounce flag
+	skipEmptyFlag           = flags.Bool("skip_empty", false, "When waiting, skip empty postings and wait for a non-empty one")                                                                                                                 // Skip empty postings flag
+	tempFlag                = flags.Bool("temp", false, "Store in a temporary file, and only print its path")                                                                                                                                   // Temporary file flag
+	maxBytesFlag            = flags.Int64("max_bytes", 0, "Maximum number of bytes to keep of a raw retrieval (0 for no limit)")                                                                                                                // Maximum bytes flag
+	progressIntervalFlag    = flags.Duration("progress_interval", app_generics.DefaultProgressInterval, "Interval between progress reports of large transfers, at the verbose reporting level (0 for none)")                                    // Progress interval flag
+	expectedSHA256Flag      = flags.String("expected_sha256", "", "Expected SHA-256 digest of a raw retrieval")                                                                                                                                 // Expected checksum flag
+	strictFlag              = flags.Bool("strict", false, "Delete a raw retrieval when its checksum does not match")                                                                                                                            // Strict checksum flag
+	timestampFormatFlag     = flags.String("timestamp_format", app_generics.TimestampRaw, "Format of timestamp files. One of: "+app_generics.TimestampRaw+", "+app_generics.TimestampRFC3339+", or "+app_generics.TimestampUnix+".")            // Timestamp format flag
+	retriesFlag             = flags.Int("retries", 0, "Number of times to retry on transient errors, such as I/O errors, timeouts, and failing bus operations")                                                                                 // Retries flag
+	failFastFlag            = flags.Bool("fail_fast", false, "Stop a batch operation at the first failure, rather than continuing and reporting a summary")                                                                                     // Fail fast flag
+	atFlag                  = flags.String("at", "", "Retrieve the state of a JSON artefact as it was at this time, e.g. 2025-12-16T10:00:00Z")                                                                                                 // At flag
+	prettyFlag              = flags.Bool("pretty", false, "Indent retrieved JSON content by two spaces")                                                                                                                                        // Pretty flag
+	gzipFlag                = flags.Bool("gzip", false, "Compress stored files with gzip, adding the "+app_generics.GzipExtension+" extension; timestamp files are not compressed")                                                             // Gzip flag
+	retryBackoffFlag        = flags.Duration("retry_backoff", time.Second, "Backoff before the first retry, doubling with each further retry")                                                                                                  // Retry backoff flag
 )
 
+/*
+ * Retrieval context
+ */
+
+// The context of a retrieval, carrying the connector and the parameters of the retrieval,
+// as resolved from the flags by run
+type TRetrievalContext struct {
+	Connector *connect.TModellingBusConnector      // The Modelling Bus Connector
+	Bus       app_generics.TModellingBusOperations // The operations on the modelling bus; the connector, unless replaced by a fake
+	Reporter  *generics.TReporter                  // The reporter
+	Output    io.Writer                            // The output, for printing paths and listings
+	Shutdown  context.Context                      // Cancelled when the app is asked to shut down
+
+	JSONArtefactRetriever TJSONArtefactRetrieverCreator // Creating JSON artefact retrievers; based on the connector, unless replaced by a fake
+
+	Kind              string // Kind of retrieval
+	WorkFolder        string // The local folder to store retrieved postings in
+	FileName          string // Local file name to store retrieved postings
+	AgentID           string // Agent ID
+	ArtefactID        string // Artefact ID, or a comma-separated list of artefact IDs
+	JSONVersion       string // JSON version of JSON artefact content
+	ObservationID     string // Observation ID
+	CoordinationTopic string // Coordination topic path
+	At                string // Time of the historical version to retrieve, if any
+
+	Wait                bool          // Wait for a posting
+	WaitMode            string        // Wait mode when waiting for a JSON artefact posting
+	WaitTimeout         time.Duration // Maximum time to wait for a posting (0 for no limit)
+	Watch               bool          // Keep waiting for postings, storing each one, until shut down
+	SkipEmpty           bool          // When waiting, skip empty postings
+	Temp                bool          // Store in a temporary file, and only print its path
+	MaxBytes            int64         // Maximum number of bytes to keep of a raw retrieval (0 for no limit)
+	ProgressInterval    time.Duration // Interval between progress reports of large transfers
+	ExpectedSHA256      string        // Expected SHA-256 digest of a raw retrieval
+	Strict              bool          // Delete a raw retrieval when its checksum does not match
+	TimestampFormat     string        // Format of timestamp files
+	Pretty              bool          // Indent retrieved JSON content
+	Gzip                bool          // Compress stored files with gzip
+	SkipTopicValidation bool          // Do not validate topic paths before using them
+	Retries             int           // Number of times to retry on transient errors
+	RetryBackoff        time.Duration // Backoff before the first retry
+	FailFast            bool          // Stop a batch operation at the first failure
+
+	OnChange *app_generics.TChangeCommand // Command to run for each stored file; nil if none
+}
+
+// A handler for a retrieval kind
+type TRetrievalHandler func(retrieval *TRetrievalContext) error
+
+/*
+ * Supported kinds
+ */
+
+// Listing the supported retrieval kinds, in sorted order
+func SupportedKinds() []string {
+	return app_generics.SortedKinds(retrievalHandlers)
+}
+
+// Explaining the retrieval kind flag, based on the supported kinds.
+// As the handler map is a package variable, this can only be done once it is initialised.
+func init() {
+	flags.Lookup("kind").Usage = "Kind of retrieval to conduct. One of: " + app_generics.ExplainOptions(SupportedKinds()) + "."
+}
+
 /*
  * Generic functionality to support the retrieval handlers
  */
 
 // Write timestamp to a file
-func writeTimestampToFile(timestamp, filePath string) {
+func writeTimestampToFile(retrieval *TRetrievalContext, timestamp, filePath string) {
+	// Normalising the timestamp, falling back to the raw timestamp if needed
+	timestamp, err := app_generics.FormatTimestamp(timestamp, retrieval.TimestampFormat)
+	if err != nil {
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Warning: %s; writing the timestamp as is.", err)
+	}
+
 	if err := os.WriteFile(filePath+timestampExtension, []byte(timestamp), 0644); err != nil {
 		// Reporting error
-		modellingBusConnector.Reporter.ReportError("Error writing to timestamp file:", err)
+		retrieval.Reporter.ReportError("Error writing to timestamp file:", err)
+	}
+}
+
+// Write content to a new temporary file, and print its path. The content is copied in
+// chunks, so large raw retrievals need not be held in memory.
+func writeToTempFile(retrieval *TRetrievalContext, content io.Reader, fileNamePattern string) {
+	// Creating the temporary file
+	tempFile, err := os.CreateTemp("", fileNamePattern)
+	if retrieval.Reporter.MaybeReportError("Error creating temporary file:", err) {
+		return
+	}
+	defer tempFile.Close()
+
+	// Writing the content
+	if _, err := io.Copy(tempFile, content); retrieval.Reporter.MaybeReportError("Error writing to temporary file:", err) {
+		return
 	}
+
+	// Printing the absolute path of the temporary file
+	tempFilePath, err := filepath.Abs(tempFile.Name())
+	if retrieval.Reporter.MaybeReportError("Error determining path of temporary file:", err) {
+		return
+	}
+	fmt.Fprintln(retrieval.Output, tempFilePath)
+}
+
+// Truncate a retrieved raw file to the maximum number of bytes, if needed
+func truncateRawFile(retrieval *TRetrievalContext, filePath string) {
+	// A maximum of 0 means no limit
+	if retrieval.MaxBytes <= 0 {
+		return
+	}
+
+	// Getting the size of the retrieved file
+	fileInfo, err := os.Stat(filePath)
+	if retrieval.Reporter.MaybeReportError("Error determining size of retrieved file:", err) {
+		return
+	}
+
+	// Truncating the file, if it exceeds the maximum
+	if fileInfo.Size() > retrieval.MaxBytes {
+		if retrieval.Reporter.MaybeReportError("Error truncating retrieved file:", os.Truncate(filePath, retrieval.MaxBytes)) {
+			return
+		}
+
+		// Reporting the truncation
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Truncated %s from %d to %d bytes.", filePath, fileInfo.Size(), retrieval.MaxBytes)
+	}
+}
+
+// Verify the SHA-256 checksum of a file against an expected (hexadecimal) digest, if any,
+// returning the computed digest. The modelling bus does not store digests with postings,
+// so the expected digest has to be obtained from the poster.
+func VerifyChecksum(filePath, expectedDigest string) (string, error) {
+	// Computing the digest
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	// Comparing with the expected digest
+	if expectedDigest != "" && !strings.EqualFold(digest, expectedDigest) {
+		return digest, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", filePath, expectedDigest, digest)
+	}
+
+	return digest, nil
+}
+
+// Verify the checksum of a retrieved raw file, and write it to a sidecar file.
+// Returns false if the file is to be discarded.
+func verifyRawFile(retrieval *TRetrievalContext, filePath string) bool {
+	// Verifying the checksum
+	digest, err := VerifyChecksum(filePath, retrieval.ExpectedSHA256)
+	if retrieval.Reporter.MaybeReportError("Error verifying checksum:", err) {
+		// Without a digest, the file could not be read, so there is nothing more to do
+		if digest == "" {
+			return true
+		}
+
+		// Deleting the corrupted file in strict mode
+		if retrieval.Strict {
+			os.Remove(filePath)
+
+			return false
+		}
+	}
+
+	// Writing the sidecar file, in the format of sha256sum, so it can be re-verified
+	if !retrieval.Temp {
+		sidecar := digest + "  " + filepath.Base(filePath) + "\n"
+		if err := os.WriteFile(filePath+checksumExtension, []byte(sidecar), 0644); err != nil {
+			retrieval.Reporter.ReportError("Error writing to checksum file:", err)
+		}
+	}
+
+	return true
+}
+
+// Store a retrieved raw file, together with its timestamp. The artefact connector writes
+// raw retrievals to a file itself, and everything here reads that file in chunks, so memory
+// use does not grow with the size of the file. A streaming GetRawArtefactStream on the
+// artefact connector is out of scope for these apps, as the connector belongs to the
+// modelling bus library (github.com/erikproper/big-modelling-bus.go.v1).
+func storeRawFile(retrieval *TRetrievalContext, filePath, timestamp, description string) {
+	// Ensuring a shutdown waits for the file to be stored
+	savingLock.Lock()
+	defer savingLock.Unlock()
+
+	// Limiting the size of the file, if requested
+	truncateRawFile(retrieval, filePath)
+
+	// Verifying the integrity of the file
+	if !verifyRawFile(retrieval, filePath) {
+		return
+	}
+
+	// Moving the file to a temporary file, if requested
+	if retrieval.Temp {
+		content, err := os.Open(filePath)
+		if retrieval.Reporter.MaybeReportError("Error reading retrieved file:", err) {
+			return
+		}
+
+		// Reporting the progress of copying large files
+		var total int64
+		if fileInfo, err := content.Stat(); err == nil {
+			total = fileInfo.Size()
+		}
+		progressReader := app_generics.CreateProgressReader(content, retrieval.Reporter, "Copied", total, retrieval.ProgressInterval)
+
+		writeToTempFile(retrieval, progressReader, "*_"+filepath.Base(filePath))
+		content.Close()
+		os.Remove(filePath)
+
+		return
+	}
+
+	// Write timestamp to a file
+	writeTimestampToFile(retrieval, timestamp, filePath)
+
+	// Compressing the file, if requested
+	if retrieval.Gzip {
+		compressedFilePath, err := app_generics.GzipFile(filePath)
+		if retrieval.Reporter.MaybeReportError("Error compressing retrieved file:", err) {
+			return
+		}
+
+		filePath = compressedFilePath
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved %s as: %s", description, filePath)
+
+	// Acting on the stored file
+	fileStored(retrieval, filePath, timestamp)
+}
+
+// Indenting JSON content by two spaces. Content that is not valid JSON is returned unchanged, with a warning.
+func indentJSON(retrieval *TRetrievalContext, jsonContent []byte) []byte {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, jsonContent, "", "  "); err != nil {
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Warning: content is not valid JSON (%s); saving it unchanged.", err)
+
+		return jsonContent
+	}
+
+	return indented.Bytes()
 }
 
 // Save JSON to file with given kind and base file name
-func SaveJSONToFile(jsonContent []byte, timestamp, kind string) {
-	fileBaseName := *fileNameFlag + generics.JSONExtension
+func SaveJSONToFile(retrieval *TRetrievalContext, jsonContent []byte, timestamp, kind string) {
+	// Ensuring a shutdown waits for the file to be saved
+	savingLock.Lock()
+	defer savingLock.Unlock()
+
+	fileBaseName := retrieval.FileName + jsonExtension
+
+	// Indenting the JSON, if requested
+	if retrieval.Pretty {
+		jsonContent = indentJSON(retrieval, jsonContent)
+	}
 
 	if len(kind) > 0 {
 		fileBaseName = kind + "_" + fileBaseName
 	}
 
-	filePath := filepath.FromSlash(localFilePath + "/" + fileBaseName)
-	if err := os.WriteFile(filePath, jsonContent, 0644); err != nil {
+	// Saving to a temporary file, if requested
+	if retrieval.Temp {
+		writeToTempFile(retrieval, bytes.NewReader(jsonContent), "*_"+fileBaseName)
+
+		return
+	}
+
+	filePath := filepath.FromSlash(retrieval.WorkFolder + "/" + fileBaseName)
+
+	// Compressing the file, if requested, where the timestamp file keeps the uncompressed name
+	storedFilePath := filePath
+	if retrieval.Gzip {
+		storedFilePath = filePath + app_generics.GzipExtension
+		if err := app_generics.WriteGzipFile(storedFilePath, bytes.NewReader(jsonContent)); err != nil {
+			// Reporting error
+			retrieval.Reporter.ReportError("Error writing to compressed json file:", err)
+			return
+		}
+	} else if err := os.WriteFile(filePath, jsonContent, 0644); err != nil {
 		// Reporting error
-		modellingBusConnector.Reporter.ReportError("Error writing to json file:", err)
+		retrieval.Reporter.ReportError("Error writing to json file:", err)
 		return
 	}
 
 	// Write timestamp to a file
-	writeTimestampToFile(timestamp, filePath)
+	writeTimestampToFile(retrieval, timestamp, filePath)
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved JSON artefact for %s as: %s", kind, filePath)
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved JSON artefact for %s as: %s", kind, storedFilePath)
+
+	// Acting on the stored file
+	fileStored(retrieval, storedFilePath, timestamp)
 }
 
-// Deferred or immediate retrieval
-func deferredOrImmediate(progress string, deferredHandler func(*bool), immediateHandler func()) {
-	if *waitFlag {
+// Running the change command, if any, for a file that was stored successfully
+func fileStored(retrieval *TRetrievalContext, filePath, timestamp string) {
+	if retrieval.OnChange != nil {
+		retrieval.OnChange.Changed(filePath, timestamp)
+	}
+}
+
+// Checking if a posting is to be skipped, as it is empty and we should wait for a non-empty one
+func skipEmptyPayload(retrieval *TRetrievalContext, content []byte, description string) bool {
+	// Only when requested
+	if !retrieval.SkipEmpty {
+		return false
+	}
+
+	// Checking for an empty payload
+	switch strings.TrimSpace(string(content)) {
+	case "", "null", "{}":
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Skipped empty %s posting.", description)
+
+		return true
+	}
+
+	return false
+}
+
+// Checking if a retrieved file is to be skipped, as it is empty and we should wait for a non-empty one
+func skipEmptyFile(retrieval *TRetrievalContext, filePath, description string) bool {
+	// Only when requested
+	if !retrieval.SkipEmpty {
+		return false
+	}
+
+	// Checking for an empty file
+	if fileInfo, err := os.Stat(filePath); err == nil && fileInfo.Size() == 0 {
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Skipped empty %s posting.", description)
+		os.Remove(filePath)
+
+		return true
+	}
+
+	return false
+}
+
+// Deferred or immediate retrieval. The deferred handler is given a function that
+// signals that the awaited posting has been handled. Returns an ExitBusError exit error
+// when timing out, and nil otherwise, including when shutting down.
+func deferredOrImmediate(retrieval *TRetrievalContext, progress string, deferredHandler func(finished func()), immediateHandler func()) error {
+	if retrieval.Wait || retrieval.Watch {
 		// Reporting progress
-		modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Deferred %s retrieval.", progress)
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Deferred %s retrieval.", progress)
+
+		// Signalling completion by closing the done channel, once, unless watching, which
+		// only ends with a shutdown
+		done := make(chan struct{})
+		var finishing sync.Once
+		deferredHandler(func() {
+			if !retrieval.Watch {
+				finishing.Do(func() { close(done) })
+			}
+		})
 
-		finished := false
+		// Without a timeout, or when watching, the timeout channel is never signalled
+		var timeoutChannel <-chan time.Time
+		if retrieval.WaitTimeout > 0 && !retrieval.Watch {
+			timeout := time.NewTimer(retrieval.WaitTimeout)
+			defer timeout.Stop()
 
-		deferredHandler(&finished)
+			timeoutChannel = timeout.C
+		}
+
+		// Waiting for completion, the timeout, or a shutdown
+		select {
+		case <-done:
+		case <-timeoutChannel:
+			retrieval.Reporter.ReportError("Timed out waiting for posting", fmt.Errorf("no posting received within %s", retrieval.WaitTimeout))
+
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		case <-retrieval.Shutdown.Done():
+			// Letting a posting that is being saved, finish
+			savingLock.Lock()
+			defer savingLock.Unlock()
 
-		for !finished {
-			time.Sleep(1 * time.Second)
+			if retrieval.OnChange != nil {
+				retrieval.OnChange.Flush()
+			}
+			retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Shutting down")
 		}
 	} else {
 		// Reporting progress
-		modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Immediate %s retrieval.", progress)
+		retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Immediate %s retrieval.", progress)
 
 		immediateHandler()
 	}
+
+	return nil
+}
+
+// Validating a topic path given by the named flag, unless validation is skipped
+func validTopic(retrieval *TRetrievalContext, flagName, topic string) bool {
+	if retrieval.SkipTopicValidation {
+		return true
+	}
+
+	return !retrieval.Reporter.MaybeReportError("Error in "+flagName+" flag:", app_generics.ValidateTopic(topic))
 }
 
 /*
@@ -142,209 +564,551 @@ func deferredOrImmediate(progress string, deferredHandler func(*bool), immediate
  */
 
 // Handler for raw artefact retrieval
-func handleRawArtefactRetrieval() {
-	// We need an artefact ID for artefact retrievals
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact retrieval.") {
-		return
-	}
-
-	// We must also have an agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+func handleRawArtefactRetrieval(retrieval *TRetrievalContext) error {
+	// We need the flags required for raw artefact retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "raw artefact retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.ArtefactID, Name: "artefact_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Create the modelling bus artefact retriever
-	modellingBusArtefactRetriever := connect.CreateModellingBusArtefactConnector(modellingBusConnector, "", *artefactIDFlag)
+	modellingBusArtefactRetriever := connect.CreateModellingBusArtefactConnector(*retrieval.Connector, "", retrieval.ArtefactID)
 
 	// Deferred or immediate variation
-	deferredOrImmediate("raw artefact",
-		func(finished *bool) {
+	return deferredOrImmediate(retrieval, "raw artefact",
+		func(finished func()) {
 			// Deferr for a raw artefact state posting
-			modellingBusArtefactRetriever.ListenForRawArtefactStatePostings(*agentIDFlag, *artefactIDFlag, *fileNameFlag, func(filePath string, timestamp string) {
-				// Write timestamp to a file
-				writeTimestampToFile(timestamp, filePath)
-
-				// Reporting progress
-				modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved raw artefact as: %s", filePath)
-
-				*finished = true
+			modellingBusArtefactRetriever.ListenForRawArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func(receivedFilePath string) {
+				// The artefact connector neither passes on the timestamp of the posting, nor
+				// uses the file name, so the posting is stamped with the time it was received,
+				// and moved to the file name
+				timestamp := generics.GetTimestamp()
+				filePath := filepath.Join(filepath.Dir(receivedFilePath), retrieval.FileName)
+				if retrieval.Reporter.MaybeReportError("Error storing received raw artefact:", os.Rename(receivedFilePath, filePath)) {
+					return
+				}
+
+				// Waiting for a non-empty posting, if requested
+				if skipEmptyFile(retrieval, filePath, "raw artefact") {
+					return
+				}
+
+				// Storing the raw artefact
+				storeRawFile(retrieval, filePath, timestamp, "raw artefact")
+
+				finished()
 			})
 		},
 		func() {
 			// Retrieving the raw artefact
-			filePath, timestamp := modellingBusArtefactRetriever.GetRawArtefact(*agentIDFlag, *artefactIDFlag, *fileNameFlag)
+			filePath, timestamp := modellingBusArtefactRetriever.GetRawArtefactState(retrieval.AgentID, rawArtefactsTopicPathElement+"/"+retrieval.ArtefactID, retrieval.FileName)
 
-			// Write timestamp to a file
-			writeTimestampToFile(timestamp, filePath)
-
-			// Reporting progress
-			modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved raw artefact as: %s", filePath)
+			// Storing the raw artefact
+			storeRawFile(retrieval, filePath, timestamp, "raw artefact")
 		})
 }
 
-// Handler for JSON artefact retrieval
-func handleJSONArtefactRetrieval() {
-	// We need a JSON version for JSON artefact retrievals
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(jsonVersionFlag, "No JSON version specified for JSON artefact retrieval.") {
-		return
+// Saving a version of a JSON artefact, labelled with its kind
+func saveJSONArtefactVersion(retrieval *TRetrievalContext, version TJSONArtefactVersion, kind string) {
+	SaveJSONToFile(retrieval, version.Content, version.Timestamp, kind)
+}
+
+// Saving the state, update, and considered versions of a JSON artefact
+func saveJSONArtefactVersions(retrieval *TRetrievalContext, artefactRetriever TJSONArtefactRetriever) {
+	saveJSONArtefactVersion(retrieval, artefactRetriever.State(), "state")
+	saveJSONArtefactVersion(retrieval, artefactRetriever.Update(), "update")
+	saveJSONArtefactVersion(retrieval, artefactRetriever.Considered(), "considered")
+}
+
+// Saving only the newest of the state, update, and considered versions of a JSON artefact,
+// by their timestamps. Versions without content, or without a known timestamp, are skipped.
+// Returns false if no version could be saved.
+func saveLatestJSONArtefactVersion(retrieval *TRetrievalContext, artefactRetriever TJSONArtefactRetriever) bool {
+	versions := []struct {
+		TJSONArtefactVersion        // The version
+		kind                 string // Kind of the version
+	}{
+		{artefactRetriever.State(), "state"},
+		{artefactRetriever.Update(), "update"},
+		{artefactRetriever.Considered(), "considered"},
 	}
 
-	// We must also have an agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+	// Determining the newest version
+	newest := -1
+	newestTime := time.Time{}
+	for position, version := range versions {
+		if len(version.Content) == 0 {
+			continue
+		}
+
+		versionTime, err := app_generics.ParseTimestamp(version.Timestamp)
+		if err != nil {
+			continue
+		}
+
+		if newest < 0 || versionTime.After(newestTime) {
+			newest = position
+			newestTime = versionTime
+		}
 	}
 
-	// We also need an artefact ID for artefact retrievals
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact retrieval.") {
-		return
+	if newest < 0 {
+		return false
+	}
+
+	// Saving the newest version, labelled with its kind
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Latest posting: %s.", versions[newest].kind)
+	saveJSONArtefactVersion(retrieval, versions[newest].TJSONArtefactVersion, versions[newest].kind)
+
+	return true
+}
+
+// Retrieving the state, update, and considered versions of a JSON artefact. Getting the
+// considered version already gets the state and the update it is based on, in one chain of
+// round-trips, so these are not retrieved separately. Nor can they be retrieved concurrently
+// using the same retriever, as getting the state resets the update and considered versions.
+// The connector reports the errors of each version itself, while the versions that were
+// retrieved are kept.
+func getJSONArtefactParts(retrieval *TRetrievalContext, artefactRetriever TJSONArtefactRetriever) {
+	artefactRetriever.GetJSONArtefactConsidering(retrieval.AgentID, retrieval.ArtefactID)
+}
+
+// Handler for JSON artefact retrieval
+func handleJSONArtefactRetrieval(retrieval *TRetrievalContext) error {
+	// We need the flags required for JSON artefact retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "JSON artefact retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.JSONVersion, Name: "json_version"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.ArtefactID, Name: "artefact_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// The wait mode must be known
+	if !waitModes[retrieval.WaitMode] {
+		retrieval.Reporter.Error("Unknown wait mode: %s", retrieval.WaitMode)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Create the modelling bus artefact retriever
-	modellingBusArtefactRetriever := connect.CreateModellingBusArtefactConnector(modellingBusConnector, *jsonVersionFlag, *artefactIDFlag)
-
-	deferredOrImmediate("JSON artefact",
-		func(finished *bool) {
-			if *waitModeFlag == "state" {
-				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.CurrentContent, modellingBusArtefactRetriever.CurrentTimestamp, "state")
-					*finished = true
+	modellingBusArtefactRetriever := retrieval.JSONArtefactRetriever(retrieval.JSONVersion, retrieval.ArtefactID)
+
+	return deferredOrImmediate(retrieval, "JSON artefact",
+		func(finished func()) {
+			if retrieval.WaitMode == waitModeState {
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.State().Content, "JSON artefact state") {
+						return
+					}
+
+					saveJSONArtefactVersion(retrieval, modellingBusArtefactRetriever.State(), "state")
+					finished()
+				})
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+
+			} else if retrieval.WaitMode == waitModeUpdate {
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.Update().Content, "JSON artefact update") {
+						return
+					}
+
+					saveJSONArtefactVersion(retrieval, modellingBusArtefactRetriever.Update(), "update")
+					finished()
+				})
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+
+			} else if retrieval.WaitMode == waitModeConsidering {
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {})
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.Considered().Content, "JSON artefact considered") {
+						return
+					}
+
+					saveJSONArtefactVersion(retrieval, modellingBusArtefactRetriever.Considered(), "considered")
+					finished()
+				})
+
+			} else if retrieval.WaitMode == waitModeFirst {
+				// Only saving the posting that arrives first, labelled with its kind
+				var first sync.Once
+				saveFirst := func(version TJSONArtefactVersion, kind string) {
+					if skipEmptyPayload(retrieval, version.Content, "JSON artefact "+kind) {
+						return
+					}
+
+					first.Do(func() {
+						retrieval.Reporter.Progress(generics.ProgressLevelBasic, "First posting received: %s.", kind)
+						saveJSONArtefactVersion(retrieval, version, kind)
+						finished()
+					})
+				}
+
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					saveFirst(modellingBusArtefactRetriever.State(), "state")
 				})
-				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(*agentIDFlag, *artefactIDFlag, func() {})
-				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(*agentIDFlag, *artefactIDFlag, func() {})
-
-			} else if *waitModeFlag == "update" {
-				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(*agentIDFlag, *artefactIDFlag, func() {})
-				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.UpdatedContent, modellingBusArtefactRetriever.UpdatedTimestamp, "update")
-					*finished = true
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					saveFirst(modellingBusArtefactRetriever.Update(), "update")
 				})
-				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(*agentIDFlag, *artefactIDFlag, func() {})
-
-			} else if *waitModeFlag == "considering" {
-				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(*agentIDFlag, *artefactIDFlag, func() {})
-				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(*agentIDFlag, *artefactIDFlag, func() {})
-				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.ConsideredContent, modellingBusArtefactRetriever.ConsideredTimestamp, "considered")
-					*finished = true
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					saveFirst(modellingBusArtefactRetriever.Considered(), "considered")
 				})
 
+			} else if retrieval.WaitMode == waitModeLatest {
+				// On the first posting, only saving the newest of the versions received so far
+				var first sync.Once
+				saveLatest := func() {
+					first.Do(func() {
+						if !saveLatestJSONArtefactVersion(retrieval, modellingBusArtefactRetriever) {
+							retrieval.Reporter.Error("No JSON artefact version with a known timestamp received.")
+						}
+						finished()
+					})
+				}
+
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, saveLatest)
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, saveLatest)
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, saveLatest)
+
 			} else {
-				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.CurrentContent, modellingBusArtefactRetriever.CurrentTimestamp, "state")
-					SaveJSONToFile(modellingBusArtefactRetriever.UpdatedContent, modellingBusArtefactRetriever.UpdatedTimestamp, "update")
-					SaveJSONToFile(modellingBusArtefactRetriever.ConsideredContent, modellingBusArtefactRetriever.ConsideredTimestamp, "considered")
-					*finished = true
+				modellingBusArtefactRetriever.ListenForJSONArtefactStatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.State().Content, "JSON artefact state") {
+						return
+					}
+
+					saveJSONArtefactVersions(retrieval, modellingBusArtefactRetriever)
+					finished()
 				})
-				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.CurrentContent, modellingBusArtefactRetriever.CurrentTimestamp, "state")
-					SaveJSONToFile(modellingBusArtefactRetriever.UpdatedContent, modellingBusArtefactRetriever.UpdatedTimestamp, "update")
-					SaveJSONToFile(modellingBusArtefactRetriever.ConsideredContent, modellingBusArtefactRetriever.ConsideredTimestamp, "considered")
-					*finished = true
+				modellingBusArtefactRetriever.ListenForJSONArtefactUpdatePostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.Update().Content, "JSON artefact update") {
+						return
+					}
+
+					saveJSONArtefactVersions(retrieval, modellingBusArtefactRetriever)
+					finished()
 				})
-				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(*agentIDFlag, *artefactIDFlag, func() {
-					SaveJSONToFile(modellingBusArtefactRetriever.CurrentContent, modellingBusArtefactRetriever.CurrentTimestamp, "state")
-					SaveJSONToFile(modellingBusArtefactRetriever.UpdatedContent, modellingBusArtefactRetriever.UpdatedTimestamp, "update")
-					SaveJSONToFile(modellingBusArtefactRetriever.ConsideredContent, modellingBusArtefactRetriever.ConsideredTimestamp, "considered")
-					*finished = true
+				modellingBusArtefactRetriever.ListenForJSONArtefactConsideringPostings(retrieval.AgentID, retrieval.ArtefactID, func() {
+					if skipEmptyPayload(retrieval, modellingBusArtefactRetriever.Considered().Content, "JSON artefact considered") {
+						return
+					}
+
+					saveJSONArtefactVersions(retrieval, modellingBusArtefactRetriever)
+					finished()
 				})
 			}
 		},
 		func() {
 			// Retrieving the JSON artefact state, update, and considering
-			modellingBusArtefactRetriever.GetJSONArtefactState(*agentIDFlag, *artefactIDFlag)
-			modellingBusArtefactRetriever.GetJSONArtefactUpdate(*agentIDFlag, *artefactIDFlag)
-			modellingBusArtefactRetriever.GetJSONArtefactConsidering(*agentIDFlag, *artefactIDFlag)
+			getJSONArtefactParts(retrieval, modellingBusArtefactRetriever)
+
+			// Only saving the newest version, if requested
+			if retrieval.WaitMode == waitModeLatest {
+				if !saveLatestJSONArtefactVersion(retrieval, modellingBusArtefactRetriever) {
+					retrieval.Reporter.Error("No JSON artefact version with a known timestamp found.")
+				}
+
+				return
+			}
 
 			// Save JSONs to files
-			SaveJSONToFile(modellingBusArtefactRetriever.CurrentContent, modellingBusArtefactRetriever.CurrentTimestamp, "state")
-			SaveJSONToFile(modellingBusArtefactRetriever.UpdatedContent, modellingBusArtefactRetriever.UpdatedTimestamp, "update")
-			SaveJSONToFile(modellingBusArtefactRetriever.ConsideredContent, modellingBusArtefactRetriever.ConsideredTimestamp, "considered")
+			saveJSONArtefactVersions(retrieval, modellingBusArtefactRetriever)
 		})
 }
 
 // Handler for raw observation retrieval
-func handleRawObservationRetrieval() {
-	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
-	}
-
-	// We must also have an agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+func handleRawObservationRetrieval(retrieval *TRetrievalContext) error {
+	// We need the flags required for raw observation retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "raw observation retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.ObservationID, Name: "observation_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation retrieval.")
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation retrieval.")
 
 	// Retrieving the raw observation
-	filePath, timestamp := modellingBusConnector.GetRawObservation(*agentIDFlag, *observationIDFlag, *fileNameFlag)
+	filePath, timestamp := retrieval.Bus.GetRawObservation(retrieval.AgentID, retrieval.ObservationID, retrieval.FileName)
 
-	// timestampFileNameFlag
-	writeTimestampToFile(timestamp, filePath)
+	// Storing the raw observation
+	storeRawFile(retrieval, filePath, timestamp, "raw observation")
 
-	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Retrieved raw observation as: %s", filePath)
+	return nil
 }
 
 // Handler for JSON observation retrieval
-func handleJSONObservationRetrieval() {
-	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
-	}
-
-	// We must also have an agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+func handleJSONObservationRetrieval(retrieval *TRetrievalContext) error {
+	// We need the flags required for JSON observation retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "JSON observation retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.ObservationID, Name: "observation_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation retrieval.")
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation retrieval.")
 
 	// Retrieving the JSON observation
-	observation, timestamp := modellingBusConnector.GetJSONObservation(*agentIDFlag, *observationIDFlag)
+	observation, timestamp := retrieval.Bus.GetJSONObservation(retrieval.AgentID, retrieval.ObservationID)
 
 	// Saving the JSON observation to a file
-	SaveJSONToFile(observation, timestamp, "")
+	SaveJSONToFile(retrieval, observation, timestamp, "")
+
+	return nil
 }
 
 // Handler for streamed observation retrieval
-func handleStreamedObservationRetrieval() {
-	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
-	}
-
-	// We must also have an agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No agent ID specified.") {
-		return
+func handleStreamedObservationRetrieval(retrieval *TRetrievalContext) error {
+	// We need the flags required for streamed observation retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "streamed observation retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.ObservationID, Name: "observation_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation retrieval.")
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation retrieval.")
 
 	// Retrieving the JSON observation
-	observation, timestamp := modellingBusConnector.GetStreamedObservation(*agentIDFlag, *observationIDFlag)
+	observation, timestamp := retrieval.Bus.GetStreamedObservation(retrieval.AgentID, retrieval.ObservationID)
 
 	// Saving the JSON observation to a file
-	SaveJSONToFile(observation, timestamp, "")
+	SaveJSONToFile(retrieval, observation, timestamp, "")
+
+	return nil
 }
 
 // Handler for coordination retrieval
-func handleCoordinationRetrieval() {
+func handleCoordinationRetrieval(retrieval *TRetrievalContext) error {
 	// We must have a coordination topic
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(coordinationTopicFlag, "No coordination topic specified.") {
-		return
+	if retrieval.Reporter.MaybeReportEmptyFlagError(&retrieval.CoordinationTopic, "No coordination topic specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating the coordination topic
+	if !validTopic(retrieval, "coordination_topic", retrieval.CoordinationTopic) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Coordination retrieval.")
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Coordination retrieval.")
 
-	coordination, timestamp := modellingBusConnector.GetCoordination(*coordinationTopicFlag)
+	coordination, timestamp := retrieval.Bus.GetCoordination(retrieval.AgentID, retrieval.CoordinationTopic)
 
 	// Saving the JSON observation to a file
-	SaveJSONToFile(coordination, timestamp, "")
+	SaveJSONToFile(retrieval, coordination, timestamp, "")
+
+	return nil
+}
+
+// Handler for listing the available artefacts
+func handleListRetrieval(retrieval *TRetrievalContext) error {
+	// We must have an agent ID
+	if retrieval.Reporter.MaybeReportEmptyFlagError(&retrieval.AgentID, "No agent ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Listing artefacts.")
+
+	// Listing the artefacts
+	artefactIDs, err := app_generics.ListArtefacts(*retrieval.Connector, retrieval.AgentID)
+	if retrieval.Reporter.MaybeReportError("Error listing artefacts:", err) {
+		return err
+	}
+
+	// Printing the artefact IDs
+	for _, artefactID := range artefactIDs {
+		fmt.Fprintln(retrieval.Output, artefactID)
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Found %d artefact(s) of agent %s.", len(artefactIDs), retrieval.AgentID)
+
+	return nil
+}
+
+// Handler for listing the available coordination topics, under the coordination topic as
+// prefix, if given, where a trailing wildcard is optional
+func handleCoordinationListRetrieval(retrieval *TRetrievalContext) error {
+	prefix := strings.TrimSuffix(retrieval.CoordinationTopic, coordinationWildcard)
+
+	// Validating the prefix, as far as it forms a topic
+	if strings.TrimSuffix(prefix, "/") != "" && !validTopic(retrieval, "coordination_topic", strings.TrimSuffix(prefix, "/")) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Listing coordination topics.")
+
+	// Listing the coordination topics
+	topics, err := app_generics.ListCoordinationTopics(retrieval.Bus, prefix)
+	if retrieval.Reporter.MaybeReportError("Error listing coordination topics:", err) {
+		return err
+	}
+
+	// Printing the coordination topics
+	for _, topic := range topics {
+		fmt.Fprintln(retrieval.Output, topic)
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Found %d coordination topic(s) under: %s", len(topics), prefix)
+
+	return nil
+}
+
+/*
+ * Retrieving historical versions
+ */
+
+// Retrieving the state of a JSON artefact as it was at the time given by the at flag
+func retrieveHistoricalArtefact(retrieval *TRetrievalContext) error {
+	// Historical versions are only supported for JSON artefacts
+	if retrieval.Kind != jsonArtefactRetrieval {
+		retrieval.Reporter.Error("Retrieving historical versions is only supported for JSON artefacts.")
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating the time
+	at, err := app_generics.ParseTimestamp(retrieval.At)
+	if retrieval.Reporter.MaybeReportError("Error in at flag:", err) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// We need the flags required for historical JSON artefact retrieval
+	if !app_generics.RequireFlags(retrieval.Reporter, "historical JSON artefact retrieval",
+		app_generics.TRequiredFlag{Value: &retrieval.ArtefactID, Name: "artefact_id"},
+		app_generics.TRequiredFlag{Value: &retrieval.JSONVersion, Name: "json_version"},
+		app_generics.TRequiredFlag{Value: &retrieval.AgentID, Name: "agent_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Reporting progress
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Historical JSON artefact retrieval, at %s.", at.Format(time.RFC3339))
+
+	// Retrieving the version effective at the given time
+	modellingBusArtefactRetriever := connect.CreateModellingBusArtefactConnector(*retrieval.Connector, retrieval.JSONVersion, retrieval.ArtefactID)
+	content, timestamp, err := app_generics.GetJSONArtefactStateAt(modellingBusArtefactRetriever, retrieval.AgentID, retrieval.ArtefactID, at)
+	if retrieval.Reporter.MaybeReportError("Error retrieving historical version:", err) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	// Saving the JSON to a file
+	SaveJSONToFile(retrieval, content, timestamp, "state")
+
+	return nil
+}
+
+/*
+ * Retrying retrievals
+ */
+
+// Wrapping a retrieval handler, so it retries retrievals that fail due to transient problems, if requested
+func withRetries(retrievalHandler TRetrievalHandler) TRetrievalHandler {
+	return func(retrieval *TRetrievalContext) error {
+		return app_generics.WithRetries(retrieval.Reporter, retrieval.Retries, retrieval.RetryBackoff, func() error { return retrievalHandler(retrieval) })()
+	}
+}
+
+/*
+ * Retrieving several artefacts
+ */
+
+// Retrieving the artefacts with the comma-separated artefact IDs, one by one, using the same connector.
+// The artefact ID is included in the file names, while failing retrievals do not stop the others.
+func retrieveArtefacts(retrieval *TRetrievalContext, retrievalHandler TRetrievalHandler) error {
+
+	// Collecting the artefact IDs
+	artefactIDs := []string{}
+	for _, artefactID := range strings.Split(retrieval.ArtefactID, ",") {
+		if artefactID = strings.TrimSpace(artefactID); artefactID != "" {
+			artefactIDs = append(artefactIDs, artefactID)
+		}
+	}
+
+	// Retrieving the artefacts, one at a time, as the errors reported by each retrieval are counted
+	results := app_generics.RunTasks(app_generics.Sequential, retrieval.FailFast, artefactIDs, func(artefactID string) error {
+		artefactRetrieval := *retrieval
+		artefactRetrieval.ArtefactID = artefactID
+		artefactRetrieval.FileName = retrieval.FileName + "_" + artefactID
+
+		return app_generics.ErrorsReportedBy(func() error { return retrievalHandler(&artefactRetrieval) })
+	})
+
+	// Reporting the summary
+	if !app_generics.ReportTaskSummary(retrieval.Reporter, "Retrieved", "artefacts", results) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	return nil
+}
+
+/*
+ * Retrieving several coordinations
+ */
+
+// Checking if a coordination topic is a prefix, ending with the wildcard, e.g. project/alpha/*
+func isCoordinationPrefix(topic string) bool {
+	return strings.HasSuffix(topic, coordinationWildcard)
+}
+
+// Deriving a file name from a coordination topic, by replacing the characters that cannot
+// occur in file names
+func topicFileName(topic string) string {
+	return strings.Map(func(character rune) rune {
+		if strings.ContainsRune(`/\:*?"<>| `, character) {
+			return '_'
+		}
+
+		return character
+	}, strings.Trim(topic, "/"))
+}
+
+// Retrieving all coordinations whose topic starts with the prefix before the wildcard, one by one.
+// The topic is included in the file names, while failing retrievals do not stop the others.
+func retrieveCoordinations(retrieval *TRetrievalContext, retrievalHandler TRetrievalHandler) error {
+	prefix := strings.TrimSuffix(retrieval.CoordinationTopic, coordinationWildcard)
+
+	// Validating the prefix, as far as it forms a topic
+	if strings.TrimSuffix(prefix, "/") != "" && !validTopic(retrieval, "coordination_topic", strings.TrimSuffix(prefix, "/")) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Finding the coordinations under the prefix
+	coordinations, err := app_generics.GetCoordinationsUnder(retrieval.Bus, prefix)
+	if retrieval.Reporter.MaybeReportError("Error finding coordinations under "+prefix+":", err) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	topics := slices.Sorted(maps.Keys(coordinations))
+	retrieval.Reporter.Progress(generics.ProgressLevelBasic, "Found %d coordination(s) under: %s", len(topics), prefix)
+
+	// Retrieving the coordinations, one at a time, as the errors reported by each retrieval are counted
+	results := app_generics.RunTasks(app_generics.Sequential, retrieval.FailFast, topics, func(topic string) error {
+		topicRetrieval := *retrieval
+		topicRetrieval.CoordinationTopic = topic
+		topicRetrieval.FileName = retrieval.FileName + "_" + topicFileName(topic)
+
+		return app_generics.ErrorsReportedBy(func() error { return retrievalHandler(&topicRetrieval) })
+	})
+
+	// Reporting the summary
+	if !app_generics.ReportTaskSummary(retrieval.Reporter, "Retrieved", "coordinations", results) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	return nil
 }
 
 /*
@@ -352,41 +1116,191 @@ func handleCoordinationRetrieval() {
  */
 
 func main() {
+	os.Exit(app_generics.ExitCode(run(os.Args[1:], os.Stdout, os.Stderr)))
+}
+
+// Running the app with the given arguments and outputs, returning an exit error when it fails
+func run(arguments []string, stdout, stderr io.Writer) (err error) {
 	// Parsing flags
-	flag.Parse()
+	flags.SetOutput(stderr)
+	parsed, err := app_generics.ParseFlags(flags, arguments)
+	if !parsed {
+		return err
+	}
+
+	// Failing with an error code if errors were reported, once all else is done
+	errorCount := app_generics.ReportedErrorCount()
+	defer func() {
+		if err == nil {
+			err = app_generics.ExitErrorSince(errorCount)
+		}
+	}()
 
-	// Creating the reporter
-	reporter := generics.CreateReporter(*reportLevelFlag, generics.ReportError, generics.ReportProgress)
+	// Only reporting the version, if requested
+	if *versionFlag {
+		app_generics.WriteVersion(stdout, appName, appVersion)
+
+		return nil
+	}
+
+	// Directing the progress reports to the output, and the errors to the error output
+	app_generics.SetReportOutput(stdout, stderr)
+
+	// Shutting down gracefully on SIGINT/SIGTERM
+	shutdownContext, stopSignals := app_generics.ShutdownContext()
+	defer stopSignals()
+
+	// Creating the reporter, where only errors are reported when quiet, or when storing in a temporary file
+	progressLevel := *reportLevelFlag
+	if *quietFlag || *tempFlag {
+		progressLevel = app_generics.ProgressLevelSilent
+	}
+	reporter := app_generics.CreateReporter(progressLevel, *errorReportLevelFlag)
+
+	// Formatting the reports as requested
+	if reporter.MaybeReportError("Error in log format flag:", app_generics.SetLogFormat(*logFormatFlag)) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Directing the reports to a log file, if requested
+	if *logFileFlag != "" {
+		if reporter.MaybeReportError("Error opening log file:", app_generics.SetLogFile(*logFileFlag, *logMaxSizeFlag)) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+	}
+
+	// Scoping to the requested environment, if any
+	configOverrides := []app_generics.TConfigOverride{}
+	if *environmentFlag != "" {
+		// Validating the environment name
+		if reporter.MaybeReportError("Error in environment flag:", app_generics.ValidateEnvironment(*environmentFlag)) {
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		configOverrides = append(configOverrides, app_generics.TConfigOverride{Key: "environment", Value: *environmentFlag})
+	}
+
+	// Validating the timestamp format
+	if !app_generics.IsTimestampFormat(*timestampFormatFlag) {
+		reporter.Error("Unknown timestamp format specified: %s.", *timestampFormatFlag)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Overriding the configuration values given on the command line, where the
+	// dedicated flags above take precedence
+	configOverrides = append(append([]app_generics.TConfigOverride{}, *configSetFlag...), configOverrides...)
+
+	// Only checking the configuration, if requested
+	if *configCheckFlag {
+		return app_generics.CheckConfig(*configFlag, configOverrides, !connect.PostingOnly, reporter)
+	}
 
 	// Loading the configuration
-	configData := generics.LoadConfig(*configFlag, reporter)
+	configData, err := app_generics.LoadConfig(*configFlag, configOverrides, reporter)
+	if err != nil {
+		return err
+	}
+
+	// Checking the required configuration
+	if !app_generics.RequireConfigKeys(configData, reporter, "work_folder") {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
 
 	// Getting the work folder
-	localFilePath = configData.GetValue("", "work_folder").String()
+	workFolder := configData.GetValue("", "work_folder").String()
+
+	// Checking the work folder
+	if reporter.MaybeReportError("Error in work folder:", app_generics.CheckWorkFolder(workFolder, *createWorkFolderFlag)) {
+		return app_generics.ExitWith(app_generics.ExitConfigError)
+	}
 
 	// Creating the Modelling Bus Connector
-	modellingBusConnector = connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
+	modellingBusConnector := connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
+
+	// Creating the retrieval context from the flags
+	retrieval := &TRetrievalContext{
+		Connector: &modellingBusConnector,
+		Bus:       &modellingBusConnector,
+		Reporter:  modellingBusConnector.Reporter,
+		Output:    stdout,
+		Shutdown:  shutdownContext,
+
+		JSONArtefactRetriever: ConnectorJSONArtefactRetrievers(modellingBusConnector),
+
+		Kind:              *retrievalKindFlag,
+		WorkFolder:        workFolder,
+		FileName:          *fileNameFlag,
+		AgentID:           *agentIDFlag,
+		ArtefactID:        *artefactIDFlag,
+		JSONVersion:       *jsonVersionFlag,
+		ObservationID:     *observationIDFlag,
+		CoordinationTopic: *coordinationTopicFlag,
+		At:                *atFlag,
+
+		Wait:                *waitFlag,
+		WaitMode:            *waitModeFlag,
+		WaitTimeout:         *waitTimeoutFlag,
+		Watch:               *watchFlag,
+		SkipEmpty:           *skipEmptyFlag,
+		Temp:                *tempFlag,
+		MaxBytes:            *maxBytesFlag,
+		ProgressInterval:    *progressIntervalFlag,
+		ExpectedSHA256:      *expectedSHA256Flag,
+		Strict:              *strictFlag,
+		TimestampFormat:     *timestampFormatFlag,
+		Pretty:              *prettyFlag,
+		Gzip:                *gzipFlag,
+		SkipTopicValidation: *skipTopicValidationFlag,
+		Retries:             *retriesFlag,
+		RetryBackoff:        *retryBackoffFlag,
+		FailFast:            *failFastFlag,
+	}
+
+	// Running a command for each stored file, if requested
+	if *onChangeFlag != "" {
+		retrieval.OnChange = app_generics.CreateChangeCommand(*onChangeFlag, *debounceFlag, reporter)
+		defer retrieval.OnChange.Flush()
+	}
 
 	// We must always have a retrieval kind
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(retrievalKindFlag, "No retrieval kind specified.") {
-		return
+	if retrieval.Reporter.MaybeReportEmptyFlagError(&retrieval.Kind, "No retrieval kind specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
-	// We also also, always have a file name
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(fileNameFlag, "No file name specified for artefact retrieval.") {
-		return
+	// We also also, always have a file name, except when listing
+	if retrieval.Kind != listRetrieval && retrieval.Kind != coordinationListRetrieval && retrieval.Reporter.MaybeReportEmptyFlagError(&retrieval.FileName, "No file name specified for artefact retrieval.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the retrieval handler
-	retrievalHandler := retrievalHandlers[*retrievalKindFlag]
+	retrievalHandler := retrievalHandlers[retrieval.Kind]
 
 	// Validating retrieval handler
 	if retrievalHandler == nil {
-		modellingBusConnector.Reporter.Error("Unknown retrieval kind specified: %s.", *retrievalKindFlag)
+		retrieval.Reporter.Error("Unknown retrieval kind specified: %s.", retrieval.Kind)
 
-		return
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Retrying retrievals that fail due to transient problems, if requested
+	retrievalHandler = withRetries(retrievalHandler)
+
+	// Retrieving a historical version, if requested
+	if retrieval.At != "" {
+		return retrieveHistoricalArtefact(retrieval)
+	}
+
+	// Retrieving several artefacts, if a list of artefact IDs is given
+	if (retrieval.Kind == rawArtefactRetrieval || retrieval.Kind == jsonArtefactRetrieval) && strings.Contains(retrieval.ArtefactID, ",") {
+		return retrieveArtefacts(retrieval, retrievalHandler)
+	}
+
+	// Retrieving several coordinations, if a topic prefix is given
+	if retrieval.Kind == coordinationRetrieval && isCoordinationPrefix(retrieval.CoordinationTopic) {
+		return retrieveCoordinations(retrieval, retrievalHandler)
 	}
 
 	// Calling the retrieval handler
-	retrievalHandler()
+	return retrievalHandler(retrieval)
 }
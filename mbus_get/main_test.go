@@ -0,0 +1,437 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic get application for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * A fake JSON artefact retriever
+ */
+
+// A JSON artefact retriever holding given versions, which delivers them as postings once
+// listeners for all versions are registered, and takes the given delay for each retrieval
+type TFakeJSONArtefactRetriever struct {
+	state, update, considered TJSONArtefactVersion // The versions of the artefact
+	delay                     time.Duration        // Time taken by each retrieval
+
+	listeners map[string]func() // The listeners, by kind
+	gets      []string          // The retrievals, in order
+	lock      sync.Mutex        // Guarding the listeners and retrievals
+}
+
+func (f *TFakeJSONArtefactRetriever) listen(kind string, handler func()) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.listeners == nil {
+		f.listeners = map[string]func(){}
+	}
+	f.listeners[kind] = handler
+
+	// Delivering the versions as postings, in order, once all listeners are registered
+	if len(f.listeners) == 3 {
+		go func() {
+			for _, kind := range []string{"state", "update", "considered"} {
+				f.listeners[kind]()
+			}
+		}()
+	}
+}
+
+func (f *TFakeJSONArtefactRetriever) get(kind string) {
+	time.Sleep(f.delay)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.gets = append(f.gets, kind)
+}
+
+func (f *TFakeJSONArtefactRetriever) ListenForJSONArtefactStatePostings(_, _ string, handler func()) {
+	f.listen("state", handler)
+}
+
+func (f *TFakeJSONArtefactRetriever) ListenForJSONArtefactUpdatePostings(_, _ string, handler func()) {
+	f.listen("update", handler)
+}
+
+func (f *TFakeJSONArtefactRetriever) ListenForJSONArtefactConsideringPostings(_, _ string, handler func()) {
+	f.listen("considered", handler)
+}
+
+func (f *TFakeJSONArtefactRetriever) GetJSONArtefactConsidering(_, _ string) { f.get("considered") }
+
+func (f *TFakeJSONArtefactRetriever) State() TJSONArtefactVersion      { return f.state }
+func (f *TFakeJSONArtefactRetriever) Update() TJSONArtefactVersion     { return f.update }
+func (f *TFakeJSONArtefactRetriever) Considered() TJSONArtefactVersion { return f.considered }
+
+/*
+ * Test support
+ */
+
+// Creating a retrieval of the given JSON artefact, storing into a temporary work folder
+func testJSONArtefactRetrieval(t *testing.T, artefactRetriever TJSONArtefactRetriever) *TRetrievalContext {
+	return &TRetrievalContext{
+		Reporter: generics.CreateReporter(app_generics.ProgressLevelSilent,
+			func(message string) { t.Errorf("reported error: %s", message) },
+			func(string) {}),
+
+		Shutdown: context.Background(),
+
+		JSONArtefactRetriever: func(string, string) TJSONArtefactRetriever { return artefactRetriever },
+
+		Kind:        jsonArtefactRetrieval,
+		WorkFolder:  t.TempDir(),
+		FileName:    "model",
+		AgentID:     "agent",
+		ArtefactID:  "university",
+		JSONVersion: "1.0",
+
+		WaitMode:        waitModeAll,
+		TimestampFormat: app_generics.TimestampRaw,
+	}
+}
+
+// Listing the JSON files stored by a retrieval
+func storedJSONFiles(t *testing.T, retrieval *TRetrievalContext) []string {
+	stored, err := filepath.Glob(filepath.Join(retrieval.WorkFolder, "*"+jsonExtension))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, path := range stored {
+		names = append(names, filepath.Base(path))
+	}
+
+	return names
+}
+
+/*
+ * Retrieving the latest JSON artefact version
+ */
+
+// Waiting for the latest version, where all three versions arrive with different timestamps,
+// only saves the newest one
+func TestLatestWaitMode(t *testing.T) {
+	tests := []struct {
+		name                      string
+		state, update, considered string // Timestamps of the versions
+		want                      string // The only file to be saved
+	}{
+		{"state newest", "2025-12-18-10-22-35-00", "2025-12-18-10-22-33-00", "2025-12-18-10-22-34-00", "state_model.json"},
+		{"update newest", "2025-12-18-10-22-33-00", "2025-12-18-10-22-35-00", "2025-12-18-10-22-34-00", "update_model.json"},
+		{"considered newest", "2025-12-18-10-22-33-00", "2025-12-18-10-22-34-00", "2025-12-18-10-22-35-00", "considered_model.json"},
+		{"unknown timestamps skipped", "2025-12-18-10-22-33-00", "", "", "state_model.json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			artefactRetriever := &TFakeJSONArtefactRetriever{
+				state:      TJSONArtefactVersion{Content: []byte(`{"version":"state"}`), Timestamp: test.state},
+				update:     TJSONArtefactVersion{Content: []byte(`{"version":"update"}`), Timestamp: test.update},
+				considered: TJSONArtefactVersion{Content: []byte(`{"version":"considered"}`), Timestamp: test.considered},
+			}
+			retrieval := testJSONArtefactRetrieval(t, artefactRetriever)
+			retrieval.Wait = true
+			retrieval.WaitMode = waitModeLatest
+
+			if err := handleJSONArtefactRetrieval(retrieval); err != nil {
+				t.Fatalf("retrieval failed: %v", err)
+			}
+
+			if got := storedJSONFiles(t, retrieval); !slices.Equal(got, []string{test.want}) {
+				t.Errorf("saved %v, want only %s", got, test.want)
+			}
+		})
+	}
+}
+
+// Retrieving the latest version immediately also only saves the newest one
+func TestLatestImmediate(t *testing.T) {
+	artefactRetriever := &TFakeJSONArtefactRetriever{
+		state:      TJSONArtefactVersion{Content: []byte(`{"version":"state"}`), Timestamp: "2025-12-18-10-22-33-00"},
+		update:     TJSONArtefactVersion{Content: []byte(`{"version":"update"}`), Timestamp: "2025-12-18-10-22-35-00"},
+		considered: TJSONArtefactVersion{Content: []byte(`{"version":"considered"}`), Timestamp: "2025-12-18-10-22-34-00"},
+	}
+	retrieval := testJSONArtefactRetrieval(t, artefactRetriever)
+	retrieval.WaitMode = waitModeLatest
+
+	if err := handleJSONArtefactRetrieval(retrieval); err != nil {
+		t.Fatalf("retrieval failed: %v", err)
+	}
+
+	if got := storedJSONFiles(t, retrieval); !slices.Equal(got, []string{"update_model.json"}) {
+		t.Errorf("saved %v, want only update_model.json", got)
+	}
+	content, err := os.ReadFile(filepath.Join(retrieval.WorkFolder, "update_model.json"))
+	if err != nil || string(content) != `{"version":"update"}` {
+		t.Errorf("saved %q (%v), want the update", content, err)
+	}
+}
+
+// Waiting for the first posting skips an empty posting when requested, so only the real
+// posting following it is saved
+func TestSkipEmptyWaitMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		skipEmpty bool
+		want      string // The only file to be saved
+	}{
+		{"empty posting skipped", true, "update_model.json"},
+		{"empty posting saved", false, "state_model.json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			artefactRetriever := &TFakeJSONArtefactRetriever{
+				state:      TJSONArtefactVersion{Content: []byte(`{}`), Timestamp: "2025-12-18-10-22-33-00"},
+				update:     TJSONArtefactVersion{Content: []byte(`{"version":"update"}`), Timestamp: "2025-12-18-10-22-34-00"},
+				considered: TJSONArtefactVersion{Content: []byte(`{"version":"considered"}`), Timestamp: "2025-12-18-10-22-35-00"},
+			}
+			retrieval := testJSONArtefactRetrieval(t, artefactRetriever)
+			retrieval.Wait = true
+			retrieval.WaitMode = waitModeFirst
+			retrieval.SkipEmpty = test.skipEmpty
+
+			if err := handleJSONArtefactRetrieval(retrieval); err != nil {
+				t.Fatalf("retrieval failed: %v", err)
+			}
+
+			if got := storedJSONFiles(t, retrieval); !slices.Equal(got, []string{test.want}) {
+				t.Errorf("saved %v, want only %s", got, test.want)
+			}
+		})
+	}
+}
+
+/*
+ * Retrieving the parts of a JSON artefact
+ */
+
+// Retrieving a JSON artefact immediately takes a single chained retrieval, rather than one per
+// version, while still saving all versions
+func TestJSONArtefactPartsRetrievedOnce(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	artefactRetriever := &TFakeJSONArtefactRetriever{
+		state:      TJSONArtefactVersion{Content: []byte(`{"version":"state"}`), Timestamp: "2025-12-18-10-22-33-00"},
+		update:     TJSONArtefactVersion{Content: []byte(`{"version":"update"}`), Timestamp: "2025-12-18-10-22-34-00"},
+		considered: TJSONArtefactVersion{Content: []byte(`{"version":"considered"}`), Timestamp: "2025-12-18-10-22-35-00"},
+		delay:      delay,
+	}
+	retrieval := testJSONArtefactRetrieval(t, artefactRetriever)
+
+	started := time.Now()
+	if err := handleJSONArtefactRetrieval(retrieval); err != nil {
+		t.Fatalf("retrieval failed: %v", err)
+	}
+	elapsed := time.Since(started)
+
+	if elapsed >= 2*delay {
+		t.Errorf("retrieval took %s, want about %s, the time of a single retrieval", elapsed, delay)
+	}
+	if !slices.Equal(artefactRetriever.gets, []string{"considered"}) {
+		t.Errorf("retrieved %v, want [considered]", artefactRetriever.gets)
+	}
+	if got, want := storedJSONFiles(t, retrieval), []string{"considered_model.json", "state_model.json", "update_model.json"}; !slices.Equal(got, want) {
+		t.Errorf("saved %v, want %v", got, want)
+	}
+}
+
+/*
+ * Dispatching retrievals
+ */
+
+// Each retrieval kind is dispatched to its handler, which stores the posting as set up by the
+// retrieval context, or fails with a usage error when a required parameter is missing
+func TestRetrievalDispatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		retrieval TRetrievalContext
+		stored    string // Name of the stored file
+		content   string // Content of the stored file
+		exitCode  int
+	}{
+		{
+			name:      "JSON observation",
+			retrieval: TRetrievalContext{Kind: jsonObservationRetrieval, ObservationID: "sensors/temperature"},
+			stored:    "model.json",
+			content:   `{"celsius":21}`,
+		},
+		{
+			name:      "pretty JSON observation",
+			retrieval: TRetrievalContext{Kind: jsonObservationRetrieval, ObservationID: "sensors/temperature", Pretty: true},
+			stored:    "model.json",
+			content:   "{\n  \"celsius\": 21\n}",
+		},
+		{
+			name:      "compressed streamed observation",
+			retrieval: TRetrievalContext{Kind: streamedObservationRetrieval, ObservationID: "sensors/humidity", Gzip: true},
+			stored:    "model.json" + app_generics.GzipExtension,
+		},
+		{
+			name:      "coordination",
+			retrieval: TRetrievalContext{Kind: coordinationRetrieval, CoordinationTopic: "render/request"},
+			stored:    "model.json",
+			content:   `{"model":"university"}`,
+		},
+		{
+			name:      "JSON observation without observation ID",
+			retrieval: TRetrievalContext{Kind: jsonObservationRetrieval},
+			exitCode:  app_generics.ExitUsageError,
+		},
+		{
+			name:      "invalid coordination topic",
+			retrieval: TRetrievalContext{Kind: coordinationRetrieval, CoordinationTopic: "render/#"},
+			exitCode:  app_generics.ExitUsageError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bus := app_generics.CreateFakeModellingBus("agent", t.TempDir())
+			bus.PostJSONObservation("sensors/temperature", []byte(`{"celsius":21}`))
+			bus.PostStreamedObservation("sensors/humidity", []byte(`{"percent":40}`))
+			bus.PostCoordination("render/request", []byte(`{"model":"university"}`))
+
+			retrieval := test.retrieval
+			retrieval.Bus = bus
+			retrieval.Reporter = generics.CreateReporter(app_generics.ProgressLevelSilent, func(string) {}, func(string) {})
+			retrieval.Shutdown = context.Background()
+			retrieval.WorkFolder = t.TempDir()
+			retrieval.FileName = "model"
+			retrieval.AgentID = "agent"
+			retrieval.WaitMode = waitModeAll
+			retrieval.TimestampFormat = app_generics.TimestampRaw
+
+			err := retrievalHandlers[retrieval.Kind](&retrieval)
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v)", exitCode, test.exitCode, err)
+			}
+
+			if test.exitCode != 0 {
+				return
+			}
+
+			content, err := os.ReadFile(filepath.Join(retrieval.WorkFolder, test.stored))
+			if err != nil {
+				t.Fatalf("nothing stored: %v", err)
+			}
+			if test.content != "" && string(content) != test.content {
+				t.Errorf("stored %q, want %q", content, test.content)
+			}
+		})
+	}
+}
+
+// Retrieving a historical version requires a JSON artefact, a valid time, and the flags
+// identifying the artefact, before the modelling bus is used
+func TestHistoricalRetrievalValidation(t *testing.T) {
+	artefact := TRetrievalContext{Kind: jsonArtefactRetrieval, ArtefactID: "university", JSONVersion: "cdm_v1_0_v1_0", AgentID: "agent"}
+
+	tests := []struct {
+		name   string
+		change func(*TRetrievalContext)
+	}{
+		{"not a JSON artefact", func(r *TRetrievalContext) { r.Kind = jsonObservationRetrieval }},
+		{"invalid time", func(r *TRetrievalContext) { r.At = "yesterday" }},
+		{"without artefact ID", func(r *TRetrievalContext) { r.ArtefactID = "" }},
+		{"without JSON version", func(r *TRetrievalContext) { r.JSONVersion = "" }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errors := 0
+			retrieval := artefact
+			retrieval.At = "2025-12-16T10:00:00Z"
+			retrieval.Reporter = generics.CreateReporter(app_generics.ProgressLevelSilent, func(string) { errors++ }, func(string) {})
+			test.change(&retrieval)
+
+			err := retrieveHistoricalArtefact(&retrieval)
+			if exitCode := app_generics.ExitCode(err); exitCode != app_generics.ExitUsageError || errors == 0 {
+				t.Errorf("exit code = %d with %d reported error(s), want %d and a reported error", exitCode, errors, app_generics.ExitUsageError)
+			}
+		})
+	}
+}
+
+// Running the app with command line arguments, capturing its outputs, exits with the exit code
+// of the outcome, without needing a modelling bus when failing before connecting to it
+func TestRunCommandLine(t *testing.T) {
+	missingConfig := filepath.Join(t.TempDir(), "missing.ini")
+
+	tests := []struct {
+		name      string
+		arguments []string
+		exitCode  int
+		stdout    string
+		stderr    string
+	}{
+		{"version", []string{"-version"}, 0, "mbus_get, version of " + appVersion, ""},
+		{"help", []string{"-h"}, 0, "", "-kind string"},
+		{"unknown flag", []string{"-colour"}, app_generics.ExitUsageError, "", "flag provided but not defined: -colour"},
+		{"missing configuration", []string{"-config", missingConfig, "-kind", jsonObservationRetrieval}, app_generics.ExitConfigError, "", "Error loading configuration file:"},
+	}
+
+	t.Cleanup(func() { app_generics.SetReportOutput(os.Stdout, os.Stderr) })
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(test.arguments, &stdout, &stderr)
+
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v, output: %s%s)", exitCode, test.exitCode, err, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), test.stdout) {
+				t.Errorf("output does not contain %q:\n%s", test.stdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("error output does not contain %q:\n%s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+// The supported kinds are the kinds of the handler map, in sorted order, each explained by the kind flag
+func TestSupportedKinds(t *testing.T) {
+	kinds := SupportedKinds()
+	if !slices.IsSorted(kinds) || len(kinds) != len(retrievalHandlers) {
+		t.Errorf("SupportedKinds() = %q, want the sorted kinds of the retrieval handlers", kinds)
+	}
+
+	usage := flags.Lookup("kind").Usage
+	for _, kind := range kinds {
+		if _, handled := retrievalHandlers[kind]; !handled {
+			t.Errorf("supported kind %q has no retrieval handler", kind)
+		}
+		if !strings.Contains(usage, kind) {
+			t.Errorf("kind flag usage %q does not explain %q", usage, kind)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports inferring the posting kind, when -auto is given
+ * without -kind, from the extension and content of the file to post:
+ *   - JSON content (or a .json file) is posted as a JSON artefact when an artefact ID is
+ *     given, as a coordination when a coordination topic is given, and as a JSON
+ *     observation otherwise;
+ *   - other content is posted as a raw artefact when an artefact ID is given, and as a raw
+ *     observation otherwise.
+ * When the kind cannot be inferred unambiguously, an explicit -kind is needed.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"app_generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	jsonExtension = ".json" // Extension of JSON files
+)
+
+/*
+ * Inferring posting kinds
+ */
+
+// Inferring the posting kind from the parameters of the posting, and the extension and content of the file to post
+func inferPostingKind(posting *TPostingContext) (string, error) {
+	// The ID flags must point to a single kind of posting
+	idFlagCount := 0
+	for _, idFlag := range []string{posting.ArtefactID, posting.ObservationID, posting.CoordinationTopic} {
+		if idFlag != "" {
+			idFlagCount++
+		}
+	}
+	if idFlagCount > 1 {
+		return "", errors.New("more than one of -artefact_id, -observation_id, and -coordination_topic given; please specify -kind")
+	}
+
+	// Determining if the content is JSON
+	isJSON, err := isJSONContent(posting)
+	if err != nil {
+		return "", err
+	}
+
+	// Determining the kind
+	switch {
+	case isJSON && posting.ArtefactID != "":
+		return jsonArtefactPosting, nil
+	case isJSON && posting.CoordinationTopic != "":
+		return coordinationPosting, nil
+	case isJSON:
+		return jsonObservationPosting, nil
+	case posting.CoordinationTopic != "":
+		return "", errors.New("coordinations must be JSON; please specify -kind")
+	case posting.ArtefactID != "":
+		return rawArtefactPosting, nil
+	default:
+		return rawObservationPosting, nil
+	}
+}
+
+// Determining if the content to post is JSON, based on the extension and content of the file
+func isJSONContent(posting *TPostingContext) (bool, error) {
+	// JSON content given on the command line, or a JSON template, which need not be valid
+	// JSON before it is expanded
+	if posting.JSON != "" || posting.Template {
+		return true, nil
+	}
+
+	// We need a file whose content can be inspected
+	switch {
+	case posting.File == "":
+		return false, errors.New("no file or JSON content given; please specify -kind")
+	case posting.File == stdinFile:
+		return false, errors.New("the kind of content on the standard input cannot be inferred; please specify -kind")
+	case isDirectory(posting.File):
+		return false, errors.New("the kind of the files in a directory cannot be inferred; please specify -kind")
+	}
+
+	// Reading the content, decompressing it if requested
+	fileName := posting.File
+	var content []byte
+	var err error
+	if posting.Gunzip && app_generics.IsGzipFile(fileName) {
+		content, err = app_generics.ReadGzipFile(fileName)
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	} else {
+		content, err = os.ReadFile(fileName)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// A .json file must have JSON content, while other files are JSON if their content is
+	hasJSONExtension := strings.EqualFold(filepath.Ext(fileName), jsonExtension)
+	isJSON := json.Valid(content)
+	if hasJSONExtension && !isJSON {
+		return false, errors.New("the " + jsonExtension + " file does not contain valid JSON; please specify -kind")
+	}
+
+	return isJSON, nil
+}
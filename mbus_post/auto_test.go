@@ -0,0 +1,72 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Inferring the posting kind from the extension and content of the file, and the ID flags
+func TestInferPostingKind(t *testing.T) {
+	folder := t.TempDir()
+	files := map[string][]byte{
+		"model.json":   []byte(`{"name":"University"}`),
+		"broken.json":  []byte(`{"name":`),
+		"diagram.png":  {0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0xff},
+		"readings.txt": []byte(`[21, 22, 23]`),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(folder, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := func(name string) string { return filepath.Join(folder, name) }
+
+	tests := []struct {
+		name      string
+		posting   TPostingContext
+		want      string
+		expectErr bool
+	}{
+		{name: "json file", posting: TPostingContext{File: file("model.json")}, want: jsonObservationPosting},
+		{name: "json file with artefact ID", posting: TPostingContext{File: file("model.json"), ArtefactID: "models/university"}, want: jsonArtefactPosting},
+		{name: "json file with coordination topic", posting: TPostingContext{File: file("model.json"), CoordinationTopic: "render/request"}, want: coordinationPosting},
+		{name: "json content of other file", posting: TPostingContext{File: file("readings.txt")}, want: jsonObservationPosting},
+		{name: "binary file", posting: TPostingContext{File: file("diagram.png")}, want: rawObservationPosting},
+		{name: "binary file with artefact ID", posting: TPostingContext{File: file("diagram.png"), ArtefactID: "diagrams/university"}, want: rawArtefactPosting},
+		{name: "json flag", posting: TPostingContext{JSON: `{}`}, want: jsonObservationPosting},
+		{name: "binary file with coordination topic", posting: TPostingContext{File: file("diagram.png"), CoordinationTopic: "render/request"}, expectErr: true},
+		{name: "invalid json file", posting: TPostingContext{File: file("broken.json")}, expectErr: true},
+		{name: "several ID flags", posting: TPostingContext{File: file("model.json"), ArtefactID: "models/university", ObservationID: "models/university"}, expectErr: true},
+		{name: "no file", posting: TPostingContext{}, expectErr: true},
+		{name: "standard input", posting: TPostingContext{File: stdinFile}, expectErr: true},
+		{name: "directory", posting: TPostingContext{File: folder}, expectErr: true},
+		{name: "missing file", posting: TPostingContext{File: file("missing.json")}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kind, err := inferPostingKind(&test.posting)
+			switch {
+			case test.expectErr && err == nil:
+				t.Errorf("inferPostingKind() = %q, want an error", kind)
+			case !test.expectErr && err != nil:
+				t.Errorf("inferPostingKind() error: %v", err)
+			case kind != test.want:
+				t.Errorf("inferPostingKind() = %q, want %q", kind, test.want)
+			}
+		})
+	}
+}
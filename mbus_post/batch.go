@@ -0,0 +1,246 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports posting a batch of artefact versions,
+ * taken from a directory or a glob pattern, with auto-incrementing artefact IDs, as
+ * well as posting all files in a directory, with IDs derived from their file names.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	batchIDWidth = 4 // Minimal number of digits in the sequence number of batch artefact IDs
+
+	fileNamePlaceholder = "{file}" // Placeholder for the file name in ID templates
+	baseNamePlaceholder = "{name}" // Placeholder for the file name without its extension in ID templates
+)
+
+/*
+ * Batch support
+ */
+
+// Checking if a path refers to a directory
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// Checking if a file is hidden, i.e. its name starts with a '.'
+func isHiddenFile(file string) bool {
+	return strings.HasPrefix(filepath.Base(file), ".")
+}
+
+// Collecting the files in a directory, or matching a glob pattern, in sorted order.
+// Hidden files, such as .DS_Store, are skipped unless includeHidden is set.
+func batchFiles(pattern string, includeHidden bool) ([]string, error) {
+	files := []string{}
+
+	// Checking if we are dealing with a directory
+	if isDirectory(pattern) {
+		entries, err := os.ReadDir(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only taking the files in the directory
+		for _, entry := range entries {
+			if !entry.IsDir() && (includeHidden || !isHiddenFile(entry.Name())) {
+				files = append(files, filepath.Join(pattern, entry.Name()))
+			}
+		}
+	} else {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only taking the files that match
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() && (includeHidden || !isHiddenFile(match)) {
+				files = append(files, match)
+			}
+		}
+	}
+
+	// Ensuring a deterministic order
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Creating the artefact ID for the given (1-based) position in a batch of the given size.
+// The sequence number is padded consistently, so all IDs in a batch have the same length.
+func batchArtefactID(prefix string, sequence, count int) string {
+	width := max(batchIDWidth, len(strconv.Itoa(count)))
+
+	return fmt.Sprintf("%s-%0*d", prefix, width, sequence)
+}
+
+// Handling the posting of a batch of artefacts
+func handleBatchPosting(posting *TPostingContext, postingHandler TPostingHandler) error {
+	// Batches are only supported for artefacts
+	if posting.Kind != rawArtefactPosting && posting.Kind != jsonArtefactPosting {
+		posting.Reporter.Error("Batch posting is only supported for artefact postings.")
+
+		return nil
+	}
+
+	// We need a prefix for the artefact IDs
+	if posting.Reporter.MaybeReportEmptyFlagError(&posting.IDPrefix, "No artefact ID prefix specified for batch posting.") {
+		return nil
+	}
+
+	// Collecting the files to post
+	files, err := batchFiles(posting.Batch, posting.IncludeHidden)
+	if posting.Reporter.MaybeReportError("Error collecting files for batch posting:", err) {
+		return nil
+	}
+
+	// Limiting the batch to the requested count
+	if posting.Count > 0 {
+		if posting.Count > len(files) {
+			posting.Reporter.Error("Only %d file(s) found for a batch of %d.", len(files), posting.Count)
+
+			return nil
+		}
+
+		files = files[:posting.Count]
+	}
+
+	// Determining the artefact IDs of the files
+	artefactIDs := map[string]string{}
+	for sequence, file := range files {
+		artefactIDs[file] = batchArtefactID(posting.IDPrefix, sequence+1, len(files))
+	}
+
+	// Posting the files, one at a time, as the errors reported by each posting are counted
+	results := app_generics.RunTasks(app_generics.Sequential, posting.FailFast, files, func(file string) error {
+		filePosting := *posting
+		filePosting.File = file
+		filePosting.ArtefactID = artefactIDs[file]
+
+		// Reporting progress
+		posting.Reporter.Progress(generics.ProgressLevelBasic, "Posting %s as: %s", file, filePosting.ArtefactID)
+
+		// Posting the file
+		return app_generics.ErrorsReportedBy(func() error { return postingHandler(&filePosting) })
+	})
+
+	// Reporting the summary
+	if !app_generics.ReportTaskSummary(posting.Reporter, "Posted", "files", results) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	return nil
+}
+
+/*
+ * Directory support
+ */
+
+// Determining the field of the posting context holding the ID, or topic, of the posting kind
+func postingID(posting *TPostingContext) *string {
+	switch posting.Kind {
+	case rawArtefactPosting, jsonArtefactPosting:
+		return &posting.ArtefactID
+	case coordinationPosting:
+		return &posting.CoordinationTopic
+	default:
+		return &posting.ObservationID
+	}
+}
+
+// Expanding an ID template for the given file, by replacing the {file} and {name} placeholders
+func expandIDTemplate(template, file string) string {
+	fileName := filepath.Base(file)
+	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+
+	return strings.NewReplacer(fileNamePlaceholder, fileName, baseNamePlaceholder, baseName).Replace(template)
+}
+
+// Collecting the files in a directory, in sorted order, only taking those whose name matches the glob pattern (if any)
+func directoryFiles(directory, glob string, includeHidden bool) ([]string, error) {
+	files, err := batchFiles(directory, includeHidden)
+	if err != nil || glob == "" {
+		return files, err
+	}
+
+	// Filtering the files
+	matchingFiles := []string{}
+	for _, file := range files {
+		matches, err := filepath.Match(glob, filepath.Base(file))
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			matchingFiles = append(matchingFiles, file)
+		}
+	}
+
+	return matchingFiles, nil
+}
+
+// Handling the posting of all files in a directory, where the ID flag of the posting kind
+// serves as template for the IDs, e.g. -observation_id sensors/{name}
+func handleDirectoryPosting(posting *TPostingContext, postingHandler TPostingHandler) error {
+	directory := posting.File
+
+	// Collecting the files to post
+	files, err := directoryFiles(directory, posting.Glob, posting.IncludeHidden)
+	if posting.Reporter.MaybeReportError("Error collecting files for directory posting:", err) {
+		return nil
+	}
+
+	// Determining the ID template, defaulting to the file name without its extension
+	idTemplate := *postingID(posting)
+	if idTemplate == "" {
+		idTemplate = baseNamePlaceholder
+	}
+
+	// Posting the files, one at a time, as the errors reported by each posting are counted
+	results := app_generics.RunTasks(app_generics.Sequential, posting.FailFast, files, func(file string) error {
+		filePosting := *posting
+		filePosting.File = file
+		*postingID(&filePosting) = expandIDTemplate(idTemplate, file)
+
+		// Reporting progress
+		posting.Reporter.Progress(generics.ProgressLevelBasic, "Posting %s as: %s", file, *postingID(&filePosting))
+
+		// Posting the file
+		return app_generics.ErrorsReportedBy(func() error { return postingHandler(&filePosting) })
+	})
+
+	// Reporting the summary
+	if !app_generics.ReportTaskSummary(posting.Reporter, "Posted", "files", results) {
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	return nil
+}
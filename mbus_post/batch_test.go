@@ -0,0 +1,113 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Writing files with the given names to a temporary directory
+func writeBatchFiles(t *testing.T, names ...string) string {
+	directory := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(`{"name":"`+name+`"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return directory
+}
+
+// Posting a batch gives the (first count) files, in sorted order, consistently padded auto-incrementing artefact IDs
+func TestBatchArtefactIDs(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		count int
+		want  []string
+	}{
+		{"three files", []string{"c.json", "a.json", "b.json"}, 0, []string{"university-0001", "university-0002", "university-0003"}},
+		{"first three of four files", []string{"d.json", "c.json", "a.json", "b.json"}, 3, []string{"university-0001", "university-0002", "university-0003"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posting, _ := testPosting(t, false)
+			posting.Kind = jsonArtefactPosting
+			posting.Batch = writeBatchFiles(t, test.files...)
+			posting.IDPrefix = "university"
+			posting.Count = test.count
+
+			// Collecting the artefact IDs the files are posted with
+			posted := []string{}
+			postedFiles := []string{}
+			err := handleBatchPosting(posting, func(filePosting *TPostingContext) error {
+				posted = append(posted, filePosting.ArtefactID)
+				postedFiles = append(postedFiles, filepath.Base(filePosting.File))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(posted, test.want) {
+				t.Errorf("posted with artefact IDs %q, want %q", posted, test.want)
+			}
+			if wantFiles := slices.Sorted(slices.Values(test.files))[:len(test.want)]; !slices.Equal(postedFiles, wantFiles) {
+				t.Errorf("posted files %q, want %q", postedFiles, wantFiles)
+			}
+		})
+	}
+
+	if got, want := batchArtefactID("university", 7, 12345), "university-00007"; got != want {
+		t.Errorf("batchArtefactID() = %q, want %q", got, want)
+	}
+}
+
+// Hidden files are skipped when posting a directory or the files matching a glob pattern,
+// unless they are explicitly included
+func TestBatchFilesHidden(t *testing.T) {
+	directory := writeBatchFiles(t, "model.json", ".hidden", ".DS_Store")
+
+	tests := []struct {
+		name          string
+		pattern       string
+		includeHidden bool
+		want          []string
+	}{
+		{"directory", directory, false, []string{"model.json"}},
+		{"directory including hidden files", directory, true, []string{".DS_Store", ".hidden", "model.json"}},
+		{"glob", filepath.Join(directory, "*"), false, []string{"model.json"}},
+		{"glob including hidden files", filepath.Join(directory, ".*"), true, []string{".DS_Store", ".hidden"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := batchFiles(test.pattern, test.includeHidden)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := []string{}
+			for _, file := range files {
+				names = append(names, filepath.Base(file))
+			}
+			if !slices.Equal(names, test.want) {
+				t.Errorf("batchFiles(%q, %t) = %q, want %q", test.pattern, test.includeHidden, names, test.want)
+			}
+		})
+	}
+}
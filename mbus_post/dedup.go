@@ -0,0 +1,89 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports skipping observations that are identical to
+ * the previously posted one, when posting several observations in one run.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Key variables
+ */
+
+// The posting kinds that post observations
+var observationPosting = map[string]bool{
+	rawObservationPosting:      true,
+	jsonObservationPosting:     true,
+	streamedObservationPosting: true,
+}
+
+/*
+ * Deduplication support
+ */
+
+// The deduplication of the observations posted in a run
+type TDeduplication struct {
+	LastPostedHash string // Content hash of the most recently posted observation
+	Count          int    // Number of observations skipped
+}
+
+// Determining the content hash of the observation to post, taken from its file or JSON content
+func observationHash(posting *TPostingContext) (string, error) {
+	content := []byte(posting.JSON)
+	if len(content) == 0 {
+		var err error
+		if content, err = os.ReadFile(posting.File); err != nil {
+			return "", err
+		}
+	}
+
+	hash := sha256.Sum256(content)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Wrapping a posting handler, so it skips observations identical to the previously posted one
+func withDeduplication(postingHandler TPostingHandler) TPostingHandler {
+	return func(posting *TPostingContext) error {
+		// Determining the content hash, posting anyway if this fails
+		hash, err := observationHash(posting)
+		if err != nil {
+			return postingHandler(posting)
+		}
+
+		// Skipping identical consecutive observations
+		if hash == posting.Deduplication.LastPostedHash {
+			posting.Deduplication.Count++
+			posting.Reporter.Progress(generics.ProgressLevelBasic, "Skipping observation identical to the previous one: %s", posting.File)
+
+			return nil
+		}
+
+		// Posting the observation, only remembering it when posted successfully
+		err = app_generics.ErrorsReportedBy(func() error { return postingHandler(posting) })
+		if err == nil {
+			posting.Deduplication.LastPostedHash = hash
+		}
+
+		return err
+	}
+}
@@ -16,8 +16,16 @@
 package main
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"flag"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"time"
+
+	"app_generics"
 
 	"github.com/erikproper/big-modelling-bus.go.v1/connect"
 	"github.com/erikproper/big-modelling-bus.go.v1/generics"
@@ -36,213 +44,439 @@ const (
 	jsonObservationPosting     = "json_observation"     // JSON observation posting kind
 	streamedObservationPosting = "streamed_observation" // Streamed observation posting kinds
 	coordinationPosting        = "coordination"         // Coordination posting kind
+	plantUMLModelPosting       = "plantuml_model"       // PlantUML model posting kind
+
+	stdinFile = "-" // File name standing for the standard input
+
+	appName = "mbus_post" // Name of the app, as reported by -version
 )
 
+// Version of the app, as reported by -version; can be set at build time using
+// -ldflags "-X main.appVersion=..."
+var appVersion = "18.12.2025"
+
 /*
  * Key variables
  */
 
 var (
-	modellingBusConnector connect.TModellingBusConnector // The Modelling Bus Connector
+	// The flags of the app
+	flags = flag.NewFlagSet(appName, flag.ContinueOnError)
 
 	// Handlers for different posting kinds
-	postingHandlers = map[string]func(){
+	postingHandlers = map[string]TPostingHandler{
 		rawArtefactPosting:         handleRawArtefactPosting,         // Handler for raw artefact posting
 		jsonArtefactPosting:        handleJSONArtefactPosting,        // Handler for JSON artefact posting
 		rawObservationPosting:      handleRawObservationPosting,      // Handler for raw observation posting
 		jsonObservationPosting:     handleJSONObservationPosting,     // Handler for JSON observation posting
 		streamedObservationPosting: handleStreamedObservationPosting, // Handler for streamed observation posting
 		coordinationPosting:        handleCoordinationPosting,        // Handler for coordination posting
+		plantUMLModelPosting:       handlePlantUMLModelPosting,       // Handler for PlantUML model posting
 	}
 
-	// Explaining the posting kind flag
-	postingKindExplain = "Kind of posting to make. One of: " +
-		rawArtefactPosting + ", " +
-		jsonArtefactPosting + ", " +
-		rawObservationPosting + ", " +
-		jsonObservationPosting + ", " +
-		streamedObservationPosting + ", or " +
-		coordinationPosting + "."
-
-	configFlag            = flag.String("config", defaultIni, "Configuration file")                  // Configuration file flag
-	reportLevelFlag       = flag.Int("reporting", generics.ProgressLevelBasic, "Reporting level")    // Reporting level flag
-	observationIDFlag     = flag.String("observation_id", "", "Observation ID")                      // Observation ID flag
-	agentIDFlag           = flag.String("agent_id", "", "Agent ID")                                  // Agent ID flag
-	coordinationTopicFlag = flag.String("coordination_topic", "", "Coordination topic path")         // Coordination topic path flag
-	postingKindFlag       = flag.String("kind", "", postingKindExplain)                              // Posting kind flag
-	fileFlag              = flag.String("file", "", "File to post")                                  // File to post flag
-	jsonFlag              = flag.String("json", "", "JSON content to post")                          // JSON content to post flag
-	jsonVersionFlag       = flag.String("json_version", "", "JSON version of JSON artefact content") // JSON version flag
-	artefactIDFlag        = flag.String("artefact_id", "", "Artefact ID")                            // Artefact ID flag
+	configFlag              = flags.String("config", defaultIni, "Configuration file")                                                                                                                 // Configuration file flag
+	versionFlag             = flags.Bool("version", false, "Report the version of the app, and exit")                                                                                                  // Version flag
+	configSetFlag           = app_generics.ConfigOverridesFlag(flags, "set", "Configuration value to override, as key=value or section.key=value (repeatable)")                                        // Configuration override flag
+	reportLevelFlag         = flags.Int("reporting", generics.ProgressLevelBasic, "Reporting level")                                                                                                   // Reporting level flag
+	quietFlag               = flags.Bool("quiet", false, "Only report errors, suppressing all progress reports (same as -reporting=0)")                                                                // Quiet flag
+	errorReportLevelFlag    = flags.Int("error_reporting", generics.ProgressLevelBasic, "Error reporting level")                                                                                       // Error reporting level flag
+	logFileFlag             = flags.String("log_file", "", "Log file to write the reports to, instead of the standard output and standard error")                                                      // Log file flag
+	logMaxSizeFlag          = flags.Int64("log_max_size", 0, "Size in bytes beyond which the log file is rotated (0 for no rotation)")                                                                 // Log file rotation size flag
+	logFormatFlag           = flags.String("log_format", app_generics.TextLogFormat, "Format of the reports: text or json")                                                                            // Log format flag
+	configCheckFlag         = flags.Bool("config_check", false, "Only check that the connector can be created from the configuration, and exit")                                                       // Configuration check flag
+	observationIDFlag       = flags.String("observation_id", "", "Observation ID; a template that may include {file} and {name} when posting a directory")                                             // Observation ID flag
+	agentIDFlag             = flags.String("agent_id", "", "Agent ID; the target agent for coordinations, and the owning agent for other postings")                                                    // Agent ID flag
+	coordinationTopicFlag   = flags.String("coordination_topic", "", "Coordination topic path; a template that may include {file} and {name} when posting a directory")                                // Coordination topic path flag
+	skipTopicValidationFlag = flags.Bool("skip_topic_validation", false, "Do not validate topic paths before using them")                                                                              // Skip topic validation flag
+	postingKindFlag         = flags.String("kind", "", "Kind of posting to make")                                                                                                                      // Posting kind flag
+	autoFlag                = flags.Bool("auto", false, "Infer the kind of posting from the extension and content of the file, unless -kind is given")                                                 // Auto kind flag
+	fileFlag                = flags.String("file", "", "File, or directory of files, to post; '"+stdinFile+"' reads JSON payloads from the standard input")                                            // File to post flag
+	jsonFlag                = flags.String("json", "", "JSON content to post")                                                                                                                         // JSON content to post flag
+	jsonVersionFlag         = flags.String("json_version", "", "JSON version of JSON artefact content")                                                                                                // JSON version flag
+	artefactIDFlag          = flags.String("artefact_id", "", "Artefact ID; a template that may include {file} and {name} when posting a directory")                                                   // Artefact ID flag
+	environmentFlag         = flags.String("environment", "", "Environment to scope the operation to")                                                                                                 // Environment flag
+	envelopeFlag            = flags.Bool("envelope", false, "Wrap the JSON payload in an envelope with metadata")                                                                                      // Envelope flag
+	correlationIDFlag       = flags.String("correlation_id", "", "Correlation ID to attach to the posting, in an envelope")                                                                            // Correlation ID flag
+	generateCorrelationFlag = flags.Bool("generate_correlation", false, "Generate a correlation ID, if none is given")                                                                                 // Generate correlation ID flag
+	batchFlag               = flags.String("batch", "", "Directory or glob pattern of artefact files to post as a batch")                                                                              // Batch flag
+	idPrefixFlag            = flags.String("id_prefix", "", "Prefix of the artefact IDs in batch posting")                                                                                             // Artefact ID prefix flag
+	countFlag               = flags.Int("count", 0, "Number of files to post in batch posting (0 for all)")                                                                                            // Batch count flag
+	includeHiddenFlag       = flags.Bool("include_hidden", false, "Include hidden files in batch posting")                                                                                             // Include hidden files flag
+	globFlag                = flags.String("glob", "", "Glob pattern the names of the files must match when posting a directory, e.g. *.json")                                                         // Glob flag
+	dedupFlag               = flags.Bool("dedup", false, "Skip observations identical to the previously posted one, when posting a batch or a directory")                                              // Deduplication flag
+	retriesFlag             = flags.Int("retries", 0, "Number of times to retry on transient errors, such as I/O errors, timeouts, and failing bus operations")                                        // Retries flag
+	failFastFlag            = flags.Bool("fail_fast", false, "Stop a batch operation at the first failure, rather than continuing and reporting a summary")                                            // Fail fast flag
+	retryBackoffFlag        = flags.Duration("retry_backoff", time.Second, "Backoff before the first retry, doubling with each further retry")                                                         // Retry backoff flag
+	verifyFlag              = flags.Bool("verify", false, "Verify each posting by reading it back from the modelling bus and comparing it to the posted content")                                      // Verify flag
+	gunzipFlag              = flags.Bool("gunzip", false, "Decompress files with the "+app_generics.GzipExtension+" extension before posting them")                                                    // Gunzip flag
+	templateFlag            = flags.Bool("template", false, "Expand the JSON content as a Go text/template, with the variables given by -var and the built-in {{.Now}} and {{.UUID}}, before posting") // Template flag
+	varFlag                 = templateVarsFlag(flags, "var", "Template variable, as name=value (repeatable)")                                                                                          // Template variable flag
+	splitFlag               = flags.Bool("split", false, "Post each entity of a PlantUML model, with its relationships, as its own CDM artefact, with an ID derived from the entity name")             // Split flag
 )
 
+/*
+ * Posting context
+ */
+
+// The context of a posting, carrying the connectors and the parameters of the posting,
+// as resolved from the flags by run
+type TPostingContext struct {
+	Connector *connect.TModellingBusConnector      // The Modelling Bus Connector
+	Bus       app_generics.TModellingBusOperations // The operations on the modelling bus; the connector, unless replaced by a fake
+	Verifier  *connect.TModellingBusConnector      // The connector for reading back postings; nil unless verifying
+	Reporter  *generics.TReporter                  // The reporter
+	Input     io.Reader                            // The input, for reading JSON payloads from the standard input
+	Source    string                               // The source mentioned in envelopes
+
+	Kind              string // Kind of posting
+	File              string // File to post
+	JSON              string // JSON content to post
+	JSONVersion       string // JSON version of JSON artefact content
+	ArtefactID        string // Artefact ID
+	ObservationID     string // Observation ID
+	AgentID           string // Agent ID
+	CoordinationTopic string // Coordination topic path
+	CorrelationID     string // Correlation ID to attach to the posting
+	Envelope          bool   // Wrap the JSON payload in an envelope
+	Split             bool   // Post each entity of a PlantUML model as its own artefact
+
+	Gunzip              bool          // Decompress files compressed with gzip before posting them
+	Template            bool          // Expand the JSON content as a template before posting
+	TemplateVars        TTemplateVars // The variables for expanding templates
+	SkipTopicValidation bool          // Do not validate topic paths before using them
+	Retries             int           // Number of times to retry on transient errors
+	RetryBackoff        time.Duration // Backoff before the first retry
+	FailFast            bool          // Stop a batch operation at the first failure
+
+	Batch         string // Directory or glob pattern of artefact files to post as a batch
+	IDPrefix      string // Prefix of the artefact IDs in batch posting
+	Count         int    // Number of files to post in batch posting (0 for all)
+	IncludeHidden bool   // Include hidden files in batch and directory posting
+	Glob          string // Glob pattern the names of the files must match when posting a directory
+
+	Deduplication *TDeduplication // The deduplication of observations; nil unless deduplicating
+}
+
+// A handler for a posting kind
+type TPostingHandler func(posting *TPostingContext) error
+
+/*
+ * Supported kinds
+ */
+
+// Listing the supported posting kinds, in sorted order
+func SupportedKinds() []string {
+	return app_generics.SortedKinds(postingHandlers)
+}
+
+// Explaining the posting kind flag, based on the supported kinds.
+// As the handler map is a package variable, this can only be done once it is initialised.
+func init() {
+	flags.Lookup("kind").Usage = "Kind of posting to make. One of: " + app_generics.ExplainOptions(SupportedKinds()) + "."
+}
+
 /*
  * Getting the JSON payload to post
  */
 
-func getJSONPayload() ([]byte, bool) {
+// Getting the JSON payload to post, from the JSON flag, or from the file to post
+func getJSONPayload(posting *TPostingContext) ([]byte, error) {
 	// Getting the JSON payload
-	jsonPayload := []byte(*jsonFlag)
+	jsonPayload := []byte(posting.JSON)
 
 	// If no JSON content is given, we try to read it from a file
-	if len(jsonPayload) == 0 && len(*fileFlag) > 0 {
+	if len(jsonPayload) == 0 && len(posting.File) > 0 {
 		var err error
 
-		// Reading the file content
-		jsonPayload, err = os.ReadFile(*fileFlag)
+		// Reading the file content, or the standard input when the file is '-', decompressing it if requested
+		if posting.File == stdinFile {
+			jsonPayload, err = io.ReadAll(posting.Input)
+		} else if posting.Gunzip && app_generics.IsGzipFile(posting.File) {
+			jsonPayload, err = app_generics.ReadGzipFile(posting.File)
+		} else {
+			jsonPayload, err = os.ReadFile(posting.File)
+		}
 
 		// Reporting errors if needed
-		if modellingBusConnector.Reporter.MaybeReportError("Error reading file for JSON artefact posting:", err) {
-			return []byte{}, false
+		if posting.Reporter.MaybeReportError("Error reading file for JSON artefact posting:", err) {
+			return []byte{}, err
 		}
 	}
 
-	return jsonPayload, true
+	// Expanding the payload as a template, if requested
+	if posting.Template {
+		var err error
+		if jsonPayload, err = expandTemplate(posting, jsonPayload); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	// Wrapping the payload in an envelope, if requested or needed to carry a correlation ID
+	if posting.Envelope || posting.CorrelationID != "" {
+		return wrapInEnvelope(posting, jsonPayload)
+	}
+
+	return jsonPayload, nil
 }
 
 /*
- * Handlers for different posting kinds
+ * Wrapping JSON payloads in an envelope
  */
 
-// Handling raw artefact posting
-func handleRawArtefactPosting() {
-	// Check if we have a file to post
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(fileFlag, "No file specified for raw artefact posting.") {
-		return
+// The metadata of an envelope
+type TEnvelopeMeta struct {
+	Timestamp string `json:"timestamp"` // Time of wrapping the payload
+	Source    string `json:"source"`    // Agent posting the payload
+	Kind      string `json:"kind"`      // Kind of posting
+
+	CorrelationID string `json:"correlation_id,omitempty"` // ID for tracing the posting across systems
+}
+
+// An envelope around a JSON payload
+type TEnvelope struct {
+	Meta    TEnvelopeMeta   `json:"meta"`    // The metadata
+	Payload json.RawMessage `json:"payload"` // The original payload
+}
+
+// Wrapping a JSON payload in an envelope with metadata
+func wrapInEnvelope(posting *TPostingContext, jsonPayload []byte) ([]byte, error) {
+	// The payload must be valid JSON
+	if !json.Valid(jsonPayload) {
+		posting.Reporter.Error("The payload to be wrapped in an envelope is not valid JSON.")
+
+		return []byte{}, app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Creating the envelope
+	envelope := TEnvelope{
+		Meta: TEnvelopeMeta{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Source:    posting.Source,
+			Kind:      posting.Kind,
+
+			CorrelationID: posting.CorrelationID,
+		},
+		Payload: jsonPayload,
+	}
+
+	// Encoding the envelope
+	envelopedPayload, err := json.Marshal(envelope)
+	if posting.Reporter.MaybeReportError("Error wrapping payload in an envelope:", err) {
+		return []byte{}, err
 	}
 
-	// We also need an artefact ID for artefact postings
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact posting.") {
-		return
+	return envelopedPayload, nil
+}
+
+/*
+ * Handlers for different posting kinds
+ */
+
+// Handling raw artefact posting
+func handleRawArtefactPosting(posting *TPostingContext) error {
+	// We need the flags required for raw artefact posting
+	if !app_generics.RequireFlags(posting.Reporter, "raw artefact posting",
+		app_generics.TRequiredFlag{Value: &posting.File, Name: "file"},
+		app_generics.TRequiredFlag{Value: &posting.ArtefactID, Name: "artefact_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Create the modelling bus artefact poster
-	modellingBusArtefactPoster := connect.CreateModellingBusArtefactConnector(modellingBusConnector, "", *artefactIDFlag)
+	modellingBusArtefactPoster := connect.CreateModellingBusArtefactConnector(*posting.Connector, "", posting.ArtefactID)
+
+	// Decompressing the file to post, if requested
+	file, cleanUp, err := rawFileToPost(posting)
+	if err != nil {
+		return err
+	}
+	defer cleanUp()
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Raw artefact posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Raw artefact posting.")
 
 	// Posting the raw artefact
-	modellingBusArtefactPoster.PostRawArtefactState(*fileFlag)
+	postRawFile(posting, file, func() { modellingBusArtefactPoster.PostRawArtefactState(file) })
+
+	// Verifying the posting, if requested
+	return verifyRawPosting(posting, "raw artefact", file, func() []byte {
+		artefactReader := connect.CreateModellingBusArtefactConnector(*posting.Verifier, "", posting.ArtefactID)
+		filePath, _ := artefactReader.GetRawArtefactState(posting.Source, rawArtefactsTopicPathElement+"/"+posting.ArtefactID, verificationFileName)
+
+		return readBackRawFile(posting, filePath)
+	})
 }
 
 // Handling JSON artefact posting
-func handleJSONArtefactPosting() {
-	// We need a JSON version for JSON artefact posting
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(jsonVersionFlag, "No JSON version specified for JSON artefact posting.") {
-		return
-	}
-
-	// We also need an artefact ID for artefact postings
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(artefactIDFlag, "No artefact ID specified for artefact posting.") {
-		return
+func handleJSONArtefactPosting(posting *TPostingContext) error {
+	// We need the flags required for JSON artefact posting
+	if !app_generics.RequireFlags(posting.Reporter, "JSON artefact posting",
+		app_generics.TRequiredFlag{Value: &posting.JSONVersion, Name: "json_version"},
+		app_generics.TRequiredFlag{Value: &posting.ArtefactID, Name: "artefact_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Creating modelling bus artefact poster
-	modellingBusArtefactPoster := connect.CreateModellingBusArtefactConnector(modellingBusConnector, *jsonVersionFlag, *artefactIDFlag)
+	modellingBusArtefactPoster := connect.CreateModellingBusArtefactConnector(*posting.Connector, posting.JSONVersion, posting.ArtefactID)
 
 	// Getting the JSON payload
-	jsonPayload, ok := getJSONPayload()
+	jsonPayload, err := getJSONPayload(posting)
 
 	// Checking if we got the payload properly
-	if !ok {
-		return
+	if err != nil {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "JSON artefact posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "JSON artefact posting.")
 
 	// Posting the JSON artefact
-	modellingBusArtefactPoster.PostJSONArtefactState(jsonPayload, ok)
+	modellingBusArtefactPoster.PostJSONArtefactState(jsonPayload, true)
+
+	// Verifying the posting, if requested
+	return verifyPosting(posting, "JSON artefact", jsonPayload, func() []byte {
+		artefactReader := connect.CreateModellingBusArtefactConnector(*posting.Verifier, posting.JSONVersion, posting.ArtefactID)
+		artefactReader.GetJSONArtefactState(posting.Source, posting.ArtefactID)
+
+		return artefactReader.CurrentContent
+	})
 }
 
 // Handling raw observation posting
-func handleRawObservationPosting() {
-	// Check if we have a file to post
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(fileFlag, "No file specified for raw observation posting.") {
-		return
+func handleRawObservationPosting(posting *TPostingContext) error {
+	// We need the flags required for raw observation posting
+	if !app_generics.RequireFlags(posting.Reporter, "raw observation posting",
+		app_generics.TRequiredFlag{Value: &posting.File, Name: "file"},
+		app_generics.TRequiredFlag{Value: &posting.ObservationID, Name: "observation_id"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
-	// We must have a topic path
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	// Validating the observation ID
+	if !validTopic(posting, "observation_id", posting.ObservationID) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
+	// Decompressing the file to post, if requested
+	file, cleanUp, err := rawFileToPost(posting)
+	if err != nil {
+		return err
+	}
+	defer cleanUp()
+
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Raw observation posting.")
 
 	// Posting the raw observation
-	modellingBusConnector.PostRawObservation(*observationIDFlag, *fileFlag)
+	postRawFile(posting, file, func() { posting.Bus.PostRawObservation(posting.ObservationID, file) })
+
+	// Verifying the posting, if requested
+	return verifyRawPosting(posting, "raw observation", file, func() []byte {
+		filePath, _ := posting.Verifier.GetRawObservation(posting.Source, posting.ObservationID, verificationFileName)
+
+		return readBackRawFile(posting, filePath)
+	})
 }
 
 // Handling JSON observation posting
-func handleJSONObservationPosting() {
+func handleJSONObservationPosting(posting *TPostingContext) error {
 	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	if posting.Reporter.MaybeReportEmptyFlagError(&posting.ObservationID, "No observation ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating the observation ID
+	if !validTopic(posting, "observation_id", posting.ObservationID) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the JSON payload
-	jsonPayload, ok := getJSONPayload()
+	jsonPayload, err := getJSONPayload(posting)
 
 	// Checking if we got the payload properly
-	if !ok {
-		return
+	if err != nil {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "JSON observation posting.")
 
 	// Posting the JSON observation
-	modellingBusConnector.PostJSONObservation(*observationIDFlag, jsonPayload)
+	posting.Bus.PostJSONObservation(posting.ObservationID, jsonPayload)
+
+	// Verifying the posting, if requested
+	return verifyPosting(posting, "JSON observation", jsonPayload, func() []byte {
+		observation, _ := posting.Verifier.GetJSONObservation(posting.Source, posting.ObservationID)
+
+		return observation
+	})
 }
 
 // Handling streamed observation posting
-func handleStreamedObservationPosting() {
+func handleStreamedObservationPosting(posting *TPostingContext) error {
 	// We must have an observation ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(observationIDFlag, "No observation ID specified.") {
-		return
+	if posting.Reporter.MaybeReportEmptyFlagError(&posting.ObservationID, "No observation ID specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Validating the observation ID
+	if !validTopic(posting, "observation_id", posting.ObservationID) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the JSON payload
-	jsonPayload, ok := getJSONPayload()
+	jsonPayload, err := getJSONPayload(posting)
 
 	// Checking if we got the payload properly
-	if !ok {
-		return
+	if err != nil {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Streamed observation posting.")
 
 	// Posting the streamed observation
-	modellingBusConnector.PostStreamedObservation(*observationIDFlag, jsonPayload)
+	posting.Bus.PostStreamedObservation(posting.ObservationID, jsonPayload)
+
+	// Verifying the posting, if requested
+	return verifyPosting(posting, "streamed observation", jsonPayload, func() []byte {
+		observation, _ := posting.Verifier.GetStreamedObservation(posting.Source, posting.ObservationID)
+
+		return observation
+	})
 }
 
-func handleCoordinationPosting() {
-	// We must have a (target) agent ID
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(agentIDFlag, "No target agent ID specified.") {
-		return
+// Handling coordination posting
+func handleCoordinationPosting(posting *TPostingContext) error {
+	// We need the flags required for coordination posting
+	if !app_generics.RequireFlags(posting.Reporter, "coordination posting",
+		app_generics.TRequiredFlag{Value: &posting.AgentID, Name: "agent_id"},
+		app_generics.TRequiredFlag{Value: &posting.CoordinationTopic, Name: "coordination_topic"},
+	) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
-	// We must have a coordination topic
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(coordinationTopicFlag, "No coordination topic specified.") {
-		return
+	// Validating the coordination topic
+	if !validTopic(posting, "coordination_topic", posting.CoordinationTopic) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the JSON payload
-	jsonPayload, ok := getJSONPayload()
+	jsonPayload, err := getJSONPayload(posting)
 
 	// Checking if we got the payload properly
-	if !ok {
-		return
+	if err != nil {
+		return err
 	}
 
 	// Reporting progress
-	modellingBusConnector.Reporter.Progress(generics.ProgressLevelBasic, "Coordination posting.")
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Coordination posting.")
 
 	// Posting the coordination
-	modellingBusConnector.PostCoordination(*agentIDFlag, *coordinationTopicFlag, jsonPayload)
+	posting.Bus.PostCoordination(posting.CoordinationTopic, jsonPayload)
+
+	return nil
 }
 
 /*
@@ -250,33 +484,284 @@ func handleCoordinationPosting() {
  */
 
 func main() {
+	os.Exit(app_generics.ExitCode(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)))
+}
+
+// Running the app with the given arguments, input, and outputs, returning an exit error when it fails
+func run(arguments []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
 	// Parsing flags
-	flag.Parse()
+	flags.SetOutput(stderr)
+	parsed, err := app_generics.ParseFlags(flags, arguments)
+	if !parsed {
+		return err
+	}
 
-	// Creating the reporter
-	reporter := generics.CreateReporter(*reportLevelFlag, generics.ReportError, generics.ReportProgress)
+	// Failing with an error code if errors were reported, once all else is done
+	errorCount := app_generics.ReportedErrorCount()
+	defer func() {
+		if err == nil {
+			err = app_generics.ExitErrorSince(errorCount)
+		}
+	}()
 
-	// Loading the configuration
-	configData := generics.LoadConfig(*configFlag, reporter)
+	// Only reporting the version, if requested
+	if *versionFlag {
+		app_generics.WriteVersion(stdout, appName, appVersion)
 
-	// Creating the Modelling Bus Connector
-	modellingBusConnector = connect.CreateModellingBusConnector(configData, reporter, connect.PostingOnly)
+		return nil
+	}
+
+	// Directing the progress reports to the output, and the errors to the error output
+	app_generics.SetReportOutput(stdout, stderr)
+
+	// Creating the reporter, where only errors are reported when quiet
+	progressLevel := *reportLevelFlag
+	if *quietFlag {
+		progressLevel = app_generics.ProgressLevelSilent
+	}
+	reporter := app_generics.CreateReporter(progressLevel, *errorReportLevelFlag)
+
+	// Formatting the reports as requested
+	if reporter.MaybeReportError("Error in log format flag:", app_generics.SetLogFormat(*logFormatFlag)) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Directing the reports to a log file, if requested
+	if *logFileFlag != "" {
+		if reporter.MaybeReportError("Error opening log file:", app_generics.SetLogFile(*logFileFlag, *logMaxSizeFlag)) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+	}
+
+	// Creating the posting context from the flags, where the connectors follow once the
+	// configuration is loaded
+	posting := &TPostingContext{
+		Reporter: reporter,
+		Input:    stdin,
+
+		Kind:              *postingKindFlag,
+		File:              *fileFlag,
+		JSON:              *jsonFlag,
+		JSONVersion:       *jsonVersionFlag,
+		ArtefactID:        *artefactIDFlag,
+		ObservationID:     *observationIDFlag,
+		AgentID:           *agentIDFlag,
+		CoordinationTopic: *coordinationTopicFlag,
+		CorrelationID:     *correlationIDFlag,
+		Envelope:          *envelopeFlag,
+		Split:             *splitFlag,
+
+		Gunzip:              *gunzipFlag,
+		Template:            *templateFlag,
+		TemplateVars:        *varFlag,
+		SkipTopicValidation: *skipTopicValidationFlag,
+		Retries:             *retriesFlag,
+		RetryBackoff:        *retryBackoffFlag,
+		FailFast:            *failFastFlag,
+
+		Batch:         *batchFlag,
+		IDPrefix:      *idPrefixFlag,
+		Count:         *countFlag,
+		IncludeHidden: *includeHiddenFlag,
+		Glob:          *globFlag,
+	}
+
+	// Inferring the posting kind, if requested and not given
+	if *autoFlag && posting.Kind == "" {
+		inferredKind, err := inferPostingKind(posting)
+		if reporter.MaybeReportError("Error inferring posting kind:", err) {
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		posting.Kind = inferredKind
+		reporter.Progress(generics.ProgressLevelBasic, "Inferred posting kind: %s", posting.Kind)
+	}
+
+	// Scoping to the requested environment, if any
+	configOverrides := []app_generics.TConfigOverride{}
+	if *environmentFlag != "" {
+		// Validating the environment name
+		if reporter.MaybeReportError("Error in environment flag:", app_generics.ValidateEnvironment(*environmentFlag)) {
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		configOverrides = append(configOverrides, app_generics.TConfigOverride{Key: "environment", Value: *environmentFlag})
+	}
+
+	// Posting on behalf of the requested agent, if any. The connector scopes its
+	// postings to the configured agent, while coordinations address their target agent.
+	if posting.AgentID != "" && posting.Kind != coordinationPosting {
+		configOverrides = append(configOverrides, app_generics.TConfigOverride{Key: "agent", Value: posting.AgentID})
+	}
+
+	// Overriding the configuration values given on the command line, where the
+	// dedicated flags above take precedence
+	configOverrides = append(append([]app_generics.TConfigOverride{}, *configSetFlag...), configOverrides...)
 
-	// We must have a posting kind
-	if modellingBusConnector.Reporter.MaybeReportEmptyFlagError(postingKindFlag, "No posting kind specified.") {
-		return
+	// Only checking the configuration, if requested
+	if *configCheckFlag {
+		return app_generics.CheckConfig(*configFlag, configOverrides, connect.PostingOnly, reporter)
+	}
+
+	// We must have a posting kind, which is checked before connecting to the modelling bus
+	if posting.Reporter.MaybeReportEmptyFlagError(&posting.Kind, "No posting kind specified.") {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
 	}
 
 	// Getting the posting handler
-	postingHandler := postingHandlers[*postingKindFlag]
+	postingHandler := postingHandlers[posting.Kind]
 
 	// Validating posting handler
 	if postingHandler == nil {
-		modellingBusConnector.Reporter.Error("Unknown posting kind specified: %s.", *postingKindFlag)
+		posting.Reporter.Error("Unknown posting kind specified: %s.", posting.Kind)
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Loading the configuration
+	configData, err := app_generics.LoadConfig(*configFlag, configOverrides, reporter)
+	if err != nil {
+		return err
+	}
+
+	// Creating the Modelling Bus Connector
+	modellingBusConnector := connect.CreateModellingBusConnector(configData, reporter, connect.PostingOnly)
+
+	// Completing the posting context with the connector, and the source for envelopes
+	// taken from the configuration
+	posting.Connector = &modellingBusConnector
+	posting.Bus = &modellingBusConnector
+	posting.Reporter = modellingBusConnector.Reporter
+	posting.Source = configData.GetValue("", "agent").String()
+
+	// Creating a connector for reading back the postings, if they are to be verified.
+	// Coordinations are addressed to other agents, so they cannot be read back.
+	if *verifyFlag {
+		if posting.Kind == coordinationPosting {
+			posting.Reporter.Error("Verification is not supported for coordination postings.")
+
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
+
+		verifyingConnector := connect.CreateModellingBusConnector(configData, reporter, !connect.PostingOnly)
+		posting.Verifier = &verifyingConnector
+	}
+
+	// Generating a correlation ID, if requested
+	if posting.CorrelationID == "" && *generateCorrelationFlag {
+		correlationID, err := generateUUID()
+		if posting.Reporter.MaybeReportError("Error generating correlation ID:", err) {
+			return app_generics.ExitWith(app_generics.ExitBusError)
+		}
+
+		posting.CorrelationID = correlationID
+	}
+
+	// Correlation IDs are carried in an envelope, which raw postings cannot have
+	if posting.CorrelationID != "" && (posting.Kind == rawArtefactPosting || posting.Kind == rawObservationPosting) {
+		posting.Reporter.Error("Correlation IDs can only be attached to JSON postings.")
+
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Retrying postings that fail due to transient problems, if requested
+	postingHandler = withRetries(postingHandler)
+
+	// Skipping identical consecutive observations, if requested
+	if *dedupFlag {
+		if !observationPosting[posting.Kind] {
+			posting.Reporter.Error("Deduplication is only supported for observation postings.")
+
+			return app_generics.ExitWith(app_generics.ExitUsageError)
+		}
 
-		return
+		posting.Deduplication = &TDeduplication{}
+		postingHandler = withDeduplication(postingHandler)
 	}
 
-	// Calling the posting handler
-	postingHandler()
+	// Posting a batch, or a directory, if requested
+	if posting.Batch != "" {
+		err = handleBatchPosting(posting, postingHandler)
+	} else if isDirectory(posting.File) {
+		err = handleDirectoryPosting(posting, postingHandler)
+	} else {
+		err = postingHandler(posting)
+	}
+
+	// Reporting the deduplicated observations, if any
+	if posting.Deduplication != nil {
+		posting.Reporter.Progress(generics.ProgressLevelBasic, "Deduplicated %d observation(s).", posting.Deduplication.Count)
+	}
+
+	// Reporting the correlation ID, if any
+	if posting.CorrelationID != "" {
+		posting.Reporter.Progress(generics.ProgressLevelBasic, "Posted with correlation ID: %s", posting.CorrelationID)
+	}
+
+	return err
+}
+
+/*
+ * Support functions
+ */
+
+// Validating a topic path given by the named flag, unless validation is skipped
+func validTopic(posting *TPostingContext, flagName, topic string) bool {
+	if posting.SkipTopicValidation {
+		return true
+	}
+
+	return !posting.Reporter.MaybeReportError("Error in "+flagName+" flag:", app_generics.ValidateTopic(topic))
+}
+
+// Posting a raw file, reporting its size and the time taken at the verbose level. The
+// connector reads the file itself, so the progress within the transfer cannot be reported.
+func postRawFile(posting *TPostingContext, file string, post func()) {
+	if fileInfo, err := os.Stat(file); err == nil {
+		posting.Reporter.Progress(app_generics.ProgressLevelVerbose, "Posting %s of %s.", app_generics.FormatByteCount(fileInfo.Size()), file)
+	}
+
+	started := time.Now()
+	post()
+
+	posting.Reporter.Progress(app_generics.ProgressLevelVerbose, "Posted %s in %s.", file, time.Since(started).Round(time.Millisecond))
+}
+
+// Determining the raw file to post, which is a decompressed copy of the file when it is
+// compressed with gzip and decompression is requested. The returned function removes the
+// copy, if any.
+func rawFileToPost(posting *TPostingContext) (string, func(), error) {
+	// Posting the file as is, unless it is to be decompressed
+	if !posting.Gunzip || !app_generics.IsGzipFile(posting.File) {
+		return posting.File, func() {}, nil
+	}
+
+	// Decompressing the file
+	decompressedFile, err := app_generics.GunzipToTempFile(posting.File)
+	if posting.Reporter.MaybeReportError("Error decompressing file to post:", err) {
+		return "", func() {}, err
+	}
+
+	return decompressedFile, func() { os.RemoveAll(filepath.Dir(decompressedFile)) }, nil
+}
+
+// Wrapping a posting handler, so it retries postings that fail due to transient problems, if requested
+func withRetries(postingHandler TPostingHandler) TPostingHandler {
+	return func(posting *TPostingContext) error {
+		return app_generics.WithRetries(posting.Reporter, posting.Retries, posting.RetryBackoff, func() error { return postingHandler(posting) })()
+	}
+}
+
+// Generating a random (version 4) UUID
+func generateUUID() (string, error) {
+	uuid := make([]byte, 16)
+	if _, err := rand.Read(uuid); err != nil {
+		return "", err
+	}
+
+	// Setting the version and variant bits
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
 }
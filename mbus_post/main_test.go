@@ -0,0 +1,282 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+// Creating a posting context on a fake modelling bus, for the agent "agent", reporting
+// errors to the test unless they are expected
+func testPosting(t *testing.T, expectErrors bool) (*TPostingContext, *app_generics.TFakeModellingBus) {
+	bus := app_generics.CreateFakeModellingBus("agent", t.TempDir())
+
+	return &TPostingContext{
+		Bus: bus,
+		Reporter: generics.CreateReporter(app_generics.ProgressLevelSilent,
+			func(message string) {
+				if !expectErrors {
+					t.Errorf("reported error: %s", message)
+				}
+			},
+			func(string) {}),
+		Source: "agent",
+	}, bus
+}
+
+// Each posting kind is dispatched to its handler, which posts the content from the context
+// to the modelling bus, or fails with a usage error when a required parameter is missing
+func TestPostingDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		posting  TPostingContext
+		input    string
+		kind     string
+		id       string
+		content  string
+		exitCode int
+	}{
+		{
+			name:    "JSON observation",
+			posting: TPostingContext{Kind: jsonObservationPosting, ObservationID: "sensors/temperature", JSON: `{"celsius":21}`},
+			kind:    app_generics.FakeJSONObservation,
+			id:      "sensors/temperature",
+			content: `{"celsius":21}`,
+		},
+		{
+			name:    "JSON observation from the input",
+			posting: TPostingContext{Kind: jsonObservationPosting, ObservationID: "sensors/temperature", File: stdinFile},
+			input:   `{"celsius":22}`,
+			kind:    app_generics.FakeJSONObservation,
+			id:      "sensors/temperature",
+			content: `{"celsius":22}`,
+		},
+		{
+			name:    "streamed observation",
+			posting: TPostingContext{Kind: streamedObservationPosting, ObservationID: "sensors/humidity", JSON: `{"percent":40}`},
+			kind:    app_generics.FakeStreamedObservation,
+			id:      "sensors/humidity",
+			content: `{"percent":40}`,
+		},
+		{
+			name:    "coordination",
+			posting: TPostingContext{Kind: coordinationPosting, AgentID: "renderer", CoordinationTopic: "render/request", JSON: `{"model":"university"}`},
+			kind:    app_generics.FakeCoordination,
+			id:      "render/request",
+			content: `{"model":"university"}`,
+		},
+		{
+			name:     "JSON observation without observation ID",
+			posting:  TPostingContext{Kind: jsonObservationPosting, JSON: `{"celsius":21}`},
+			exitCode: app_generics.ExitUsageError,
+		},
+		{
+			name:     "coordination without agent ID",
+			posting:  TPostingContext{Kind: coordinationPosting, CoordinationTopic: "render/request", JSON: `{}`},
+			exitCode: app_generics.ExitUsageError,
+		},
+		{
+			name:     "invalid observation ID",
+			posting:  TPostingContext{Kind: jsonObservationPosting, ObservationID: "sensors/#", JSON: `{}`},
+			exitCode: app_generics.ExitUsageError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posting, bus := testPosting(t, test.exitCode != 0)
+			test.posting.Bus, test.posting.Reporter, test.posting.Source = posting.Bus, posting.Reporter, posting.Source
+			test.posting.Input = strings.NewReader(test.input)
+
+			err := postingHandlers[test.posting.Kind](&test.posting)
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v)", exitCode, test.exitCode, err)
+			}
+
+			if test.exitCode != 0 {
+				return
+			}
+
+			posted, present := bus.Posting(test.kind, "agent", test.id)
+			if !present {
+				t.Fatalf("no %s posting for %q", test.kind, test.id)
+			}
+			if string(posted.Content) != test.content {
+				t.Errorf("posted content = %s, want %s", posted.Content, test.content)
+			}
+		})
+	}
+}
+
+// Posting a JSON observation lands its payload, wrapped in an envelope when requested, on
+// the fake modelling bus, which tells its listeners about it
+func TestJSONObservationPosting(t *testing.T) {
+	posting, bus := testPosting(t, false)
+	posting.Kind = jsonObservationPosting
+	posting.ObservationID = "sensors/temperature"
+	posting.JSON = `{"celsius":21}`
+	posting.Envelope = true
+	posting.CorrelationID = "request-42"
+
+	heard := []app_generics.TFakePosting{}
+	bus.ListenForPostings(func(posted app_generics.TFakePosting) { heard = append(heard, posted) })
+
+	if err := handleJSONObservationPosting(posting); err != nil {
+		t.Fatalf("posting failed: %v", err)
+	}
+
+	posted, present := bus.Posting(app_generics.FakeJSONObservation, "agent", "sensors/temperature")
+	if !present {
+		t.Fatal("no JSON observation posted")
+	}
+	if len(heard) != 1 || string(heard[0].Content) != string(posted.Content) {
+		t.Errorf("listener heard %d posting(s), want the posted observation", len(heard))
+	}
+
+	envelope := TEnvelope{}
+	if err := json.Unmarshal(posted.Content, &envelope); err != nil {
+		t.Fatalf("posted content is no envelope: %v", err)
+	}
+	if string(envelope.Payload) != posting.JSON {
+		t.Errorf("payload = %s, want %s", envelope.Payload, posting.JSON)
+	}
+	if envelope.Meta.Source != "agent" || envelope.Meta.Kind != jsonObservationPosting || envelope.Meta.CorrelationID != "request-42" {
+		t.Errorf("envelope metadata = %+v", envelope.Meta)
+	}
+}
+
+// Deduplication keeps its state in the posting context, so identical consecutive observations
+// are only posted once, and a new run starts afresh
+func TestDeduplicationState(t *testing.T) {
+	folder := t.TempDir()
+	for name, content := range map[string]string{"a.json": `{"n":1}`, "b.json": `{"n":1}`, "c.json": `{"n":2}`} {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for run := 1; run <= 2; run++ {
+		posting, bus := testPosting(t, false)
+		posting.Kind = jsonObservationPosting
+		posting.ObservationID = "readings/{name}"
+		posting.File = folder
+		posting.Deduplication = &TDeduplication{}
+
+		postings := 0
+		bus.ListenForPostings(func(app_generics.TFakePosting) { postings++ })
+
+		if err := handleDirectoryPosting(posting, withDeduplication(postingHandlers[posting.Kind])); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+
+		if postings != 2 || posting.Deduplication.Count != 1 {
+			t.Errorf("run %d: posted %d, deduplicated %d; want 2 and 1", run, postings, posting.Deduplication.Count)
+		}
+	}
+}
+
+// Deduplication skips an observation identical to the previously posted one, but not one
+// identical to an observation whose posting failed
+func TestWithDeduplication(t *testing.T) {
+	tests := []struct {
+		name         string
+		observations []string
+		failing      string // Observation for which the posting fails, if any
+		posted       []string
+		count        int
+	}{
+		{"identical consecutive", []string{`{"n":1}`, `{"n":1}`, `{"n":2}`}, "", []string{`{"n":1}`, `{"n":2}`}, 1},
+		{"identical apart", []string{`{"n":1}`, `{"n":2}`, `{"n":1}`}, "", []string{`{"n":1}`, `{"n":2}`, `{"n":1}`}, 0},
+		{"identical to a failed one", []string{`{"n":1}`, `{"n":1}`}, `{"n":1}`, []string{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posting, _ := testPosting(t, false)
+			posting.Deduplication = &TDeduplication{}
+
+			posted := []string{}
+			postingHandler := withDeduplication(func(posting *TPostingContext) error {
+				if posting.JSON == test.failing {
+					return errors.New("posting failed")
+				}
+				posted = append(posted, posting.JSON)
+
+				return nil
+			})
+
+			for _, observation := range test.observations {
+				posting.JSON = observation
+				postingHandler(posting)
+			}
+
+			if !slices.Equal(posted, test.posted) || posting.Deduplication.Count != test.count {
+				t.Errorf("posted %q, deduplicated %d; want %q and %d", posted, posting.Deduplication.Count, test.posted, test.count)
+			}
+		})
+	}
+}
+
+// Running the app without a posting kind, or with an unknown one, exits with the usage error code
+func TestRunKindExitCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+	}{
+		{"missing kind", []string{"-kind=", "-json", "{}"}},
+		{"unknown kind", []string{"-kind", "telegram", "-json", "{}"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			err := run(test.arguments, strings.NewReader(""), &stdout, &stderr)
+			if exitCode := app_generics.ExitCode(err); exitCode != app_generics.ExitUsageError {
+				t.Errorf("exit code = %d, want %d (output: %s%s)", exitCode, app_generics.ExitUsageError, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String()+stderr.String(), "posting kind") {
+				t.Errorf("no posting kind error reported: %s%s", stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+// The supported kinds are the kinds of the handler map, in sorted order, each explained by the kind flag
+func TestSupportedKinds(t *testing.T) {
+	kinds := SupportedKinds()
+	if !slices.IsSorted(kinds) || len(kinds) != len(postingHandlers) {
+		t.Errorf("SupportedKinds() = %q, want the sorted kinds of the posting handlers", kinds)
+	}
+
+	usage := flags.Lookup("kind").Usage
+	for _, kind := range kinds {
+		if _, handled := postingHandlers[kind]; !handled {
+			t.Errorf("supported kind %q has no posting handler", kind)
+		}
+		if !strings.Contains(usage, kind) {
+			t.Errorf("kind flag usage %q does not explain %q", usage, kind)
+		}
+	}
+}
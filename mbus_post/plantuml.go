@@ -0,0 +1,111 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports posting PlantUML models as CDM models, either
+ * as a whole, or, with -split, as one CDM model per entity.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"app_generics"
+	"app_generics/cdm_tools"
+	"app_generics/plantuml_cdm"
+	"plantuml"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+	cdm "github.com/erikproper/big-modelling-bus.go.v1/languages/cdm/cdm_v1_0_v1_0"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	entityArtefactIDSeparator = "-" // Separator between the artefact ID prefix and the entity name, when splitting
+)
+
+/*
+ * Posting PlantUML models
+ */
+
+// Deriving the artefact ID of an entity from its name, where white space becomes '_',
+// prefixed by the given artefact ID, if any
+func entityArtefactID(prefix, entity string) string {
+	artefactID := strings.Join(strings.Fields(entity), "_")
+	if prefix != "" {
+		return prefix + entityArtefactIDSeparator + artefactID
+	}
+
+	return artefactID
+}
+
+// Determining the PlantUML models to post, by their artefact IDs. Unless split, the whole
+// model is posted under the given artefact ID. When split, each entity is posted with the
+// relationships it takes part in, including the entities at their other ends, under the
+// artefact ID derived from its name.
+func plantUMLModelsToPost(model *plantuml.Model, artefactID string, split bool) map[string]*plantuml.Model {
+	if !split {
+		return map[string]*plantuml.Model{artefactID: model}
+	}
+
+	models := map[string]*plantuml.Model{}
+	for entity, entityModel := range model.SplitByEntity() {
+		models[entityArtefactID(artefactID, entity)] = entityModel
+	}
+
+	return models
+}
+
+// Handling PlantUML model posting
+func handlePlantUMLModelPosting(posting *TPostingContext) error {
+	// We need the file, and, unless splitting, the artefact ID
+	requiredFlags := []app_generics.TRequiredFlag{{Value: &posting.File, Name: "file"}}
+	if !posting.Split {
+		requiredFlags = append(requiredFlags, app_generics.TRequiredFlag{Value: &posting.ArtefactID, Name: "artefact_id"})
+	}
+	if !app_generics.RequireFlags(posting.Reporter, "PlantUML model posting", requiredFlags...) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Reading the PlantUML model
+	file, err := os.Open(posting.File)
+	if posting.Reporter.MaybeReportError("Error reading file for PlantUML model posting:", err) {
+		return err
+	}
+	defer file.Close()
+
+	model, err := plantuml.NewParser(file).Parse()
+	if posting.Reporter.MaybeReportError("Error parsing PlantUML model:", err) {
+		return app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Posting the models as CDM models, in a deterministic order, with their annotations
+	// ahead of the models they refer to
+	models := plantUMLModelsToPost(model, posting.ArtefactID, posting.Split)
+	for _, artefactID := range slices.Sorted(maps.Keys(models)) {
+		posting.Reporter.Progress(generics.ProgressLevelBasic, "PlantUML model posting: %s", artefactID)
+
+		CDMModel, annotations := plantuml_cdm.ToCDMWithAnnotations(models[artefactID], posting.Reporter)
+		annotationsPoster := cdm_tools.CreateCDMAnnotationsPoster(*posting.Connector, artefactID)
+		annotationsPoster.PostAnnotations(annotations)
+
+		CDMPoster := cdm.CreateCDMPoster(*posting.Connector, artefactID)
+		CDMPoster.PostState(CDMModel)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"plantuml"
+)
+
+// A two-class diagram, with a relationship between the classes
+const twoClassDiagram = `@startuml
+class Student {
+  name : String
+}
+class "Study Programme" as SP {
+  title : String
+}
+Student "0..*" -- "1" SP : studies
+@enduml
+`
+
+// Posting a two-class diagram yields one artefact, or two artefacts under -split, each
+// with the relationship and both of its endpoints
+func TestPlantUMLModelsToPost(t *testing.T) {
+	model, err := plantuml.NewParser(strings.NewReader(twoClassDiagram)).Parse()
+	if err != nil {
+		t.Fatalf("parsing: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		artefactID string
+		split      bool
+		want       []string
+	}{
+		{"whole model", "university", false, []string{"university"}},
+		{"split", "", true, []string{"SP", "Student"}},
+		{"split with prefix", "university", true, []string{"university-SP", "university-Student"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			models := plantUMLModelsToPost(model, test.artefactID, test.split)
+			if got := slices.Sorted(maps.Keys(models)); !slices.Equal(got, test.want) {
+				t.Fatalf("got artefacts %v, want %v", got, test.want)
+			}
+
+			for artefactID, artefactModel := range models {
+				if len(artefactModel.Entities) != 2 || len(artefactModel.Relationships) != 1 {
+					t.Errorf("%s: got %d entities and %d relationships, want 2 and 1",
+						artefactID, len(artefactModel.Entities), len(artefactModel.Relationships))
+				}
+			}
+		})
+	}
+}
+
+// Deriving artefact IDs from entity names
+func TestEntityArtefactID(t *testing.T) {
+	tests := []struct {
+		prefix, entity, want string
+	}{
+		{"", "Student", "Student"},
+		{"", "Study  Programme", "Study_Programme"},
+		{"university", "Student", "university-Student"},
+	}
+
+	for _, test := range tests {
+		if got := entityArtefactID(test.prefix, test.entity); got != test.want {
+			t.Errorf("entityArtefactID(%q, %q) = %q, want %q", test.prefix, test.entity, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports posting JSON content as a template, where the
+ * variables given by -var, as well as the built-in {{.Now}} and {{.UUID}}, are substituted
+ * before posting, e.g. {"ts": "{{.Now}}", "run": "{{.run}}"} with -var run=42.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"app_generics"
+)
+
+/*
+ * Defining key constants
+ */
+
+const (
+	nowVariable  = "Now"  // Built-in variable with the current time, in RFC3339 format
+	uuidVariable = "UUID" // Built-in variable with a random (version 4) UUID, fresh for each posting
+)
+
+/*
+ * Defining template variables
+ */
+
+// A repeatable command line flag collecting template variables, given as name=value
+type TTemplateVars map[string]string
+
+// Rendering the variables, as needed for flag.Value
+func (v *TTemplateVars) String() string {
+	settings := []string{}
+	for _, name := range slices.Sorted(maps.Keys(*v)) {
+		settings = append(settings, name+"="+(*v)[name])
+	}
+
+	return strings.Join(settings, ", ")
+}
+
+// Removing all variables, as needed for ParseFlags
+func (v *TTemplateVars) Reset() {
+	*v = TTemplateVars{}
+}
+
+// Adding a variable, as needed for flag.Value
+func (v *TTemplateVars) Set(setting string) error {
+	name, value, found := strings.Cut(setting, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return fmt.Errorf("expected name=value, got: %s", setting)
+	}
+
+	(*v)[name] = value
+
+	return nil
+}
+
+// Defining a repeatable flag for template variables
+func templateVarsFlag(flags *flag.FlagSet, name, usage string) *TTemplateVars {
+	vars := &TTemplateVars{}
+	flags.Var(vars, name, usage)
+
+	return vars
+}
+
+/*
+ * Expanding templates
+ */
+
+// Expanding a JSON payload as a template, with the built-in variables and those given by -var,
+// where the latter take precedence. The result must still be valid JSON.
+func expandTemplate(posting *TPostingContext, jsonPayload []byte) ([]byte, error) {
+	// Parsing the template, where unknown variables are errors rather than left empty
+	payloadTemplate, err := template.New("payload").Option("missingkey=error").Parse(string(jsonPayload))
+	if posting.Reporter.MaybeReportError("Error parsing JSON template:", err) {
+		return []byte{}, app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// Collecting the variables
+	uuid, err := generateUUID()
+	if posting.Reporter.MaybeReportError("Error generating UUID for JSON template:", err) {
+		return []byte{}, err
+	}
+
+	variables := map[string]string{
+		nowVariable:  time.Now().UTC().Format(time.RFC3339),
+		uuidVariable: uuid,
+	}
+	maps.Copy(variables, posting.TemplateVars)
+
+	// Expanding the template
+	expandedPayload := bytes.Buffer{}
+	if err := payloadTemplate.Execute(&expandedPayload, variables); posting.Reporter.MaybeReportError("Error expanding JSON template:", err) {
+		return []byte{}, app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	// The expanded template must be valid JSON
+	if !json.Valid(expandedPayload.Bytes()) {
+		posting.Reporter.Error("The expanded JSON template is not valid JSON.")
+
+		return []byte{}, app_generics.ExitWith(app_generics.ExitUsageError)
+	}
+
+	return expandedPayload.Bytes(), nil
+}
@@ -0,0 +1,81 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"app_generics"
+)
+
+// Posting a JSON template expands the built-in {{.Now}} to an RFC3339 timestamp
+func TestTemplateNow(t *testing.T) {
+	posting, bus := testPosting(t, false)
+	posting.Kind = jsonObservationPosting
+	posting.ObservationID = "runs/started"
+	posting.JSON = `{"ts":"{{.Now}}"}`
+	posting.Template = true
+
+	if err := handleJSONObservationPosting(posting); err != nil {
+		t.Fatalf("posting failed: %v", err)
+	}
+
+	posted, present := bus.Posting(app_generics.FakeJSONObservation, "agent", "runs/started")
+	if !present {
+		t.Fatal("no JSON observation posted")
+	}
+
+	payload := struct {
+		Timestamp string `json:"ts"`
+	}{}
+	if err := json.Unmarshal(posted.Content, &payload); err != nil {
+		t.Fatalf("posted content %s is no valid JSON: %v", posted.Content, err)
+	}
+	if _, err := time.Parse(time.RFC3339, payload.Timestamp); err != nil {
+		t.Errorf("posted timestamp %q is no RFC3339 timestamp: %v", payload.Timestamp, err)
+	}
+}
+
+// Expanding JSON templates with the variables given by -var, which take precedence over the
+// built-in ones, fails with a usage error for unknown variables or invalid JSON
+func TestExpandTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		vars     TTemplateVars
+		want     string
+		exitCode int
+	}{
+		{"variable", `{"run":"{{.run}}"}`, TTemplateVars{"run": "42"}, `{"run":"42"}`, 0},
+		{"overridden built-in", `{"ts":"{{.Now}}"}`, TTemplateVars{"Now": "yesterday"}, `{"ts":"yesterday"}`, 0},
+		{"unknown variable", `{"run":"{{.run}}"}`, TTemplateVars{}, "", app_generics.ExitUsageError},
+		{"invalid JSON", `{"run":{{.run}}}`, TTemplateVars{"run": "forty-two"}, "", app_generics.ExitUsageError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			posting, _ := testPosting(t, test.exitCode != 0)
+			posting.TemplateVars = test.vars
+
+			expanded, err := expandTemplate(posting, []byte(test.template))
+			if exitCode := app_generics.ExitCode(err); exitCode != test.exitCode {
+				t.Fatalf("exit code = %d, want %d (error: %v)", exitCode, test.exitCode, err)
+			}
+			if string(expanded) != test.want {
+				t.Errorf("expanded %s, want %s", expanded, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Generic Poster for the Modelling Bus, Version 1
+ *
+ * This part of the application supports verifying postings, by reading them back from
+ * the modelling bus and comparing them to what was posted.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"app_generics"
+
+	"github.com/erikproper/big-modelling-bus.go.v1/generics"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	verificationFileName = "mbus_post_verification" // Local file name for raw postings read back for verification
+
+	rawArtefactsTopicPathElement = "artefacts/raw" // Topic path element of raw artefacts, as used by the Modelling Bus Connector
+)
+
+/*
+ * Verifying postings
+ */
+
+// Normalising content for comparison, where JSON content is compacted, as the modelling bus
+// need not preserve its layout
+func normalisedContent(content []byte) []byte {
+	compacted := bytes.Buffer{}
+	if json.Valid(content) && json.Compact(&compacted, content) == nil {
+		return compacted.Bytes()
+	}
+
+	return content
+}
+
+// Reading back a posting, and comparing it to the posted content, if requested
+func verifyPosting(posting *TPostingContext, description string, postedContent []byte, readBack func() []byte) error {
+	// Only when requested
+	if posting.Verifier == nil {
+		return nil
+	}
+
+	// Reading back the posting
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Verifying %s posting.", description)
+	retrievedContent := readBack()
+
+	// Comparing the contents
+	if !bytes.Equal(normalisedContent(retrievedContent), normalisedContent(postedContent)) {
+		posting.Reporter.Error("Verification of %s posting failed: the content read back differs from the posted content.", description)
+
+		return app_generics.ExitWith(app_generics.ExitBusError)
+	}
+
+	posting.Reporter.Progress(generics.ProgressLevelBasic, "Verified %s posting.", description)
+
+	return nil
+}
+
+// Reading back a raw posting from the file it was retrieved into, removing that file afterwards
+func readBackRawFile(posting *TPostingContext, filePath string) []byte {
+	defer os.Remove(filePath)
+
+	content, err := os.ReadFile(filePath)
+	if posting.Reporter.MaybeReportError("Error reading back posting:", err) {
+		return nil
+	}
+
+	return content
+}
+
+// Verifying a raw posting of the given file
+func verifyRawPosting(posting *TPostingContext, description, file string, readBack func() []byte) error {
+	// Only when requested
+	if posting.Verifier == nil {
+		return nil
+	}
+
+	// Reading the posted file
+	postedContent, err := os.ReadFile(file)
+	if posting.Reporter.MaybeReportError("Error reading posted file for verification:", err) {
+		return err
+	}
+
+	return verifyPosting(posting, description, postedContent, readBack)
+}
@@ -0,0 +1,7 @@
+module mbus_validate
+
+go 1.24.0
+
+require plantuml v0.0.0
+
+replace plantuml => ../plantuml
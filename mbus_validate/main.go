@@ -0,0 +1,238 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Validator for PlantUML Models, Version 1
+ *
+ * This is a validator for structural PlantUML models, e.g. for linting diagrams in CI
+ * before converting and posting them. It reports parse warnings, references to
+ * undeclared entities, inheritance cycles, and disallowed types, as text, JSON, or
+ * SARIF, and exits with a non-zero status if any problems are found.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"plantuml"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	textOutput  = "text"  // Plain text output
+	jsonOutput  = "json"  // JSON output
+	sarifOutput = "sarif" // SARIF output, for annotations in CI
+
+	exitProblems = 1 // Exit status when problems are found
+	exitFailure  = 2 // Exit status when the file could not be validated
+
+	warningProblem   = "warning"   // Parse warning problem kind
+	referenceProblem = "reference" // Undeclared entity problem kind
+	cycleProblem     = "cycle"     // Inheritance cycle problem kind
+	typeProblem      = "type"      // Disallowed type problem kind
+)
+
+// Signalling that problems were found, as opposed to the file not being validated
+var errProblemsFound = errors.New("problems found")
+
+// The types that are always allowed, next to the declared entities and enums
+var builtinTypes = []string{
+	"String", "string", "int", "Integer", "long", "float", "double", "Real",
+	"boolean", "Boolean", "bool", "char", "byte", "Date", "DateTime", "void",
+}
+
+/*
+ * Key variables
+ */
+
+var (
+	flags = flag.NewFlagSet("mbus_validate", flag.ContinueOnError) // The flags of the app
+
+	fileFlag   = flags.String("file", "", "PlantUML file to validate")                                                            // File to validate flag
+	outputFlag = flags.String("output", textOutput, "Output format. One of: "+textOutput+", "+jsonOutput+", or "+sarifOutput+".") // Output format flag
+	typesFlag  = flags.String("types", "", "Comma-separated list of additionally allowed types")                                  // Allowed types flag
+)
+
+/*
+ * Defining problems
+ */
+
+type TProblem struct {
+	Kind    string `json:"kind"`           // The kind of problem
+	Line    int    `json:"line,omitempty"` // The line of the problem, if known
+	Message string `json:"message"`        // Description of the problem
+}
+
+// Adding the errors of a validation pass as problems of the given kind
+func addProblems(problems []TProblem, kind string, errs []error) []TProblem {
+	for _, err := range errs {
+		problems = append(problems, TProblem{Kind: kind, Message: err.Error()})
+	}
+
+	return problems
+}
+
+/*
+ * Validating models
+ */
+
+// Determining the allowed types: the built-in types, the declared entities and enums, and the additional types
+func allowedTypes(model *plantuml.Model) []string {
+	allowed := append([]string{}, builtinTypes...)
+	for name := range model.Entities {
+		allowed = append(allowed, name)
+	}
+	for name := range model.Enums {
+		allowed = append(allowed, name)
+	}
+	for _, t := range strings.Split(*typesFlag, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			allowed = append(allowed, t)
+		}
+	}
+
+	return allowed
+}
+
+// Validating a model, collecting all problems
+func validate(model *plantuml.Model) []TProblem {
+	problems := []TProblem{}
+
+	// Parse warnings
+	for _, warning := range model.Warnings {
+		problems = append(problems, TProblem{Kind: warningProblem, Line: warning.Line, Message: "unrecognised line: " + warning.Text})
+	}
+
+	// References, cycles, and types
+	problems = addProblems(problems, referenceProblem, model.Validate())
+	problems = addProblems(problems, cycleProblem, model.DetectCycles())
+	problems = addProblems(problems, typeProblem, model.ValidateTypes(allowedTypes(model)))
+
+	return problems
+}
+
+/*
+ * Reporting problems
+ */
+
+// Printing the problems in the requested output format
+func printProblems(output io.Writer, problems []TProblem) error {
+	if *outputFlag == sarifOutput {
+		return writeSARIF(output, *fileFlag, problems)
+	}
+
+	if *outputFlag == jsonOutput {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			File     string     `json:"file"`
+			Problems []TProblem `json:"problems"`
+		}{*fileFlag, problems})
+	}
+
+	for _, problem := range problems {
+		if problem.Line > 0 {
+			fmt.Fprintf(output, "%s:%d: %s: %s\n", *fileFlag, problem.Line, problem.Kind, problem.Message)
+		} else {
+			fmt.Fprintf(output, "%s: %s: %s\n", *fileFlag, problem.Kind, problem.Message)
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Main function
+ */
+
+func main() {
+	os.Exit(exitStatus(run(os.Args[1:], os.Stdout, os.Stderr)))
+}
+
+// Signalling problems, or a failure to validate, through the exit status
+func exitStatus(err error) int {
+	if errors.Is(err, errProblemsFound) {
+		return exitProblems
+	} else if err != nil {
+		return exitFailure
+	}
+
+	return 0
+}
+
+// Running the app with the given arguments and outputs, returning errProblemsFound when
+// problems are found, and another error when the file could not be validated
+func run(arguments []string, stdout, stderr io.Writer) error {
+	// Parsing flags, starting from their defaults
+	flags.SetOutput(stderr)
+	flags.VisitAll(func(definedFlag *flag.Flag) {
+		definedFlag.Value.Set(definedFlag.DefValue)
+	})
+	if err := flags.Parse(arguments); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// We must have a file
+	if *fileFlag == "" {
+		fmt.Fprintln(stderr, "No file specified.")
+
+		return errors.New("no file specified")
+	}
+
+	// Validating the output format
+	if *outputFlag != textOutput && *outputFlag != jsonOutput && *outputFlag != sarifOutput {
+		fmt.Fprintf(stderr, "Unknown output format specified: %s.\n", *outputFlag)
+
+		return fmt.Errorf("unknown output format: %s", *outputFlag)
+	}
+
+	// Opening the file
+	file, err := os.Open(*fileFlag)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error opening file:", err)
+
+		return err
+	}
+	defer file.Close()
+
+	// Parsing the model
+	model, err := plantuml.NewParser(file).Parse()
+	if err != nil {
+		fmt.Fprintln(stderr, "Error parsing file:", err)
+
+		return err
+	}
+
+	// Validating the model, and reporting the problems
+	problems := validate(model)
+	if err := printProblems(stdout, problems); err != nil {
+		fmt.Fprintln(stderr, "Error writing problems:", err)
+
+		return err
+	}
+
+	// Signalling problems
+	if len(problems) > 0 {
+		return errProblemsFound
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Validator for PlantUML Models, Version 1 (tests)
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 19.12.2025
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// A clean diagram, declaring the entities it relates
+const cleanDiagram = `@startuml
+class Student {
+  name : String
+  programme : StudyProgramme
+}
+class StudyProgramme {
+  code : String
+}
+Student --> StudyProgramme
+@enduml
+`
+
+// A broken diagram, with a malformed member on line 3, and a relationship to an undeclared entity
+const brokenDiagram = `@startuml
+class Student {
+  name : : String
+}
+Student --> Course
+@enduml
+`
+
+// Writing a diagram to a temporary file
+func writeDiagram(t *testing.T, diagram string) string {
+	file := filepath.Join(t.TempDir(), "model.puml")
+	if err := os.WriteFile(file, []byte(diagram), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+// Validating a clean diagram exits with 0, and a broken one with 1, reporting its problems
+// in each of the output formats
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		diagram    string
+		output     string
+		exitStatus int
+		reported   []string
+	}{
+		{"clean", cleanDiagram, textOutput, 0, nil},
+		{"broken", brokenDiagram, textOutput, exitProblems, []string{":3: warning: unrecognised line: name : : String", `unknown entity "Course"`}},
+		{"broken as JSON", brokenDiagram, jsonOutput, exitProblems, []string{`"kind": "warning"`, `"line": 3`, `"kind": "reference"`}},
+		{"unknown output format", cleanDiagram, "xml", exitFailure, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run([]string{"-file", writeDiagram(t, test.diagram), "-output", test.output}, &stdout, &stderr)
+
+			if status := exitStatus(err); status != test.exitStatus {
+				t.Fatalf("exit status = %d, want %d (error: %v, output: %s%s)", status, test.exitStatus, err, stdout.String(), stderr.String())
+			}
+			if test.exitStatus == 0 && stdout.Len() > 0 {
+				t.Errorf("reported problems for a clean diagram:\n%s", stdout.String())
+			}
+			for _, reported := range test.reported {
+				if !strings.Contains(stdout.String(), reported) {
+					t.Errorf("output does not contain %q:\n%s", reported, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+// Validating a broken diagram as SARIF gives a result for the malformed member, located at its line
+func TestRunSARIF(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	file := writeDiagram(t, brokenDiagram)
+	if status := exitStatus(run([]string{"-file", file, "-output", sarifOutput}, &stdout, &stderr)); status != exitProblems {
+		t.Fatalf("exit status = %d, want %d: %s", status, exitProblems, stderr.String())
+	}
+
+	log := TSARIFLog{}
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF: %s\n%s", err, stdout.String())
+	}
+	if log.Version != sarifVersion || len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(sarifRules) {
+		t.Fatalf("unexpected SARIF structure:\n%s", stdout.String())
+	}
+
+	for _, result := range log.Runs[0].Results {
+		if result.RuleID != warningProblem || len(result.Locations) != 1 {
+			continue
+		}
+
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI != file || location.Region == nil || location.Region.StartLine != 3 || result.Level != sarifWarning {
+			t.Errorf("warning result = %+v, want level %s at line 3 of %s", result, sarifWarning, file)
+		}
+
+		return
+	}
+
+	t.Errorf("no warning result in SARIF:\n%s", stdout.String())
+}
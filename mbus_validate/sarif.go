@@ -0,0 +1,151 @@
+/*
+ *
+ * Module:      BIG Modelling Bus Apps, Version 1
+ * Package:     Modelling Bus Apps
+ * Application: Validator for PlantUML Models, Version 1
+ *
+ * This part of the application reports problems in the SARIF format, so they can be
+ * shown as inline annotations by CI systems.
+ *
+ * Creator: Henderik A. Proper (e.proper@acm.org), TU Wien, Austria
+ *
+ * Version of: 18.12.2025
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+/*
+ * Defining constants
+ */
+
+const (
+	sarifVersion = "2.1.0"                                         // Version of the SARIF format
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json" // Schema of the SARIF format
+	toolName     = "mbus_validate"                                 // Name of the tool in SARIF logs
+
+	sarifWarning = "warning" // SARIF level of parse warnings
+	sarifError   = "error"   // SARIF level of other problems
+)
+
+// The rules, i.e. problem kinds, in their reporting order, with their descriptions
+var sarifRules = []struct {
+	kind        string
+	description string
+}{
+	{warningProblem, "Line that is not recognised as part of a structural PlantUML model"},
+	{referenceProblem, "Relationship referring to an undeclared entity"},
+	{cycleProblem, "Cycle in the inheritance relationships"},
+	{typeProblem, "Attribute, parameter, or return type that is not allowed"},
+}
+
+/*
+ * Defining the SARIF log
+ */
+
+type TSARIFLog struct {
+	Version string      `json:"version"` // Version of the SARIF format
+	Schema  string      `json:"$schema"` // Schema of the SARIF format
+	Runs    []TSARIFRun `json:"runs"`    // The runs of the tool
+}
+
+type TSARIFRun struct {
+	Tool    TSARIFTool     `json:"tool"`    // The tool that produced the results
+	Results []TSARIFResult `json:"results"` // The results of the run
+}
+
+type TSARIFTool struct {
+	Driver TSARIFDriver `json:"driver"` // The component of the tool that ran
+}
+
+type TSARIFDriver struct {
+	Name  string       `json:"name"`  // Name of the tool
+	Rules []TSARIFRule `json:"rules"` // The rules the tool checks
+}
+
+type TSARIFRule struct {
+	ID               string        `json:"id"`               // ID of the rule
+	ShortDescription TSARIFMessage `json:"shortDescription"` // Description of the rule
+}
+
+type TSARIFResult struct {
+	RuleID    string           `json:"ruleId"`              // ID of the violated rule
+	Level     string           `json:"level"`               // Severity of the result
+	Message   TSARIFMessage    `json:"message"`             // Description of the result
+	Locations []TSARIFLocation `json:"locations,omitempty"` // Locations of the result
+}
+
+type TSARIFMessage struct {
+	Text string `json:"text"` // The text of the message
+}
+
+type TSARIFLocation struct {
+	PhysicalLocation TSARIFPhysicalLocation `json:"physicalLocation"` // Location in a file
+}
+
+type TSARIFPhysicalLocation struct {
+	ArtifactLocation TSARIFArtifactLocation `json:"artifactLocation"` // The file
+	Region           *TSARIFRegion          `json:"region,omitempty"` // The region in the file, if known
+}
+
+type TSARIFArtifactLocation struct {
+	URI string `json:"uri"` // URI of the file
+}
+
+type TSARIFRegion struct {
+	StartLine int `json:"startLine"` // First line of the region
+}
+
+/*
+ * Reporting problems as SARIF
+ */
+
+// Converting the problems found in the given file into a SARIF log
+func sarifLog(file string, problems []TProblem) TSARIFLog {
+	// Describing the tool
+	driver := TSARIFDriver{Name: toolName, Rules: []TSARIFRule{}}
+	for _, rule := range sarifRules {
+		driver.Rules = append(driver.Rules, TSARIFRule{ID: rule.kind, ShortDescription: TSARIFMessage{Text: rule.description}})
+	}
+
+	// Converting the problems
+	results := []TSARIFResult{}
+	for _, problem := range problems {
+		level := sarifError
+		if problem.Kind == warningProblem {
+			level = sarifWarning
+		}
+
+		// Locating the problem, as precise as known
+		location := TSARIFLocation{PhysicalLocation: TSARIFPhysicalLocation{ArtifactLocation: TSARIFArtifactLocation{URI: file}}}
+		if problem.Line > 0 {
+			location.PhysicalLocation.Region = &TSARIFRegion{StartLine: problem.Line}
+		}
+
+		results = append(results, TSARIFResult{
+			RuleID:    problem.Kind,
+			Level:     level,
+			Message:   TSARIFMessage{Text: problem.Message},
+			Locations: []TSARIFLocation{location},
+		})
+	}
+
+	return TSARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []TSARIFRun{{Tool: TSARIFTool{Driver: driver}, Results: results}},
+	}
+}
+
+// Writing the problems found in the given file as a SARIF log
+func writeSARIF(w io.Writer, file string, problems []TProblem) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog(file, problems))
+}
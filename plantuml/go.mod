@@ -0,0 +1,3 @@
+module plantuml
+
+go 1.24.0
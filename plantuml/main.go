@@ -1,13 +1,15 @@
 // Package plantuml provides a parser for structural PlantUML models
-// with support for entities, attributes, methods, relationships,
+// with support for entities, enums, attributes, methods, relationships,
 // multiplicities, and basic constraint extraction.
 package plantuml
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -18,47 +20,80 @@ import (
 // Model represents a parsed PlantUML model.
 type Model struct {
 	Entities      map[string]*Entity
+	Enums         map[string]*Enum
 	Relationships []*Relationship
 	Constraints   []*Constraint
+	Warnings      []ParseWarning
 }
 
-// Entity represents a class / entity / object.
+// ParseWarning records a line that was not recognised, although it appeared
+// in a context where a declaration was expected.
+type ParseWarning struct {
+	Line int    `json:"line"` // 1-based line number
+	Text string `json:"text"` // the offending text
+}
+
+// Entity represents a class / entity / object. Entities declared with an
+// alias, as in class "Study Programme" as SP, are keyed by their alias.
 type Entity struct {
-	Name       string
-	Attributes []Attribute
-	Methods    []Method
+	Name       string      `json:"name"`
+	Alias      string      `json:"alias"`
+	Attributes []Attribute `json:"attributes"`
+	Methods    []Method    `json:"methods"`
+}
+
+// Enum represents an enumeration and its literals, in declaration order.
+type Enum struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
 }
 
 // Attribute represents a class attribute.
 type Attribute struct {
-	Name string
-	Type string
+	Visibility string `json:"visibility"` // "+", "-", "#", "~", or empty
+	Name       string `json:"name"`
+	Type       string `json:"type"`
 }
 
 // Method represents a class method.
 type Method struct {
-	Name       string
-	ReturnType string
+	Visibility string      `json:"visibility"` // "+", "-", "#", "~", or empty
+	Name       string      `json:"name"`
+	Parameters []Attribute `json:"parameters"`
+	ReturnType string      `json:"returnType"`
 }
 
+// RelationshipKind is the semantics of a relationship, derived from its arrow.
+type RelationshipKind string
+
+const (
+	Association RelationshipKind = "association" // --, -->
+	Inheritance RelationshipKind = "inheritance" // <|--, --|>, <|.., ..|>
+	Composition RelationshipKind = "composition" // *--, --*
+	Aggregation RelationshipKind = "aggregation" // o--, --o
+	Dependency  RelationshipKind = "dependency"  // .., ..>, <..
+)
+
 // Relationship represents an association between two entities.
+// For inheritance, From is always the subtype and To the supertype.
 type Relationship struct {
-	From string
-	To   string
-	Type string // e.g. "--", "<|--", "*--"
+	From string           `json:"from"`
+	To   string           `json:"to"`
+	Type string           `json:"type"` // e.g. "--", "<|--", "*--"
+	Kind RelationshipKind `json:"kind"`
 
 	// Multiplicities as written in PlantUML, e.g. "1", "0..*"
-	FromMultiplicity string
-	ToMultiplicity   string
+	FromMultiplicity string `json:"fromMultiplicity"`
+	ToMultiplicity   string `json:"toMultiplicity"`
 
-	Label string
+	Label string `json:"label"`
 }
 
 // Constraint represents a parsed constraint (e.g. unique, mandatory).
 type Constraint struct {
-	Kind   string // unique, mandatory, subset, etc.
-	Target string // entity or role
-	Expr   string // raw textual expression
+	Kind   string `json:"kind"`   // unique, mandatory, subset, etc.
+	Target string `json:"target"` // entity or role
+	Expr   string `json:"expr"`   // raw textual expression
 }
 
 // -----------------------------
@@ -69,6 +104,8 @@ type Parser struct {
 	scanner      *bufio.Scanner
 	model        *Model
 	currentClass *Entity
+	currentEnum  *Enum
+	lineNumber   int
 }
 
 // NewParser creates a new PlantUML parser.
@@ -77,8 +114,10 @@ func NewParser(r io.Reader) *Parser {
 		scanner: bufio.NewScanner(r),
 		model: &Model{
 			Entities:      make(map[string]*Entity),
+			Enums:         make(map[string]*Enum),
 			Relationships: []*Relationship{},
 			Constraints:   []*Constraint{},
+			Warnings:      []ParseWarning{},
 		},
 	}
 }
@@ -86,6 +125,7 @@ func NewParser(r io.Reader) *Parser {
 // Parse reads the input and returns a parsed model.
 func (p *Parser) Parse() (*Model, error) {
 	for p.scanner.Scan() {
+		p.lineNumber++
 		line := strings.TrimSpace(p.scanner.Text())
 
 		// Ignore empty lines and directives
@@ -93,18 +133,25 @@ func (p *Parser) Parse() (*Model, error) {
 			continue
 		}
 
+		// Inside enum body
+		if p.currentEnum != nil {
+			parseEnumValues(line, p)
+			continue
+		}
+
 		// End of class body
 		if line == "}" {
 			p.currentClass = nil
 			continue
 		}
 
-		// Inside class body
+		// Inside class body; methods are tried first, as they are the
+		// more specific of the two
 		if p.currentClass != nil {
-			if parseAttribute(line, p.currentClass) {
+			if parseMethod(line, p.currentClass) {
 				continue
 			}
-			if parseMethod(line, p.currentClass) {
+			if parseAttribute(line, p.currentClass) {
 				continue
 			}
 		}
@@ -114,6 +161,11 @@ func (p *Parser) Parse() (*Model, error) {
 			continue
 		}
 
+		// Enum declaration
+		if parseEnum(line, p) {
+			continue
+		}
+
 		// Relationship declaration (with multiplicities)
 		if parseRelationship(line, p.model) {
 			continue
@@ -123,12 +175,19 @@ func (p *Parser) Parse() (*Model, error) {
 		if parseConstraint(line, p.model) {
 			continue
 		}
+
+		// Malformed member, relationship, or constraint
+		if (p.currentClass != nil && !separatorRegex.MatchString(line)) || looksLikeDeclaration(line) {
+			p.warn(line)
+		}
 	}
 
 	if err := p.scanner.Err(); err != nil {
 		return nil, err
 	}
 
+	p.resolveAliases()
+
 	return p.model, nil
 }
 
@@ -136,7 +195,58 @@ func (p *Parser) Parse() (*Model, error) {
 // Parsing helpers
 // -----------------------------
 
-var entityRegex = regexp.MustCompile(`^(class|entity|object)\s+(\w+)\s*\{?$`)
+// resolveAliases makes relationships and constraints that refer to an
+// aliased entity by its name refer to it by its alias instead.
+func (p *Parser) resolveAliases() {
+	keys := map[string]string{}
+	for key, e := range p.model.Entities {
+		if e.Alias != "" {
+			keys[e.Name] = key
+		}
+	}
+
+	resolve := func(name string) string {
+		if _, ok := p.model.Entities[name]; ok {
+			return name
+		}
+		if key, ok := keys[name]; ok {
+			return key
+		}
+		return name
+	}
+
+	for _, r := range p.model.Relationships {
+		r.From, r.To = resolve(r.From), resolve(r.To)
+	}
+	for _, c := range p.model.Constraints {
+		c.Target = resolve(c.Target)
+	}
+}
+
+// warn records a warning for the current line.
+func (p *Parser) warn(line string) {
+	p.model.Warnings = append(p.model.Warnings, ParseWarning{
+		Line: p.lineNumber,
+		Text: line,
+	})
+}
+
+// Lines such as "A <|-= B" or "constraint unique Student" are meant as
+// relationships or constraints, even when they fail to parse.
+var declarationLikeRegex = regexp.MustCompile(`^(constraint\s|\w+\s*("[^"]*"\s*)?[^\w\s"{:])`)
+
+func looksLikeDeclaration(line string) bool {
+	return declarationLikeRegex.MatchString(line)
+}
+
+// Separators within a class body, such as "--", "..", "==", or "__"
+var separatorRegex = regexp.MustCompile(`^(--|\.\.|==|__)`)
+
+// Supports: class A, class A {, class A { }, as well as quoted names and
+// aliases, as in class "A B" as AB {
+var entityRegex = regexp.MustCompile(
+	`^(class|entity|object)\s+(?:"([^"]+)"|(\w+))(?:\s+as\s+(\w+))?\s*(\{\s*\}?)?$`,
+)
 
 func parseEntity(line string, p *Parser) bool {
 	matches := entityRegex.FindStringSubmatch(line)
@@ -144,14 +254,70 @@ func parseEntity(line string, p *Parser) bool {
 		return false
 	}
 
-	name := matches[2]
-	entity := &Entity{Name: name}
-	p.model.Entities[name] = entity
-	p.currentClass = entity
+	name, alias := matches[2]+matches[3], matches[4]
+	entity := &Entity{Name: name, Alias: alias}
+
+	key := name
+	if alias != "" {
+		key = alias
+	}
+	p.model.Entities[key] = entity
+
+	// Only a declaration with a body opens a class context
+	if strings.HasSuffix(line, "{") {
+		p.currentClass = entity
+	}
+	return true
+}
+
+// Supports: enum Color { RED GREEN BLUE }, as well as a body spanning
+// several lines
+var enumRegex = regexp.MustCompile(`^enum\s+(\w+)\s*(\{(.*))?$`)
+
+func parseEnum(line string, p *Parser) bool {
+	matches := enumRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return false
+	}
+
+	name := matches[1]
+	enum := &Enum{Name: name, Values: []string{}}
+	p.model.Enums[name] = enum
+
+	// Only an opening brace starts a body, possibly with literals
+	// following it on the same line
+	if matches[2] != "" {
+		p.currentEnum = enum
+		parseEnumValues(matches[3], p)
+	}
 	return true
 }
 
-var attributeRegex = regexp.MustCompile(`^(\w+)\s*:\s*(\w+)$`)
+var enumValueRegex = regexp.MustCompile(`^\w+$`)
+
+// parseEnumValues collects the bare literals (separated by spaces or
+// commas) in a line of an enum body, up to the closing brace.
+func parseEnumValues(line string, p *Parser) {
+	body, closed := line, false
+	if i := strings.Index(line, "}"); i >= 0 {
+		body, closed = line[:i], true
+	}
+
+	for _, value := range strings.FieldsFunc(body, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		if enumValueRegex.MatchString(value) {
+			p.currentEnum.Values = append(p.currentEnum.Values, value)
+		}
+	}
+
+	if closed {
+		p.currentEnum = nil
+	}
+}
+
+// Supports: +name : String, -id : int, #status, ~flag
+var attributeRegex = regexp.MustCompile(`^([-+#~])?\s*(\w+)\s*(:\s*(\w+(?:<.+>)?))?$`)
 
 func parseAttribute(line string, e *Entity) bool {
 	matches := attributeRegex.FindStringSubmatch(line)
@@ -160,13 +326,15 @@ func parseAttribute(line string, e *Entity) bool {
 	}
 
 	e.Attributes = append(e.Attributes, Attribute{
-		Name: matches[1],
-		Type: matches[2],
+		Visibility: matches[1],
+		Name:       matches[2],
+		Type:       matches[4],
 	})
 	return true
 }
 
-var methodRegex = regexp.MustCompile(`^(\w+)\(.*\)\s*:\s*(\w+)$`)
+// Supports: +enrol() : void, -move(dx : int, dy : int) : void, reset()
+var methodRegex = regexp.MustCompile(`^([-+#~])?\s*(\w+)\((.*)\)\s*(:\s*(\w+(?:<.+>)?))?$`)
 
 func parseMethod(line string, e *Entity) bool {
 	matches := methodRegex.FindStringSubmatch(line)
@@ -174,18 +342,64 @@ func parseMethod(line string, e *Entity) bool {
 		return false
 	}
 
+	parameters, ok := parseParameters(matches[3])
+	if !ok {
+		return false
+	}
+
 	e.Methods = append(e.Methods, Method{
-		Name:       matches[1],
-		ReturnType: matches[2],
+		Visibility: matches[1],
+		Name:       matches[2],
+		Parameters: parameters,
+		ReturnType: matches[5],
 	})
 	return true
 }
 
+var parameterRegex = regexp.MustCompile(`^(\w+)\s*:\s*(\w+(?:<.+>)?)$`)
+
+// parseParameters parses a comma-separated list of "name : Type" pairs.
+func parseParameters(list string) ([]Attribute, bool) {
+	parameters := []Attribute{}
+	if strings.TrimSpace(list) == "" {
+		return parameters, true
+	}
+
+	for _, parameter := range strings.Split(list, ",") {
+		matches := parameterRegex.FindStringSubmatch(strings.TrimSpace(parameter))
+		if matches == nil {
+			return nil, false
+		}
+
+		parameters = append(parameters, Attribute{
+			Name: matches[1],
+			Type: matches[2],
+		})
+	}
+	return parameters, true
+}
+
 // Supports: A "1" -- "0..*" B : label
 var relationRegex = regexp.MustCompile(
-	`^(\w+)\s*("[^"]+")?\s+([-.o*<|]+)\s*("[^"]+")?\s+(\w+)(\s*:\s*(.+))?$`,
+	`^(\w+)\s*("[^"]+")?\s+([-.o*<>|]+)\s*("[^"]+")?\s+(\w+)(\s*:\s*(.+))?$`,
 )
 
+// relationshipKind derives the semantics of a relationship from its arrow.
+func relationshipKind(arrow string) RelationshipKind {
+	switch {
+	case strings.Contains(arrow, "<|") || strings.Contains(arrow, "|>"):
+		return Inheritance
+	case strings.HasPrefix(arrow, "*") || strings.HasSuffix(arrow, "*"):
+		return Composition
+	case strings.HasPrefix(arrow, "o") || strings.HasSuffix(arrow, "o"):
+		return Aggregation
+	case strings.Contains(arrow, ".."):
+		return Dependency
+	default:
+		return Association
+	}
+}
+
 func parseRelationship(line string, model *Model) bool {
 	matches := relationRegex.FindStringSubmatch(line)
 	if matches == nil {
@@ -200,6 +414,13 @@ func parseRelationship(line string, model *Model) bool {
 		To:               matches[5],
 		Label:            matches[7],
 	}
+	rel.Kind = relationshipKind(rel.Type)
+
+	// Orienting inheritance from the subtype towards the supertype
+	if strings.HasPrefix(rel.Type, "<|") {
+		rel.From, rel.To = rel.To, rel.From
+		rel.FromMultiplicity, rel.ToMultiplicity = rel.ToMultiplicity, rel.FromMultiplicity
+	}
 
 	model.Relationships = append(model.Relationships, rel)
 	return true
@@ -221,6 +442,311 @@ func parseConstraint(line string, model *Model) bool {
 	return true
 }
 
+// -----------------------------
+// Validation
+// -----------------------------
+
+// Validate checks that every relationship connects declared entities, and
+// that every constraint targets a declared entity. An error is returned for
+// each unknown reference, in declaration order. The model is not modified.
+func (m *Model) Validate() []error {
+	var errs []error
+
+	for _, r := range m.Relationships {
+		for _, end := range []string{r.From, r.To} {
+			if _, ok := m.Entities[end]; !ok {
+				errs = append(errs, fmt.Errorf("relationship %s %s %s: unknown entity %q", r.From, r.Type, r.To, end))
+			}
+		}
+	}
+
+	for _, c := range m.Constraints {
+		if _, ok := m.Entities[c.Target]; !ok {
+			errs = append(errs, fmt.Errorf("constraint %s on %s: unknown entity %q", c.Kind, c.Target, c.Target))
+		}
+	}
+
+	return errs
+}
+
+// DetectCycles reports each cycle in the inheritance hierarchy, such as
+// A --|> B together with B --|> A, as an error listing the entities on the
+// cycle. Entities are visited in order of name, so the output is deterministic.
+func (m *Model) DetectCycles() []error {
+	supertypes := map[string][]string{}
+	for _, r := range m.Relationships {
+		if r.Kind == Inheritance {
+			supertypes[r.From] = append(supertypes[r.From], r.To)
+		}
+	}
+
+	names := make([]string, 0, len(supertypes))
+	for name := range supertypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	path := []string{}
+
+	var errs []error
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					cycle := append(append([]string{}, path[i:]...), name)
+					errs = append(errs, fmt.Errorf("inheritance cycle: %s", strings.Join(cycle, " --|> ")))
+					break
+				}
+			}
+			return
+		case visited:
+			return
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, supertype := range supertypes[name] {
+			visit(supertype)
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+
+	return errs
+}
+
+// ValidateTypes checks the attribute types and method return types of all
+// entities against an allow-list of type names. Generic wrappers such as
+// List<Order> are checked on their base type (Order), and wrappers with
+// several arguments, such as Map<String, Order>, on each of them, while
+// untyped attributes and methods are skipped. An error is returned for each
+// disallowed type, ordered by entity name and then by declaration.
+func (m *Model) ValidateTypes(allowed []string) []error {
+	allowedTypes := make(map[string]bool, len(allowed))
+	for _, t := range allowed {
+		allowedTypes[t] = true
+	}
+
+	names := make([]string, 0, len(m.Entities))
+	for name := range m.Entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		e := m.Entities[name]
+		for _, a := range e.Attributes {
+			for _, t := range baseTypes(a.Type) {
+				if !allowedTypes[t] {
+					errs = append(errs, fmt.Errorf("%s.%s: type %q is not allowed", name, a.Name, t))
+				}
+			}
+		}
+		for _, mt := range e.Methods {
+			for _, t := range baseTypes(mt.ReturnType) {
+				if !allowedTypes[t] {
+					errs = append(errs, fmt.Errorf("%s.%s(): return type %q is not allowed", name, mt.Name, t))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// baseTypes strips generic wrappers from a type, returning the base types of
+// its arguments, e.g. List<Order> becomes Order, and Map<String, List<Order>>
+// becomes String and Order. An empty type has no base types.
+func baseTypes(t string) []string {
+	t = strings.TrimSpace(t)
+	open, close := strings.Index(t, "<"), strings.LastIndex(t, ">")
+	if open < 0 || close < open {
+		if t == "" {
+			return nil
+		}
+		return []string{t}
+	}
+
+	var types []string
+	for _, argument := range splitTypeArguments(t[open+1 : close]) {
+		types = append(types, baseTypes(argument)...)
+	}
+	return types
+}
+
+// splitTypeArguments splits the arguments of a generic type at the commas
+// that are not nested in another generic type.
+func splitTypeArguments(arguments string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range arguments {
+		switch r {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, arguments[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, arguments[start:])
+}
+
+// -----------------------------
+// Splitting
+// -----------------------------
+
+// SplitByEntity splits the model into one model per entity, keyed by
+// entity name. Each model contains the entity, the relationships it
+// takes part in, the constraints targeting it, and all enums. Relationships
+// spanning two entities are included in the models of both, together
+// with the entity at the other end, so each model is self-contained.
+func (m *Model) SplitByEntity() map[string]*Model {
+	models := make(map[string]*Model, len(m.Entities))
+
+	for name, entity := range m.Entities {
+		model := &Model{
+			Entities:      map[string]*Entity{name: entity},
+			Enums:         m.Enums,
+			Relationships: []*Relationship{},
+			Constraints:   []*Constraint{},
+		}
+
+		for _, r := range m.Relationships {
+			if r.From != name && r.To != name {
+				continue
+			}
+
+			model.Relationships = append(model.Relationships, r)
+
+			// Include the entities at both ends
+			for _, end := range []string{r.From, r.To} {
+				if e, ok := m.Entities[end]; ok {
+					model.Entities[end] = e
+				}
+			}
+		}
+
+		for _, c := range m.Constraints {
+			if c.Target == name {
+				model.Constraints = append(model.Constraints, c)
+			}
+		}
+
+		models[name] = model
+	}
+
+	return models
+}
+
+// -----------------------------
+// JSON export
+// -----------------------------
+
+// MarshalJSON encodes the model as JSON, in the following structure:
+//
+//	{
+//	  "entities": [
+//	    {
+//	      "name": "Student", "alias": "",
+//	      "attributes": [{"visibility": "-", "name": "name", "type": "String"}],
+//	      "methods": [
+//	        {
+//	          "visibility": "+", "name": "enrol",
+//	          "parameters": [{"visibility": "", "name": "year", "type": "int"}],
+//	          "returnType": "void"
+//	        }
+//	      ]
+//	    }
+//	  ],
+//	  "enums": [{"name": "Color", "values": ["RED", "GREEN", "BLUE"]}],
+//	  "relationships": [
+//	    {
+//	      "from": "Student", "to": "Programme", "type": "--", "kind": "association",
+//	      "fromMultiplicity": "0..*", "toMultiplicity": "1",
+//	      "label": "studies"
+//	    }
+//	  ],
+//	  "constraints": [{"kind": "unique", "target": "Student", "expr": "name"}]
+//	}
+//
+// Entities and enums are emitted as arrays sorted by name, and empty lists as
+// [] rather than null, so the output is deterministic for diffing.
+func (m *Model) MarshalJSON() ([]byte, error) {
+	names := make([]string, 0, len(m.Entities))
+	for name := range m.Entities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entities := make([]Entity, 0, len(names))
+	for _, name := range names {
+		e := *m.Entities[name]
+		if e.Attributes == nil {
+			e.Attributes = []Attribute{}
+		}
+		e.Methods = append([]Method{}, e.Methods...)
+		for i := range e.Methods {
+			if e.Methods[i].Parameters == nil {
+				e.Methods[i].Parameters = []Attribute{}
+			}
+		}
+		entities = append(entities, e)
+	}
+
+	enumNames := make([]string, 0, len(m.Enums))
+	for name := range m.Enums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+
+	enums := make([]*Enum, 0, len(enumNames))
+	for _, name := range enumNames {
+		enums = append(enums, m.Enums[name])
+	}
+
+	relationships := m.Relationships
+	if relationships == nil {
+		relationships = []*Relationship{}
+	}
+
+	constraints := m.Constraints
+	if constraints == nil {
+		constraints = []*Constraint{}
+	}
+
+	return json.Marshal(struct {
+		Entities      []Entity        `json:"entities"`
+		Enums         []*Enum         `json:"enums"`
+		Relationships []*Relationship `json:"relationships"`
+		Constraints   []*Constraint   `json:"constraints"`
+	}{entities, enums, relationships, constraints})
+}
+
+// ToJSON writes the JSON encoding of the model (see MarshalJSON),
+// indented for readability, to w.
+func (m *Model) ToJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
 // -----------------------------
 // Utility
 // -----------------------------
@@ -230,23 +756,33 @@ func (m *Model) DebugPrint() {
 	for _, e := range m.Entities {
 		fmt.Println(" -", e.Name)
 		for _, a := range e.Attributes {
-			fmt.Printf("    attr %s : %s\n", a.Name, a.Type)
+			fmt.Printf("    attr %s%s : %s\n", a.Visibility, a.Name, a.Type)
 		}
 		for _, m := range e.Methods {
-			fmt.Printf("    method %s() : %s\n", m.Name, m.ReturnType)
+			parameters := make([]string, 0, len(m.Parameters))
+			for _, p := range m.Parameters {
+				parameters = append(parameters, p.Name+" : "+p.Type)
+			}
+			fmt.Printf("    method %s%s(%s) : %s\n", m.Visibility, m.Name, strings.Join(parameters, ", "), m.ReturnType)
 		}
 	}
 
+	fmt.Println("Enums:")
+	for _, e := range m.Enums {
+		fmt.Printf(" - %s { %s }\n", e.Name, strings.Join(e.Values, " "))
+	}
+
 	fmt.Println("Relationships:")
 	for _, r := range m.Relationships {
 		fmt.Printf(
-			" - %s \"%s\" %s \"%s\" %s : %s\n",
+			" - %s \"%s\" %s \"%s\" %s : %s (%s)\n",
 			r.From,
 			r.FromMultiplicity,
 			r.Type,
 			r.ToMultiplicity,
 			r.To,
 			r.Label,
+			r.Kind,
 		)
 	}
 
@@ -254,4 +790,9 @@ func (m *Model) DebugPrint() {
 	for _, c := range m.Constraints {
 		fmt.Printf(" - %s on %s : %s\n", c.Kind, c.Target, c.Expr)
 	}
+
+	fmt.Println("Warnings:")
+	for _, w := range m.Warnings {
+		fmt.Printf(" - line %d: %s\n", w.Line, w.Text)
+	}
 }
@@ -0,0 +1,367 @@
+package plantuml
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parse parses the given PlantUML source, failing the test on an error.
+func parse(t *testing.T, source string) *Model {
+	t.Helper()
+
+	model, err := NewParser(strings.NewReader(source)).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return model
+}
+
+// -----------------------------
+// Entities
+// -----------------------------
+
+func TestParseEntities(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   map[string]*Entity
+	}{
+		{"bare class", "class Student", map[string]*Entity{
+			"Student": {Name: "Student"},
+		}},
+		{"entity and object", "entity Course {\n}\nobject Room { }", map[string]*Entity{
+			"Course": {Name: "Course"},
+			"Room":   {Name: "Room"},
+		}},
+		{"quoted name with alias", `class "Study Programme" as SP {` + "\n}", map[string]*Entity{
+			"SP": {Name: "Study Programme", Alias: "SP"},
+		}},
+		{"attributes", "class Student {\n+name : String\n-id : int\n#status\n~tags : List<String>\n}", map[string]*Entity{
+			"Student": {Name: "Student", Attributes: []Attribute{
+				{Visibility: "+", Name: "name", Type: "String"},
+				{Visibility: "-", Name: "id", Type: "int"},
+				{Visibility: "#", Name: "status"},
+				{Visibility: "~", Name: "tags", Type: "List<String>"},
+			}},
+		}},
+		{"methods", "class Shape {\n+move(dx : int, dy : int) : void\nreset()\n}", map[string]*Entity{
+			"Shape": {Name: "Shape", Methods: []Method{
+				{Visibility: "+", Name: "move", ReturnType: "void", Parameters: []Attribute{
+					{Name: "dx", Type: "int"},
+					{Name: "dy", Type: "int"},
+				}},
+				{Name: "reset", Parameters: []Attribute{}},
+			}},
+		}},
+		{"members only within a body", "class A\nname : String", map[string]*Entity{
+			"A": {Name: "A"},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parse(t, test.source).Entities; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Entities = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// A body with only methods, or only attributes, keeps its first member, and closes on its
+// brace, without the members leaking into the declarations following it
+func TestParseMembersOnlyBodies(t *testing.T) {
+	model := parse(t, "class Shape {\n+area() : Real\nreset()\n}\nclass Point {\nx : int\ny : int\n}\nclass Label\nShape -- Point")
+
+	want := map[string]*Entity{
+		"Shape": {Name: "Shape", Methods: []Method{
+			{Visibility: "+", Name: "area", ReturnType: "Real", Parameters: []Attribute{}},
+			{Name: "reset", Parameters: []Attribute{}},
+		}},
+		"Point": {Name: "Point", Attributes: []Attribute{
+			{Name: "x", Type: "int"},
+			{Name: "y", Type: "int"},
+		}},
+		"Label": {Name: "Label"},
+	}
+	if !reflect.DeepEqual(model.Entities, want) {
+		t.Errorf("Entities = %+v, want %+v", model.Entities, want)
+	}
+	if len(model.Relationships) != 1 || len(model.Warnings) != 0 {
+		t.Errorf("Relationships = %d, Warnings = %+v; want 1 relationship and no warnings", len(model.Relationships), model.Warnings)
+	}
+}
+
+// -----------------------------
+// Enums
+// -----------------------------
+
+func TestParseEnums(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"single line", "enum Color { RED GREEN BLUE }", []string{"RED", "GREEN", "BLUE"}},
+		{"comma separated", "enum Color {RED, GREEN,BLUE}", []string{"RED", "GREEN", "BLUE"}},
+		{"several lines", "enum Color {\nRED\nGREEN, BLUE\n}", []string{"RED", "GREEN", "BLUE"}},
+		{"empty", "enum Color {\n}", []string{}},
+		{"without body", "enum Color\nclass Car {\nname : String\n}", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enum := parse(t, test.source).Enums["Color"]
+			if enum == nil {
+				t.Fatalf("enum Color not parsed")
+			}
+			if !reflect.DeepEqual(enum.Values, test.want) {
+				t.Errorf("Values = %q, want %q", enum.Values, test.want)
+			}
+		})
+	}
+}
+
+// An enum without a body does not swallow the declarations following it
+func TestParseEnumWithoutBody(t *testing.T) {
+	model := parse(t, "enum Color\nclass Car {\nname : String\n}\nCar -- Color")
+
+	car := model.Entities["Car"]
+	if car == nil || len(car.Attributes) != 1 {
+		t.Fatalf("class Car not parsed with its attribute: %+v", car)
+	}
+	if len(model.Relationships) != 1 {
+		t.Errorf("Relationships = %d, want 1", len(model.Relationships))
+	}
+}
+
+// -----------------------------
+// Relationships
+// -----------------------------
+
+func TestParseRelationships(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Relationship
+	}{
+		{"association with multiplicities and label", `Student "0..*" -- "1" Course : takes`, Relationship{
+			From: "Student", To: "Course", Type: "--", Kind: Association,
+			FromMultiplicity: "0..*", ToMultiplicity: "1", Label: "takes",
+		}},
+		{"directed association", "Student --> Course", Relationship{
+			From: "Student", To: "Course", Type: "-->", Kind: Association,
+		}},
+		{"inheritance towards the supertype", "Person <|-- Student", Relationship{
+			From: "Student", To: "Person", Type: "<|--", Kind: Inheritance,
+		}},
+		{"inheritance from the subtype", "Student --|> Person", Relationship{
+			From: "Student", To: "Person", Type: "--|>", Kind: Inheritance,
+		}},
+		{"realisation", "Student ..|> Person", Relationship{
+			From: "Student", To: "Person", Type: "..|>", Kind: Inheritance,
+		}},
+		{"composition", `University "1" *-- "1..*" Faculty`, Relationship{
+			From: "University", To: "Faculty", Type: "*--", Kind: Composition,
+			FromMultiplicity: "1", ToMultiplicity: "1..*",
+		}},
+		{"aggregation", "Course o-- Student", Relationship{
+			From: "Course", To: "Student", Type: "o--", Kind: Aggregation,
+		}},
+		{"dependency", "Course ..> Room", Relationship{
+			From: "Course", To: "Room", Type: "..>", Kind: Dependency,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := parse(t, test.line)
+			if len(model.Relationships) != 1 {
+				t.Fatalf("parsed %d relationships, want 1", len(model.Relationships))
+			}
+			if got := *model.Relationships[0]; got != test.want {
+				t.Errorf("Relationship = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseResolvesAliases(t *testing.T) {
+	model := parse(t, "class Student as S\nclass Course\nCourse -- Student\nconstraint unique on Student : id")
+
+	if got := model.Relationships[0].To; got != "S" {
+		t.Errorf("To = %q, want %q", got, "S")
+	}
+	if got := model.Constraints[0].Target; got != "S" {
+		t.Errorf("Target = %q, want %q", got, "S")
+	}
+}
+
+// -----------------------------
+// Constraints and warnings
+// -----------------------------
+
+func TestParseConstraints(t *testing.T) {
+	model := parse(t, "constraint unique on Student : id\nconstraint mandatory on Course : title")
+
+	want := []*Constraint{
+		{Kind: "unique", Target: "Student", Expr: "id"},
+		{Kind: "mandatory", Target: "Course", Expr: "title"},
+	}
+	if !reflect.DeepEqual(model.Constraints, want) {
+		t.Errorf("Constraints = %+v, want %+v", model.Constraints, want)
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []ParseWarning
+	}{
+		{"well formed", "@startuml\n' comment\nclass A {\n--\nname : String\n}\n@enduml", []ParseWarning{}},
+		{"malformed member", "class A {\nname : : String\n}", []ParseWarning{{Line: 2, Text: "name : : String"}}},
+		{"malformed relationship", "class A\nA <|-= B", []ParseWarning{{Line: 2, Text: "A <|-= B"}}},
+		{"malformed constraint", "constraint unique Student", []ParseWarning{{Line: 1, Text: "constraint unique Student"}}},
+		{"other text outside declarations", "title University\nskinparam monochrome true", []ParseWarning{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parse(t, test.source).Warnings; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Warnings = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------
+// Validation
+// -----------------------------
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"declared", "class Student\nclass Course\nStudent -- Course\nconstraint unique on Student : id", nil},
+		{"dangling relationship", "class Student\nStudent -- Course", []string{`relationship Student -- Course: unknown entity "Course"`}},
+		{"unknown constraint target", "class Student\nconstraint mandatory on Course : title", []string{`constraint mandatory on Course: unknown entity "Course"`}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := parse(t, test.source)
+			relationships := len(model.Relationships)
+
+			var got []string
+			for _, err := range model.Validate() {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Validate() = %q, want %q", got, test.want)
+			}
+			if len(model.Relationships) != relationships {
+				t.Errorf("Validate() changed the relationships")
+			}
+		})
+	}
+}
+
+func TestBaseTypes(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want []string
+	}{
+		{"", nil},
+		{"String", []string{"String"}},
+		{" List<Order> ", []string{"Order"}},
+		{"Map<String, Order>", []string{"String", "Order"}},
+		{"Map<String, List<Order>>", []string{"String", "Order"}},
+		{"Map<Map<String, int>, Set<Order>>", []string{"String", "int", "Order"}},
+	}
+
+	for _, test := range tests {
+		if got := baseTypes(test.typ); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("baseTypes(%q) = %q, want %q", test.typ, got, test.want)
+		}
+	}
+}
+
+func TestValidateTypes(t *testing.T) {
+	allowed := []string{"String", "int", "void", "Order", "Customer"}
+
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{"conforming", "class Customer {\nname : String\norders : Map<String, Order>\nplace(o : Order) : void\n}\nclass Order {\nitems : List<int>\n}", nil},
+		{"disallowed attribute type", "class Customer {\nbalance : Money\n}", []string{`Customer.balance: type "Money" is not allowed`}},
+		{"disallowed generic argument", "class Customer {\norders : Map<Date, Order>\n}", []string{`Customer.orders: type "Date" is not allowed`}},
+		{"disallowed return types in entity order", "class Order {\ntotal() : Money\n}\nclass Customer {\nbalance() : List<Money>\n}", []string{
+			`Customer.balance(): return type "Money" is not allowed`,
+			`Order.total(): return type "Money" is not allowed`,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, err := range parse(t, test.source).ValidateTypes(allowed) {
+				got = append(got, err.Error())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ValidateTypes() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------
+// JSON
+// -----------------------------
+
+func TestModelJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"empty", "", `{"entities":[],"enums":[],"relationships":[],"constraints":[]}`},
+		{"university", "class Student {\n+name : String\n+enrol(c : Course) : void\n}\nclass Course\n" +
+			"Student \"1..*\" --> \"0..*\" Course : takes\nconstraint unique on Student : name\nenum Level {\nBSc\nMSc\n}",
+			`{"entities":[` +
+				`{"name":"Course","alias":"","attributes":[],"methods":[]},` +
+				`{"name":"Student","alias":"","attributes":[{"visibility":"+","name":"name","type":"String"}],` +
+				`"methods":[{"visibility":"+","name":"enrol","parameters":[{"visibility":"","name":"c","type":"Course"}],"returnType":"void"}]}],` +
+				`"enums":[{"name":"Level","values":["BSc","MSc"]}],` +
+				`"relationships":[{"from":"Student","to":"Course","type":"--\u003e","kind":"association","fromMultiplicity":"1..*","toMultiplicity":"0..*","label":"takes"}],` +
+				`"constraints":[{"kind":"unique","target":"Student","expr":"name"}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := parse(t, test.source)
+
+			got, err := json.Marshal(model)
+			if err != nil {
+				t.Fatalf("MarshalJSON() error = %v", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, test.want)
+			}
+
+			var written, compacted bytes.Buffer
+			if err := model.ToJSON(&written); err != nil {
+				t.Fatalf("ToJSON() error = %v", err)
+			}
+			if err := json.Compact(&compacted, written.Bytes()); err != nil || compacted.String() != test.want {
+				t.Errorf("ToJSON() = %s, want %s", written.String(), test.want)
+			}
+		})
+	}
+}